@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/shinhauhuang/go-fsm/fsm"
+	"github.com/shinhauhuang/go-fsm/fsm/scxml"
+)
+
+// runDiff implements "fsmctl diff".
+func runDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	oldPath := flags.String("old", "", "SCXML file of the currently deployed definition")
+	newPath := flags.String("new", "", "SCXML file of the proposed definition")
+	instances := flags.Bool("instances", false, "query the database for live machines in removed or dead states")
+	flags.Parse(args)
+
+	if *oldPath == "" || *newPath == "" {
+		return errors.New("diff: -old and -new are required")
+	}
+	oldDef, err := scxml.ReadFile(*oldPath)
+	if err != nil {
+		return err
+	}
+	newDef, err := scxml.ReadFile(*newPath)
+	if err != nil {
+		return err
+	}
+
+	d := fsm.Diff(oldDef, newDef)
+	if d.Empty() {
+		fmt.Println("Definitions are structurally identical.")
+		return nil
+	}
+	for _, s := range d.AddedStates {
+		fmt.Printf("+ state %s\n", s)
+	}
+	for _, s := range d.RemovedStates {
+		fmt.Printf("- state %s\n", s)
+	}
+	for _, t := range d.AddedTransitions {
+		fmt.Printf("+ transition %s --%s--> %s\n", t.From, t.Event, t.To)
+	}
+	for _, t := range d.RemovedTransitions {
+		fmt.Printf("- transition %s --%s--> %s\n", t.From, t.Event, t.To)
+	}
+	for _, c := range d.ChangedTargets {
+		fmt.Printf("~ transition %s --%s--> %s (was %s)\n", c.From, c.Event, c.NewTo, c.OldTo)
+	}
+	for _, s := range d.DeadStates {
+		fmt.Printf("! state %s can no longer be left\n", s)
+	}
+
+	if !*instances {
+		return nil
+	}
+
+	client, err := openClient()
+	if err != nil {
+		return fmt.Errorf("failed opening connection to database: %w", err)
+	}
+	defer client.Close()
+
+	impact, err := d.Impact(context.Background(), client, oldDef)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n%d live machine(s) affected\n", impact.Machines)
+	for _, s := range impact.States() {
+		fmt.Printf("  %s: %d\n", s, impact.ByState[s])
+	}
+	return nil
+}
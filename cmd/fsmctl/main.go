@@ -0,0 +1,89 @@
+// Command fsmctl performs administrative tasks on persisted state machines.
+//
+// Usage:
+//
+//	fsmctl <command> [flags]
+//
+// Definitions are read from SCXML files. The database is configured through the same
+// DB_DRIVER and DB_DSN environment variables (or .env file) as the example application.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/joho/godotenv"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// command is a fsmctl subcommand.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"diff":    {"compare two definitions and report their impact on live machines", runDiff},
+	"migrate": {"move machines from one definition version to the next", runMigrate},
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fsmctl: ")
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: fsmctl <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
+// openClient opens an Ent client for the database configured in the environment.
+func openClient() (*ent.Client, error) {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	switch os.Getenv("DB_DRIVER") {
+	case "mariadb":
+		dsn := os.Getenv("DB_DSN")
+		if dsn == "" {
+			return nil, fmt.Errorf("DB_DRIVER is 'mariadb' but DB_DSN is not set")
+		}
+		return ent.Open("mysql", dsn)
+	case "sqlite3":
+		dsn := os.Getenv("DB_DSN")
+		if dsn == "" {
+			return nil, fmt.Errorf("DB_DRIVER is 'sqlite3' but DB_DSN is not set")
+		}
+		return ent.Open("sqlite3", dsn)
+	default:
+		return nil, fmt.Errorf("DB_DRIVER must be set to 'mariadb' or 'sqlite3'")
+	}
+}
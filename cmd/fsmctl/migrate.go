@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/shinhauhuang/go-fsm/fsm"
+	"github.com/shinhauhuang/go-fsm/fsm/scxml"
+)
+
+// stateMap is a flag.Value collecting OLD=NEW state mapping rules.
+type stateMap map[fsm.State]fsm.State
+
+func (m stateMap) String() string {
+	var rules []string
+	for from, to := range m {
+		rules = append(rules, fmt.Sprintf("%s=%s", from, to))
+	}
+	return strings.Join(rules, ",")
+}
+
+func (m stateMap) Set(value string) error {
+	for _, rule := range strings.Split(value, ",") {
+		from, to, ok := strings.Cut(rule, "=")
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("invalid mapping rule %q, expected OLD=NEW", rule)
+		}
+		m[fsm.State(from)] = fsm.State(to)
+	}
+	return nil
+}
+
+// runMigrate implements "fsmctl migrate".
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fromPath := flags.String("from", "", "SCXML file of the definition machines are currently on")
+	toPath := flags.String("to", "", "SCXML file of the definition to migrate to")
+	mapping := stateMap{}
+	flags.Var(mapping, "map", "state mapping rules OLD=NEW[,OLD=NEW...] for renamed or removed states (repeatable)")
+	unnamed := flags.Bool("unnamed", false, "also migrate machines that have no definition name recorded")
+	flags.Parse(args)
+
+	if *fromPath == "" || *toPath == "" {
+		return errors.New("migrate: -from and -to are required")
+	}
+	from, err := scxml.ReadFile(*fromPath)
+	if err != nil {
+		return err
+	}
+	to, err := scxml.ReadFile(*toPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := openClient()
+	if err != nil {
+		return fmt.Errorf("failed opening connection to database: %w", err)
+	}
+	defer client.Close()
+
+	n, err := fsm.Migrate(context.Background(), client, fsm.Migration{From: from, To: to, StateMap: mapping, Unnamed: *unnamed})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Migrated %d machine(s) of %s from version %d to %d.\n", n, from.Name, from.Version, to.Version)
+	return nil
+}
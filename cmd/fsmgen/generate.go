@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+// config controls the generated file.
+type config struct {
+	Source   string // Name of the definition file, mentioned in the header
+	Package  string
+	TypeName string
+}
+
+// named pairs a state or event value with its Go identifier.
+type named struct {
+	Ident string
+	Value string
+}
+
+// templateData is the input of fileTemplate.
+type templateData struct {
+	config
+	Definition  *fsm.Definition
+	Initial     string
+	States      []named
+	Events      []named
+	Final       []named
+	Transitions []struct{ From, Event, To string }
+	stateIdents map[fsm.State]string
+	eventIdents map[fsm.Event]string
+}
+
+// generate renders the Go source for def.
+func generate(def *fsm.Definition, cfg config) ([]byte, error) {
+	if !token.IsIdentifier(cfg.TypeName) || !token.IsExported(cfg.TypeName) {
+		return nil, fmt.Errorf("type name %q is not an exported Go identifier", cfg.TypeName)
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	if def.Initial == "" {
+		return nil, fmt.Errorf("definition has no initial state")
+	}
+
+	data := &templateData{
+		config:      cfg,
+		Definition:  def,
+		stateIdents: make(map[fsm.State]string),
+		eventIdents: make(map[fsm.Event]string),
+	}
+
+	// Event helpers become methods on a type embedding *fsm.FSM, so they must not shadow its methods.
+	reserved := make(map[string]bool)
+	fsmType := reflect.TypeOf(&fsm.FSM{})
+	for i := 0; i < fsmType.NumMethod(); i++ {
+		reserved[fsmType.Method(i).Name] = true
+	}
+	reserved["FSM"] = true
+	reserved["VisitState"] = true
+	reserved["VisitEvent"] = true
+
+	// Every top-level name of the generated file, mapped to what declares it.
+	declared := make(map[string]string)
+	declare := func(name, what string) error {
+		if other, ok := declared[name]; ok {
+			return fmt.Errorf("%s and %s both generate identifier %s", other, what, name)
+		}
+		declared[name] = what
+		return nil
+	}
+	for _, suffix := range []string{"", "Definition", "StateVisitor", "EventVisitor"} {
+		if err := declare(cfg.TypeName+suffix, "type "+cfg.TypeName); err != nil {
+			return nil, err
+		}
+	}
+	for _, prefix := range []string{"New", "Load"} {
+		if err := declare(prefix+cfg.TypeName, "type "+cfg.TypeName); err != nil {
+			return nil, err
+		}
+	}
+	for _, kind := range []string{"State", "Event"} {
+		if err := declare("Visit"+cfg.TypeName+kind, "type "+cfg.TypeName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Identifiers also name visitor and event methods, so they must be unique per kind.
+	seen := make(map[string]string)
+	claim := func(kind, value, prefix string) (string, error) {
+		ident := identifier(value)
+		if ident == "" || !unicode.IsLetter([]rune(ident)[0]) {
+			return "", fmt.Errorf("%s %q cannot be converted into a Go identifier", kind, value)
+		}
+		key := kind + " " + ident
+		if other, ok := seen[key]; ok {
+			return "", fmt.Errorf("%s %q and %q both map to identifier %s", kind, other, value, ident)
+		}
+		seen[key] = value
+		if err := declare(prefix+ident, fmt.Sprintf("%s %q", kind, value)); err != nil {
+			return "", err
+		}
+		return ident, nil
+	}
+
+	for _, s := range def.AllStates() {
+		ident, err := claim("state", string(s), cfg.TypeName)
+		if err != nil {
+			return nil, err
+		}
+		data.stateIdents[s] = ident
+		data.States = append(data.States, named{Ident: ident, Value: string(s)})
+	}
+	data.Initial = data.stateIdents[def.Initial]
+	for _, s := range def.Final {
+		data.Final = append(data.Final, named{Ident: data.stateIdents[s], Value: string(s)})
+	}
+	for _, t := range def.Transitions {
+		if _, ok := data.eventIdents[t.Event]; !ok {
+			ident, err := claim("event", string(t.Event), cfg.TypeName+"Event")
+			if err != nil {
+				return nil, err
+			}
+			if reserved[ident] {
+				return nil, fmt.Errorf("event %q would generate method %s, which clashes with a method of fsm.FSM", t.Event, ident)
+			}
+			data.eventIdents[t.Event] = ident
+			data.Events = append(data.Events, named{Ident: ident, Value: string(t.Event)})
+		}
+		data.Transitions = append(data.Transitions, struct{ From, Event, To string }{
+			From:  data.stateIdents[t.From],
+			Event: data.eventIdents[t.Event],
+			To:    data.stateIdents[t.To],
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not compile: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// identifier converts a state or event name such as "in_progress" or "LOCKED" into
+// an exported Go identifier ("InProgress", "Locked").
+func identifier(name string) string {
+	var b strings.Builder
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		runes := []rune(w)
+		// Keep camelCase words as they are, but normalise all-caps words.
+		if strings.ToUpper(w) == w {
+			runes = []rune(strings.ToLower(w))
+		}
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by fsmgen{{with .Source}} from {{.}}{{end}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+// States of the {{.TypeName}} machine.
+const (
+{{- range .States}}
+	{{$.TypeName}}{{.Ident}} fsm.State = {{printf "%q" .Value}}
+{{- end}}
+)
+
+// Events of the {{.TypeName}} machine.
+const (
+{{- range .Events}}
+	{{$.TypeName}}Event{{.Ident}} fsm.Event = {{printf "%q" .Value}}
+{{- end}}
+)
+
+// {{.TypeName}}Definition returns the definition of the {{.TypeName}} machine.
+func {{.TypeName}}Definition() *fsm.Definition {
+	return &fsm.Definition{
+		Name:    {{printf "%q" .Definition.Name}},
+		Version: {{.Definition.Version}},
+		Initial: {{.TypeName}}{{.Initial}},
+		States: []fsm.State{
+		{{- range .States}}
+			{{$.TypeName}}{{.Ident}},
+		{{- end}}
+		},
+		{{- if .Final}}
+		Final: []fsm.State{
+		{{- range .Final}}
+			{{$.TypeName}}{{.Ident}},
+		{{- end}}
+		},
+		{{- end}}
+		Transitions: []fsm.Transition{
+		{{- range .Transitions}}
+			{From: {{$.TypeName}}{{.From}}, Event: {{$.TypeName}}Event{{.Event}}, To: {{$.TypeName}}{{.To}}},
+		{{- end}}
+		},
+	}
+}
+
+// {{.TypeName}} is a typed wrapper around an fsm.FSM running the {{.TypeName}} definition.
+type {{.TypeName}} struct {
+	*fsm.FSM
+}
+
+// New{{.TypeName}} creates a {{.TypeName}} machine, loading its state if machineID already exists.
+// See fsm.NewFSMFromDefinition.
+func New{{.TypeName}}(ctx context.Context, client *ent.Client, machineID string) (*{{.TypeName}}, error) {
+	f, err := fsm.NewFSMFromDefinition(ctx, client, machineID, {{.TypeName}}Definition())
+	if err != nil {
+		return nil, err
+	}
+	return &{{.TypeName}}{FSM: f}, nil
+}
+
+// Load{{.TypeName}} loads an existing {{.TypeName}} machine. See fsm.LoadFSMFromDefinition.
+func Load{{.TypeName}}(ctx context.Context, client *ent.Client, machineID string) (*{{.TypeName}}, error) {
+	f, err := fsm.LoadFSMFromDefinition(ctx, client, machineID, {{.TypeName}}Definition())
+	if err != nil {
+		return nil, err
+	}
+	return &{{.TypeName}}{FSM: f}, nil
+}
+{{range .Events}}
+// {{.Ident}} sends the {{printf "%q" .Value}} event to the machine.
+func (m *{{$.TypeName}}) {{.Ident}}(ctx context.Context, args ...interface{}) error {
+	return m.Transition(ctx, {{$.TypeName}}Event{{.Ident}}, args...)
+}
+{{end}}
+// {{.TypeName}}StateVisitor has one method per state of the {{.TypeName}} machine.
+// Implementations stop compiling when a state is added, which makes state switches exhaustive.
+type {{.TypeName}}StateVisitor interface {
+{{- range .States}}
+	{{.Ident}}() error
+{{- end}}
+}
+
+// Visit{{.TypeName}}State calls the visitor method matching state.
+func Visit{{.TypeName}}State(state fsm.State, v {{.TypeName}}StateVisitor) error {
+	switch state {
+{{- range .States}}
+	case {{$.TypeName}}{{.Ident}}:
+		return v.{{.Ident}}()
+{{- end}}
+	default:
+		return fmt.Errorf("unknown {{.TypeName}} state %q", state)
+	}
+}
+
+// VisitState calls the visitor method matching the machine's current state.
+func (m *{{.TypeName}}) VisitState(v {{.TypeName}}StateVisitor) error {
+	return Visit{{.TypeName}}State(m.CurrentState(), v)
+}
+
+// {{.TypeName}}EventVisitor has one method per event of the {{.TypeName}} machine.
+// Implementations stop compiling when an event is added, which makes event switches exhaustive.
+type {{.TypeName}}EventVisitor interface {
+{{- range .Events}}
+	{{.Ident}}() error
+{{- end}}
+}
+
+// Visit{{.TypeName}}Event calls the visitor method matching event.
+func Visit{{.TypeName}}Event(event fsm.Event, v {{.TypeName}}EventVisitor) error {
+	switch event {
+{{- range .Events}}
+	case {{$.TypeName}}Event{{.Ident}}:
+		return v.{{.Ident}}()
+{{- end}}
+	default:
+		return fmt.Errorf("unknown {{.TypeName}} event %q", event)
+	}
+}
+`))
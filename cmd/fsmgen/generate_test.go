@@ -0,0 +1,160 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+// defineTurnstile returns the turnstile definition used by the example application.
+func defineTurnstile() *fsm.Definition {
+	return &fsm.Definition{
+		Name:    "turnstile",
+		Version: 2,
+		Initial: "LOCKED",
+		Final:   []fsm.State{"out_of_order"},
+		Transitions: []fsm.Transition{
+			{From: "LOCKED", Event: "COIN", To: "UNLOCKED"},
+			{From: "UNLOCKED", Event: "PUSH", To: "LOCKED"},
+			{From: "UNLOCKED", Event: "break-down", To: "out_of_order"},
+		},
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"LOCKED":        "Locked",
+		"in_progress":   "InProgress",
+		"order.created": "OrderCreated",
+		"break-down":    "BreakDown",
+		"waitingForPay": "WaitingForPay",
+		"step 2":        "Step2",
+	}
+	for in, expected := range tests {
+		if got := identifier(in); got != expected {
+			t.Errorf("identifier(%q): expected %s, got %s", in, expected, got)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("Generated code", func(t *testing.T) {
+		src, err := generate(defineTurnstile(), config{Source: "turnstile.scxml", Package: "main", TypeName: "Turnstile"})
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		typeCheck(t, src)
+
+		code := string(src)
+		expected := []string{
+			"// Code generated by fsmgen from turnstile.scxml. DO NOT EDIT.",
+			`TurnstileLocked     fsm.State = "LOCKED"`,
+			`TurnstileOutOfOrder fsm.State = "out_of_order"`,
+			`TurnstileEventBreakDown fsm.Event = "break-down"`,
+			"func TurnstileDefinition() *fsm.Definition {",
+			"Version: 2,",
+			"Initial: TurnstileLocked,",
+			"{From: TurnstileUnlocked, Event: TurnstileEventPush, To: TurnstileLocked},",
+			"func NewTurnstile(ctx context.Context, client *ent.Client, machineID string) (*Turnstile, error) {",
+			"func (m *Turnstile) Coin(ctx context.Context, args ...interface{}) error {",
+			"type TurnstileStateVisitor interface {",
+			"\tOutOfOrder() error",
+			"func VisitTurnstileEvent(event fsm.Event, v TurnstileEventVisitor) error {",
+		}
+		for _, s := range expected {
+			if !strings.Contains(code, s) {
+				t.Errorf("Expected generated code to contain %q\n%s", s, code)
+			}
+		}
+	})
+
+	t.Run("Event clashing with an FSM method", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial:     "a",
+			Transitions: []fsm.Transition{{From: "a", Event: "transition", To: "b"}},
+		}
+		_, err := generate(def, config{Package: "main", TypeName: "Machine"})
+		if err == nil || !strings.Contains(err.Error(), "clashes") {
+			t.Errorf("Expected clash error, got %v", err)
+		}
+	})
+
+	t.Run("States mapping to the same identifier", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial:     "in-progress",
+			Transitions: []fsm.Transition{{From: "in-progress", Event: "go", To: "in_progress"}},
+		}
+		if _, err := generate(def, config{Package: "main", TypeName: "Machine"}); err == nil {
+			t.Errorf("Expected identifier collision error, got nil")
+		}
+	})
+
+	t.Run("State and event with the same name", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial: "closed",
+			Transitions: []fsm.Transition{
+				{From: "closed", Event: "open", To: "open"},
+				{From: "open", Event: "close", To: "closed"},
+			},
+		}
+		src, err := generate(def, config{Package: "door", TypeName: "Door"})
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		typeCheck(t, src)
+	})
+
+	t.Run("State clashing with a generated declaration", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial:     "definition",
+			Transitions: []fsm.Transition{{From: "definition", Event: "go", To: "done"}},
+		}
+		_, err := generate(def, config{Package: "main", TypeName: "Machine"})
+		if err == nil || !strings.Contains(err.Error(), "MachineDefinition") {
+			t.Errorf("Expected identifier collision error, got %v", err)
+		}
+	})
+
+	t.Run("State clashing with an event constant", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial:     "event_go",
+			Transitions: []fsm.Transition{{From: "event_go", Event: "go", To: "done"}},
+		}
+		_, err := generate(def, config{Package: "main", TypeName: "Machine"})
+		if err == nil || !strings.Contains(err.Error(), "MachineEventGo") {
+			t.Errorf("Expected identifier collision error, got %v", err)
+		}
+	})
+
+	t.Run("Invalid type name", func(t *testing.T) {
+		if _, err := generate(defineTurnstile(), config{Package: "main", TypeName: "turnstile"}); err == nil {
+			t.Errorf("Expected error for unexported type name, got nil")
+		}
+	})
+}
+
+// sourceImporter type-checks imported packages from source; it is shared so that the fsm and
+// ent packages are checked once per test run.
+var (
+	typeCheckFset  = token.NewFileSet()
+	sourceImporter = importer.ForCompiler(typeCheckFset, "source", nil)
+)
+
+// typeCheck fails the test unless src, a generated file, type-checks against the fsm packages.
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+	file, err := parser.ParseFile(typeCheckFset, "generated.go", src, 0)
+	if err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, src)
+	}
+	conf := types.Config{Importer: sourceImporter}
+	if _, err := conf.Check(file.Name.Name, typeCheckFset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("Generated code does not type-check: %v\n%s", err, src)
+	}
+}
@@ -0,0 +1,73 @@
+// Command fsmgen generates typed Go code for a state machine definition.
+//
+// It reads an SCXML definition and emits typed state and event constants, a definition
+// constructor, a wrapper type with one method per event, and visitor interfaces that make
+// switches over states and events exhaustive at compile time. It is meant to be run by
+// go generate:
+//
+//	//go:generate go run github.com/shinhauhuang/go-fsm/cmd/fsmgen -in turnstile.scxml
+//
+// Flags:
+//
+//	-in    SCXML definition file (required)
+//	-out   output file (default: <in without extension>_fsm.go)
+//	-pkg   package name (default: $GOPACKAGE)
+//	-type  name of the generated machine type (default: derived from the definition name)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shinhauhuang/go-fsm/fsm/scxml"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fsmgen: ")
+
+	in := flag.String("in", "", "SCXML definition file")
+	out := flag.String("out", "", "output file (default: <in>_fsm.go)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	typeName := flag.String("type", "", "name of the generated machine type (default: derived from the definition name)")
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *pkg == "" {
+		log.Fatal("-pkg is required when not run by go generate")
+	}
+	if *out == "" {
+		*out = strings.TrimSuffix(*in, filepath.Ext(*in)) + "_fsm.go"
+	}
+
+	def, err := scxml.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *typeName == "" {
+		if def.Name == "" {
+			log.Fatal("-type is required when the definition has no name")
+		}
+		*typeName = identifier(def.Name)
+	}
+
+	src, err := generate(def, config{
+		Source:   filepath.Base(*in),
+		Package:  *pkg,
+		TypeName: *typeName,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("fsmgen: wrote %s\n", *out)
+}
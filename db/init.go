@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/migrate"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
@@ -40,9 +41,10 @@ func main() {
 	}
 	defer client.Close()
 
-	// Run the auto migration tool to create the schema
+	// Run the auto migration tool to create the schema. Indexes removed from the schema, such as
+	// the former unique index on archived machine IDs, are dropped.
 	ctx := context.Background()
-	if err := client.Schema.Create(ctx); err != nil {
+	if err := client.Schema.Create(ctx, migrate.WithDropIndex(true)); err != nil {
 		log.Fatalf("failed creating schema resources: %v", err)
 	}
 
@@ -0,0 +1,239 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ArchivedMachine is the model entity for the ArchivedMachine schema.
+type ArchivedMachine struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// MachineID holds the value of the "machine_id" field.
+	MachineID string `json:"machine_id,omitempty"`
+	// CurrentState holds the value of the "current_state" field.
+	CurrentState string `json:"current_state,omitempty"`
+	// DefinitionName holds the value of the "definition_name" field.
+	DefinitionName string `json:"definition_name,omitempty"`
+	// DefinitionVersion holds the value of the "definition_version" field.
+	DefinitionVersion int `json:"definition_version,omitempty"`
+	// Data holds the value of the "data" field.
+	Data []byte `json:"data,omitempty"`
+	// Suspended holds the value of the "suspended" field.
+	Suspended bool `json:"suspended,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// EnteredAt holds the value of the "entered_at" field.
+	EnteredAt *time.Time `json:"entered_at,omitempty"`
+	// ArchivedAt holds the value of the "archived_at" field.
+	ArchivedAt time.Time `json:"archived_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ArchivedMachineQuery when eager-loading is set.
+	Edges        ArchivedMachineEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// ArchivedMachineEdges holds the relations/edges for other nodes in the graph.
+type ArchivedMachineEdges struct {
+	// History holds the value of the history edge.
+	History []*ArchivedTransition `json:"history,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// HistoryOrErr returns the History value or an error if the edge
+// was not loaded in eager-loading.
+func (e ArchivedMachineEdges) HistoryOrErr() ([]*ArchivedTransition, error) {
+	if e.loadedTypes[0] {
+		return e.History, nil
+	}
+	return nil, &NotLoadedError{edge: "history"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ArchivedMachine) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case archivedmachine.FieldData:
+			values[i] = new([]byte)
+		case archivedmachine.FieldSuspended:
+			values[i] = new(sql.NullBool)
+		case archivedmachine.FieldID, archivedmachine.FieldDefinitionVersion:
+			values[i] = new(sql.NullInt64)
+		case archivedmachine.FieldMachineID, archivedmachine.FieldCurrentState, archivedmachine.FieldDefinitionName:
+			values[i] = new(sql.NullString)
+		case archivedmachine.FieldCreatedAt, archivedmachine.FieldUpdatedAt, archivedmachine.FieldEnteredAt, archivedmachine.FieldArchivedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ArchivedMachine fields.
+func (am *ArchivedMachine) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case archivedmachine.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			am.ID = int(value.Int64)
+		case archivedmachine.FieldMachineID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field machine_id", values[i])
+			} else if value.Valid {
+				am.MachineID = value.String
+			}
+		case archivedmachine.FieldCurrentState:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field current_state", values[i])
+			} else if value.Valid {
+				am.CurrentState = value.String
+			}
+		case archivedmachine.FieldDefinitionName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field definition_name", values[i])
+			} else if value.Valid {
+				am.DefinitionName = value.String
+			}
+		case archivedmachine.FieldDefinitionVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field definition_version", values[i])
+			} else if value.Valid {
+				am.DefinitionVersion = int(value.Int64)
+			}
+		case archivedmachine.FieldData:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field data", values[i])
+			} else if value != nil {
+				am.Data = *value
+			}
+		case archivedmachine.FieldSuspended:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field suspended", values[i])
+			} else if value.Valid {
+				am.Suspended = value.Bool
+			}
+		case archivedmachine.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				am.CreatedAt = value.Time
+			}
+		case archivedmachine.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				am.UpdatedAt = value.Time
+			}
+		case archivedmachine.FieldEnteredAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field entered_at", values[i])
+			} else if value.Valid {
+				am.EnteredAt = new(time.Time)
+				*am.EnteredAt = value.Time
+			}
+		case archivedmachine.FieldArchivedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field archived_at", values[i])
+			} else if value.Valid {
+				am.ArchivedAt = value.Time
+			}
+		default:
+			am.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ArchivedMachine.
+// This includes values selected through modifiers, order, etc.
+func (am *ArchivedMachine) Value(name string) (ent.Value, error) {
+	return am.selectValues.Get(name)
+}
+
+// QueryHistory queries the "history" edge of the ArchivedMachine entity.
+func (am *ArchivedMachine) QueryHistory() *ArchivedTransitionQuery {
+	return NewArchivedMachineClient(am.config).QueryHistory(am)
+}
+
+// Update returns a builder for updating this ArchivedMachine.
+// Note that you need to call ArchivedMachine.Unwrap() before calling this method if this ArchivedMachine
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (am *ArchivedMachine) Update() *ArchivedMachineUpdateOne {
+	return NewArchivedMachineClient(am.config).UpdateOne(am)
+}
+
+// Unwrap unwraps the ArchivedMachine entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (am *ArchivedMachine) Unwrap() *ArchivedMachine {
+	_tx, ok := am.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ArchivedMachine is not a transactional entity")
+	}
+	am.config.driver = _tx.drv
+	return am
+}
+
+// String implements the fmt.Stringer.
+func (am *ArchivedMachine) String() string {
+	var builder strings.Builder
+	builder.WriteString("ArchivedMachine(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", am.ID))
+	builder.WriteString("machine_id=")
+	builder.WriteString(am.MachineID)
+	builder.WriteString(", ")
+	builder.WriteString("current_state=")
+	builder.WriteString(am.CurrentState)
+	builder.WriteString(", ")
+	builder.WriteString("definition_name=")
+	builder.WriteString(am.DefinitionName)
+	builder.WriteString(", ")
+	builder.WriteString("definition_version=")
+	builder.WriteString(fmt.Sprintf("%v", am.DefinitionVersion))
+	builder.WriteString(", ")
+	builder.WriteString("data=")
+	builder.WriteString(fmt.Sprintf("%v", am.Data))
+	builder.WriteString(", ")
+	builder.WriteString("suspended=")
+	builder.WriteString(fmt.Sprintf("%v", am.Suspended))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(am.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(am.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := am.EnteredAt; v != nil {
+		builder.WriteString("entered_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("archived_at=")
+	builder.WriteString(am.ArchivedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ArchivedMachines is a parsable slice of ArchivedMachine.
+type ArchivedMachines []*ArchivedMachine
@@ -0,0 +1,160 @@
+// Code generated by ent, DO NOT EDIT.
+
+package archivedmachine
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the archivedmachine type in the database.
+	Label = "archived_machine"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldMachineID holds the string denoting the machine_id field in the database.
+	FieldMachineID = "machine_id"
+	// FieldCurrentState holds the string denoting the current_state field in the database.
+	FieldCurrentState = "current_state"
+	// FieldDefinitionName holds the string denoting the definition_name field in the database.
+	FieldDefinitionName = "definition_name"
+	// FieldDefinitionVersion holds the string denoting the definition_version field in the database.
+	FieldDefinitionVersion = "definition_version"
+	// FieldData holds the string denoting the data field in the database.
+	FieldData = "data"
+	// FieldSuspended holds the string denoting the suspended field in the database.
+	FieldSuspended = "suspended"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// FieldEnteredAt holds the string denoting the entered_at field in the database.
+	FieldEnteredAt = "entered_at"
+	// FieldArchivedAt holds the string denoting the archived_at field in the database.
+	FieldArchivedAt = "archived_at"
+	// EdgeHistory holds the string denoting the history edge name in mutations.
+	EdgeHistory = "history"
+	// Table holds the table name of the archivedmachine in the database.
+	Table = "archived_machines"
+	// HistoryTable is the table that holds the history relation/edge.
+	HistoryTable = "archived_transitions"
+	// HistoryInverseTable is the table name for the ArchivedTransition entity.
+	// It exists in this package in order to avoid circular dependency with the "archivedtransition" package.
+	HistoryInverseTable = "archived_transitions"
+	// HistoryColumn is the table column denoting the history relation/edge.
+	HistoryColumn = "archived_machine_history"
+)
+
+// Columns holds all SQL columns for archivedmachine fields.
+var Columns = []string{
+	FieldID,
+	FieldMachineID,
+	FieldCurrentState,
+	FieldDefinitionName,
+	FieldDefinitionVersion,
+	FieldData,
+	FieldSuspended,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+	FieldEnteredAt,
+	FieldArchivedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// MachineIDValidator is a validator for the "machine_id" field. It is called by the builders before save.
+	MachineIDValidator func(string) error
+	// DefaultDefinitionName holds the default value on creation for the "definition_name" field.
+	DefaultDefinitionName string
+	// DefaultDefinitionVersion holds the default value on creation for the "definition_version" field.
+	DefaultDefinitionVersion int
+	// DefaultSuspended holds the default value on creation for the "suspended" field.
+	DefaultSuspended bool
+	// DefaultArchivedAt holds the default value on creation for the "archived_at" field.
+	DefaultArchivedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the ArchivedMachine queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByMachineID orders the results by the machine_id field.
+func ByMachineID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMachineID, opts...).ToFunc()
+}
+
+// ByCurrentState orders the results by the current_state field.
+func ByCurrentState(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCurrentState, opts...).ToFunc()
+}
+
+// ByDefinitionName orders the results by the definition_name field.
+func ByDefinitionName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefinitionName, opts...).ToFunc()
+}
+
+// ByDefinitionVersion orders the results by the definition_version field.
+func ByDefinitionVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefinitionVersion, opts...).ToFunc()
+}
+
+// BySuspended orders the results by the suspended field.
+func BySuspended(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSuspended, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByEnteredAt orders the results by the entered_at field.
+func ByEnteredAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEnteredAt, opts...).ToFunc()
+}
+
+// ByArchivedAt orders the results by the archived_at field.
+func ByArchivedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldArchivedAt, opts...).ToFunc()
+}
+
+// ByHistoryCount orders the results by history count.
+func ByHistoryCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newHistoryStep(), opts...)
+	}
+}
+
+// ByHistory orders the results by history terms.
+func ByHistory(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newHistoryStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+func newHistoryStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(HistoryInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, HistoryTable, HistoryColumn),
+	)
+}
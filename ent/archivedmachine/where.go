@@ -0,0 +1,610 @@
+// Code generated by ent, DO NOT EDIT.
+
+package archivedmachine
+
+import (
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldID, id))
+}
+
+// MachineID applies equality check predicate on the "machine_id" field. It's identical to MachineIDEQ.
+func MachineID(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldMachineID, v))
+}
+
+// CurrentState applies equality check predicate on the "current_state" field. It's identical to CurrentStateEQ.
+func CurrentState(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldCurrentState, v))
+}
+
+// DefinitionName applies equality check predicate on the "definition_name" field. It's identical to DefinitionNameEQ.
+func DefinitionName(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldDefinitionName, v))
+}
+
+// DefinitionVersion applies equality check predicate on the "definition_version" field. It's identical to DefinitionVersionEQ.
+func DefinitionVersion(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldDefinitionVersion, v))
+}
+
+// Data applies equality check predicate on the "data" field. It's identical to DataEQ.
+func Data(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldData, v))
+}
+
+// Suspended applies equality check predicate on the "suspended" field. It's identical to SuspendedEQ.
+func Suspended(v bool) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldSuspended, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// EnteredAt applies equality check predicate on the "entered_at" field. It's identical to EnteredAtEQ.
+func EnteredAt(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldEnteredAt, v))
+}
+
+// ArchivedAt applies equality check predicate on the "archived_at" field. It's identical to ArchivedAtEQ.
+func ArchivedAt(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldArchivedAt, v))
+}
+
+// MachineIDEQ applies the EQ predicate on the "machine_id" field.
+func MachineIDEQ(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldMachineID, v))
+}
+
+// MachineIDNEQ applies the NEQ predicate on the "machine_id" field.
+func MachineIDNEQ(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldMachineID, v))
+}
+
+// MachineIDIn applies the In predicate on the "machine_id" field.
+func MachineIDIn(vs ...string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldMachineID, vs...))
+}
+
+// MachineIDNotIn applies the NotIn predicate on the "machine_id" field.
+func MachineIDNotIn(vs ...string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldMachineID, vs...))
+}
+
+// MachineIDGT applies the GT predicate on the "machine_id" field.
+func MachineIDGT(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldMachineID, v))
+}
+
+// MachineIDGTE applies the GTE predicate on the "machine_id" field.
+func MachineIDGTE(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldMachineID, v))
+}
+
+// MachineIDLT applies the LT predicate on the "machine_id" field.
+func MachineIDLT(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldMachineID, v))
+}
+
+// MachineIDLTE applies the LTE predicate on the "machine_id" field.
+func MachineIDLTE(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldMachineID, v))
+}
+
+// MachineIDContains applies the Contains predicate on the "machine_id" field.
+func MachineIDContains(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldContains(FieldMachineID, v))
+}
+
+// MachineIDHasPrefix applies the HasPrefix predicate on the "machine_id" field.
+func MachineIDHasPrefix(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldHasPrefix(FieldMachineID, v))
+}
+
+// MachineIDHasSuffix applies the HasSuffix predicate on the "machine_id" field.
+func MachineIDHasSuffix(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldHasSuffix(FieldMachineID, v))
+}
+
+// MachineIDEqualFold applies the EqualFold predicate on the "machine_id" field.
+func MachineIDEqualFold(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEqualFold(FieldMachineID, v))
+}
+
+// MachineIDContainsFold applies the ContainsFold predicate on the "machine_id" field.
+func MachineIDContainsFold(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldContainsFold(FieldMachineID, v))
+}
+
+// CurrentStateEQ applies the EQ predicate on the "current_state" field.
+func CurrentStateEQ(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldCurrentState, v))
+}
+
+// CurrentStateNEQ applies the NEQ predicate on the "current_state" field.
+func CurrentStateNEQ(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldCurrentState, v))
+}
+
+// CurrentStateIn applies the In predicate on the "current_state" field.
+func CurrentStateIn(vs ...string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldCurrentState, vs...))
+}
+
+// CurrentStateNotIn applies the NotIn predicate on the "current_state" field.
+func CurrentStateNotIn(vs ...string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldCurrentState, vs...))
+}
+
+// CurrentStateGT applies the GT predicate on the "current_state" field.
+func CurrentStateGT(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldCurrentState, v))
+}
+
+// CurrentStateGTE applies the GTE predicate on the "current_state" field.
+func CurrentStateGTE(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldCurrentState, v))
+}
+
+// CurrentStateLT applies the LT predicate on the "current_state" field.
+func CurrentStateLT(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldCurrentState, v))
+}
+
+// CurrentStateLTE applies the LTE predicate on the "current_state" field.
+func CurrentStateLTE(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldCurrentState, v))
+}
+
+// CurrentStateContains applies the Contains predicate on the "current_state" field.
+func CurrentStateContains(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldContains(FieldCurrentState, v))
+}
+
+// CurrentStateHasPrefix applies the HasPrefix predicate on the "current_state" field.
+func CurrentStateHasPrefix(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldHasPrefix(FieldCurrentState, v))
+}
+
+// CurrentStateHasSuffix applies the HasSuffix predicate on the "current_state" field.
+func CurrentStateHasSuffix(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldHasSuffix(FieldCurrentState, v))
+}
+
+// CurrentStateEqualFold applies the EqualFold predicate on the "current_state" field.
+func CurrentStateEqualFold(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEqualFold(FieldCurrentState, v))
+}
+
+// CurrentStateContainsFold applies the ContainsFold predicate on the "current_state" field.
+func CurrentStateContainsFold(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldContainsFold(FieldCurrentState, v))
+}
+
+// DefinitionNameEQ applies the EQ predicate on the "definition_name" field.
+func DefinitionNameEQ(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldDefinitionName, v))
+}
+
+// DefinitionNameNEQ applies the NEQ predicate on the "definition_name" field.
+func DefinitionNameNEQ(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldDefinitionName, v))
+}
+
+// DefinitionNameIn applies the In predicate on the "definition_name" field.
+func DefinitionNameIn(vs ...string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldDefinitionName, vs...))
+}
+
+// DefinitionNameNotIn applies the NotIn predicate on the "definition_name" field.
+func DefinitionNameNotIn(vs ...string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldDefinitionName, vs...))
+}
+
+// DefinitionNameGT applies the GT predicate on the "definition_name" field.
+func DefinitionNameGT(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldDefinitionName, v))
+}
+
+// DefinitionNameGTE applies the GTE predicate on the "definition_name" field.
+func DefinitionNameGTE(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldDefinitionName, v))
+}
+
+// DefinitionNameLT applies the LT predicate on the "definition_name" field.
+func DefinitionNameLT(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldDefinitionName, v))
+}
+
+// DefinitionNameLTE applies the LTE predicate on the "definition_name" field.
+func DefinitionNameLTE(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldDefinitionName, v))
+}
+
+// DefinitionNameContains applies the Contains predicate on the "definition_name" field.
+func DefinitionNameContains(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldContains(FieldDefinitionName, v))
+}
+
+// DefinitionNameHasPrefix applies the HasPrefix predicate on the "definition_name" field.
+func DefinitionNameHasPrefix(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldHasPrefix(FieldDefinitionName, v))
+}
+
+// DefinitionNameHasSuffix applies the HasSuffix predicate on the "definition_name" field.
+func DefinitionNameHasSuffix(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldHasSuffix(FieldDefinitionName, v))
+}
+
+// DefinitionNameEqualFold applies the EqualFold predicate on the "definition_name" field.
+func DefinitionNameEqualFold(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEqualFold(FieldDefinitionName, v))
+}
+
+// DefinitionNameContainsFold applies the ContainsFold predicate on the "definition_name" field.
+func DefinitionNameContainsFold(v string) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldContainsFold(FieldDefinitionName, v))
+}
+
+// DefinitionVersionEQ applies the EQ predicate on the "definition_version" field.
+func DefinitionVersionEQ(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionNEQ applies the NEQ predicate on the "definition_version" field.
+func DefinitionVersionNEQ(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionIn applies the In predicate on the "definition_version" field.
+func DefinitionVersionIn(vs ...int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldDefinitionVersion, vs...))
+}
+
+// DefinitionVersionNotIn applies the NotIn predicate on the "definition_version" field.
+func DefinitionVersionNotIn(vs ...int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldDefinitionVersion, vs...))
+}
+
+// DefinitionVersionGT applies the GT predicate on the "definition_version" field.
+func DefinitionVersionGT(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionGTE applies the GTE predicate on the "definition_version" field.
+func DefinitionVersionGTE(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionLT applies the LT predicate on the "definition_version" field.
+func DefinitionVersionLT(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionLTE applies the LTE predicate on the "definition_version" field.
+func DefinitionVersionLTE(v int) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldDefinitionVersion, v))
+}
+
+// DataEQ applies the EQ predicate on the "data" field.
+func DataEQ(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldData, v))
+}
+
+// DataNEQ applies the NEQ predicate on the "data" field.
+func DataNEQ(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldData, v))
+}
+
+// DataIn applies the In predicate on the "data" field.
+func DataIn(vs ...[]byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldData, vs...))
+}
+
+// DataNotIn applies the NotIn predicate on the "data" field.
+func DataNotIn(vs ...[]byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldData, vs...))
+}
+
+// DataGT applies the GT predicate on the "data" field.
+func DataGT(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldData, v))
+}
+
+// DataGTE applies the GTE predicate on the "data" field.
+func DataGTE(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldData, v))
+}
+
+// DataLT applies the LT predicate on the "data" field.
+func DataLT(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldData, v))
+}
+
+// DataLTE applies the LTE predicate on the "data" field.
+func DataLTE(v []byte) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldData, v))
+}
+
+// DataIsNil applies the IsNil predicate on the "data" field.
+func DataIsNil() predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIsNull(FieldData))
+}
+
+// DataNotNil applies the NotNil predicate on the "data" field.
+func DataNotNil() predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotNull(FieldData))
+}
+
+// SuspendedEQ applies the EQ predicate on the "suspended" field.
+func SuspendedEQ(v bool) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldSuspended, v))
+}
+
+// SuspendedNEQ applies the NEQ predicate on the "suspended" field.
+func SuspendedNEQ(v bool) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldSuspended, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// EnteredAtEQ applies the EQ predicate on the "entered_at" field.
+func EnteredAtEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldEnteredAt, v))
+}
+
+// EnteredAtNEQ applies the NEQ predicate on the "entered_at" field.
+func EnteredAtNEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldEnteredAt, v))
+}
+
+// EnteredAtIn applies the In predicate on the "entered_at" field.
+func EnteredAtIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldEnteredAt, vs...))
+}
+
+// EnteredAtNotIn applies the NotIn predicate on the "entered_at" field.
+func EnteredAtNotIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldEnteredAt, vs...))
+}
+
+// EnteredAtGT applies the GT predicate on the "entered_at" field.
+func EnteredAtGT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldEnteredAt, v))
+}
+
+// EnteredAtGTE applies the GTE predicate on the "entered_at" field.
+func EnteredAtGTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldEnteredAt, v))
+}
+
+// EnteredAtLT applies the LT predicate on the "entered_at" field.
+func EnteredAtLT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldEnteredAt, v))
+}
+
+// EnteredAtLTE applies the LTE predicate on the "entered_at" field.
+func EnteredAtLTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldEnteredAt, v))
+}
+
+// EnteredAtIsNil applies the IsNil predicate on the "entered_at" field.
+func EnteredAtIsNil() predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIsNull(FieldEnteredAt))
+}
+
+// EnteredAtNotNil applies the NotNil predicate on the "entered_at" field.
+func EnteredAtNotNil() predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotNull(FieldEnteredAt))
+}
+
+// ArchivedAtEQ applies the EQ predicate on the "archived_at" field.
+func ArchivedAtEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldEQ(FieldArchivedAt, v))
+}
+
+// ArchivedAtNEQ applies the NEQ predicate on the "archived_at" field.
+func ArchivedAtNEQ(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNEQ(FieldArchivedAt, v))
+}
+
+// ArchivedAtIn applies the In predicate on the "archived_at" field.
+func ArchivedAtIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldIn(FieldArchivedAt, vs...))
+}
+
+// ArchivedAtNotIn applies the NotIn predicate on the "archived_at" field.
+func ArchivedAtNotIn(vs ...time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldNotIn(FieldArchivedAt, vs...))
+}
+
+// ArchivedAtGT applies the GT predicate on the "archived_at" field.
+func ArchivedAtGT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGT(FieldArchivedAt, v))
+}
+
+// ArchivedAtGTE applies the GTE predicate on the "archived_at" field.
+func ArchivedAtGTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldGTE(FieldArchivedAt, v))
+}
+
+// ArchivedAtLT applies the LT predicate on the "archived_at" field.
+func ArchivedAtLT(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLT(FieldArchivedAt, v))
+}
+
+// ArchivedAtLTE applies the LTE predicate on the "archived_at" field.
+func ArchivedAtLTE(v time.Time) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.FieldLTE(FieldArchivedAt, v))
+}
+
+// HasHistory applies the HasEdge predicate on the "history" edge.
+func HasHistory() predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, HistoryTable, HistoryColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasHistoryWith applies the HasEdge predicate on the "history" edge with a given conditions (other predicates).
+func HasHistoryWith(preds ...predicate.ArchivedTransition) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(func(s *sql.Selector) {
+		step := newHistoryStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ArchivedMachine) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ArchivedMachine) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ArchivedMachine) predicate.ArchivedMachine {
+	return predicate.ArchivedMachine(sql.NotPredicates(p))
+}
@@ -0,0 +1,395 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedMachineCreate is the builder for creating a ArchivedMachine entity.
+type ArchivedMachineCreate struct {
+	config
+	mutation *ArchivedMachineMutation
+	hooks    []Hook
+}
+
+// SetMachineID sets the "machine_id" field.
+func (amc *ArchivedMachineCreate) SetMachineID(s string) *ArchivedMachineCreate {
+	amc.mutation.SetMachineID(s)
+	return amc
+}
+
+// SetCurrentState sets the "current_state" field.
+func (amc *ArchivedMachineCreate) SetCurrentState(s string) *ArchivedMachineCreate {
+	amc.mutation.SetCurrentState(s)
+	return amc
+}
+
+// SetDefinitionName sets the "definition_name" field.
+func (amc *ArchivedMachineCreate) SetDefinitionName(s string) *ArchivedMachineCreate {
+	amc.mutation.SetDefinitionName(s)
+	return amc
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (amc *ArchivedMachineCreate) SetNillableDefinitionName(s *string) *ArchivedMachineCreate {
+	if s != nil {
+		amc.SetDefinitionName(*s)
+	}
+	return amc
+}
+
+// SetDefinitionVersion sets the "definition_version" field.
+func (amc *ArchivedMachineCreate) SetDefinitionVersion(i int) *ArchivedMachineCreate {
+	amc.mutation.SetDefinitionVersion(i)
+	return amc
+}
+
+// SetNillableDefinitionVersion sets the "definition_version" field if the given value is not nil.
+func (amc *ArchivedMachineCreate) SetNillableDefinitionVersion(i *int) *ArchivedMachineCreate {
+	if i != nil {
+		amc.SetDefinitionVersion(*i)
+	}
+	return amc
+}
+
+// SetData sets the "data" field.
+func (amc *ArchivedMachineCreate) SetData(b []byte) *ArchivedMachineCreate {
+	amc.mutation.SetData(b)
+	return amc
+}
+
+// SetSuspended sets the "suspended" field.
+func (amc *ArchivedMachineCreate) SetSuspended(b bool) *ArchivedMachineCreate {
+	amc.mutation.SetSuspended(b)
+	return amc
+}
+
+// SetNillableSuspended sets the "suspended" field if the given value is not nil.
+func (amc *ArchivedMachineCreate) SetNillableSuspended(b *bool) *ArchivedMachineCreate {
+	if b != nil {
+		amc.SetSuspended(*b)
+	}
+	return amc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (amc *ArchivedMachineCreate) SetCreatedAt(t time.Time) *ArchivedMachineCreate {
+	amc.mutation.SetCreatedAt(t)
+	return amc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (amc *ArchivedMachineCreate) SetUpdatedAt(t time.Time) *ArchivedMachineCreate {
+	amc.mutation.SetUpdatedAt(t)
+	return amc
+}
+
+// SetEnteredAt sets the "entered_at" field.
+func (amc *ArchivedMachineCreate) SetEnteredAt(t time.Time) *ArchivedMachineCreate {
+	amc.mutation.SetEnteredAt(t)
+	return amc
+}
+
+// SetNillableEnteredAt sets the "entered_at" field if the given value is not nil.
+func (amc *ArchivedMachineCreate) SetNillableEnteredAt(t *time.Time) *ArchivedMachineCreate {
+	if t != nil {
+		amc.SetEnteredAt(*t)
+	}
+	return amc
+}
+
+// SetArchivedAt sets the "archived_at" field.
+func (amc *ArchivedMachineCreate) SetArchivedAt(t time.Time) *ArchivedMachineCreate {
+	amc.mutation.SetArchivedAt(t)
+	return amc
+}
+
+// SetNillableArchivedAt sets the "archived_at" field if the given value is not nil.
+func (amc *ArchivedMachineCreate) SetNillableArchivedAt(t *time.Time) *ArchivedMachineCreate {
+	if t != nil {
+		amc.SetArchivedAt(*t)
+	}
+	return amc
+}
+
+// AddHistoryIDs adds the "history" edge to the ArchivedTransition entity by IDs.
+func (amc *ArchivedMachineCreate) AddHistoryIDs(ids ...int) *ArchivedMachineCreate {
+	amc.mutation.AddHistoryIDs(ids...)
+	return amc
+}
+
+// AddHistory adds the "history" edges to the ArchivedTransition entity.
+func (amc *ArchivedMachineCreate) AddHistory(a ...*ArchivedTransition) *ArchivedMachineCreate {
+	ids := make([]int, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return amc.AddHistoryIDs(ids...)
+}
+
+// Mutation returns the ArchivedMachineMutation object of the builder.
+func (amc *ArchivedMachineCreate) Mutation() *ArchivedMachineMutation {
+	return amc.mutation
+}
+
+// Save creates the ArchivedMachine in the database.
+func (amc *ArchivedMachineCreate) Save(ctx context.Context) (*ArchivedMachine, error) {
+	amc.defaults()
+	return withHooks(ctx, amc.sqlSave, amc.mutation, amc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (amc *ArchivedMachineCreate) SaveX(ctx context.Context) *ArchivedMachine {
+	v, err := amc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (amc *ArchivedMachineCreate) Exec(ctx context.Context) error {
+	_, err := amc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (amc *ArchivedMachineCreate) ExecX(ctx context.Context) {
+	if err := amc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (amc *ArchivedMachineCreate) defaults() {
+	if _, ok := amc.mutation.DefinitionName(); !ok {
+		v := archivedmachine.DefaultDefinitionName
+		amc.mutation.SetDefinitionName(v)
+	}
+	if _, ok := amc.mutation.DefinitionVersion(); !ok {
+		v := archivedmachine.DefaultDefinitionVersion
+		amc.mutation.SetDefinitionVersion(v)
+	}
+	if _, ok := amc.mutation.Suspended(); !ok {
+		v := archivedmachine.DefaultSuspended
+		amc.mutation.SetSuspended(v)
+	}
+	if _, ok := amc.mutation.ArchivedAt(); !ok {
+		v := archivedmachine.DefaultArchivedAt()
+		amc.mutation.SetArchivedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (amc *ArchivedMachineCreate) check() error {
+	if _, ok := amc.mutation.MachineID(); !ok {
+		return &ValidationError{Name: "machine_id", err: errors.New(`ent: missing required field "ArchivedMachine.machine_id"`)}
+	}
+	if v, ok := amc.mutation.MachineID(); ok {
+		if err := archivedmachine.MachineIDValidator(v); err != nil {
+			return &ValidationError{Name: "machine_id", err: fmt.Errorf(`ent: validator failed for field "ArchivedMachine.machine_id": %w`, err)}
+		}
+	}
+	if _, ok := amc.mutation.CurrentState(); !ok {
+		return &ValidationError{Name: "current_state", err: errors.New(`ent: missing required field "ArchivedMachine.current_state"`)}
+	}
+	if _, ok := amc.mutation.DefinitionName(); !ok {
+		return &ValidationError{Name: "definition_name", err: errors.New(`ent: missing required field "ArchivedMachine.definition_name"`)}
+	}
+	if _, ok := amc.mutation.DefinitionVersion(); !ok {
+		return &ValidationError{Name: "definition_version", err: errors.New(`ent: missing required field "ArchivedMachine.definition_version"`)}
+	}
+	if _, ok := amc.mutation.Suspended(); !ok {
+		return &ValidationError{Name: "suspended", err: errors.New(`ent: missing required field "ArchivedMachine.suspended"`)}
+	}
+	if _, ok := amc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ArchivedMachine.created_at"`)}
+	}
+	if _, ok := amc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ArchivedMachine.updated_at"`)}
+	}
+	if _, ok := amc.mutation.ArchivedAt(); !ok {
+		return &ValidationError{Name: "archived_at", err: errors.New(`ent: missing required field "ArchivedMachine.archived_at"`)}
+	}
+	return nil
+}
+
+func (amc *ArchivedMachineCreate) sqlSave(ctx context.Context) (*ArchivedMachine, error) {
+	if err := amc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := amc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, amc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	amc.mutation.id = &_node.ID
+	amc.mutation.done = true
+	return _node, nil
+}
+
+func (amc *ArchivedMachineCreate) createSpec() (*ArchivedMachine, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ArchivedMachine{config: amc.config}
+		_spec = sqlgraph.NewCreateSpec(archivedmachine.Table, sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt))
+	)
+	if value, ok := amc.mutation.MachineID(); ok {
+		_spec.SetField(archivedmachine.FieldMachineID, field.TypeString, value)
+		_node.MachineID = value
+	}
+	if value, ok := amc.mutation.CurrentState(); ok {
+		_spec.SetField(archivedmachine.FieldCurrentState, field.TypeString, value)
+		_node.CurrentState = value
+	}
+	if value, ok := amc.mutation.DefinitionName(); ok {
+		_spec.SetField(archivedmachine.FieldDefinitionName, field.TypeString, value)
+		_node.DefinitionName = value
+	}
+	if value, ok := amc.mutation.DefinitionVersion(); ok {
+		_spec.SetField(archivedmachine.FieldDefinitionVersion, field.TypeInt, value)
+		_node.DefinitionVersion = value
+	}
+	if value, ok := amc.mutation.Data(); ok {
+		_spec.SetField(archivedmachine.FieldData, field.TypeBytes, value)
+		_node.Data = value
+	}
+	if value, ok := amc.mutation.Suspended(); ok {
+		_spec.SetField(archivedmachine.FieldSuspended, field.TypeBool, value)
+		_node.Suspended = value
+	}
+	if value, ok := amc.mutation.CreatedAt(); ok {
+		_spec.SetField(archivedmachine.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := amc.mutation.UpdatedAt(); ok {
+		_spec.SetField(archivedmachine.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := amc.mutation.EnteredAt(); ok {
+		_spec.SetField(archivedmachine.FieldEnteredAt, field.TypeTime, value)
+		_node.EnteredAt = &value
+	}
+	if value, ok := amc.mutation.ArchivedAt(); ok {
+		_spec.SetField(archivedmachine.FieldArchivedAt, field.TypeTime, value)
+		_node.ArchivedAt = value
+	}
+	if nodes := amc.mutation.HistoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ArchivedMachineCreateBulk is the builder for creating many ArchivedMachine entities in bulk.
+type ArchivedMachineCreateBulk struct {
+	config
+	err      error
+	builders []*ArchivedMachineCreate
+}
+
+// Save creates the ArchivedMachine entities in the database.
+func (amcb *ArchivedMachineCreateBulk) Save(ctx context.Context) ([]*ArchivedMachine, error) {
+	if amcb.err != nil {
+		return nil, amcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(amcb.builders))
+	nodes := make([]*ArchivedMachine, len(amcb.builders))
+	mutators := make([]Mutator, len(amcb.builders))
+	for i := range amcb.builders {
+		func(i int, root context.Context) {
+			builder := amcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ArchivedMachineMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, amcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, amcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, amcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (amcb *ArchivedMachineCreateBulk) SaveX(ctx context.Context) []*ArchivedMachine {
+	v, err := amcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (amcb *ArchivedMachineCreateBulk) Exec(ctx context.Context) error {
+	_, err := amcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (amcb *ArchivedMachineCreateBulk) ExecX(ctx context.Context) {
+	if err := amcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,89 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedMachineDelete is the builder for deleting a ArchivedMachine entity.
+type ArchivedMachineDelete struct {
+	config
+	hooks    []Hook
+	mutation *ArchivedMachineMutation
+}
+
+// Where appends a list predicates to the ArchivedMachineDelete builder.
+func (amd *ArchivedMachineDelete) Where(ps ...predicate.ArchivedMachine) *ArchivedMachineDelete {
+	amd.mutation.Where(ps...)
+	return amd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (amd *ArchivedMachineDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, amd.sqlExec, amd.mutation, amd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (amd *ArchivedMachineDelete) ExecX(ctx context.Context) int {
+	n, err := amd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (amd *ArchivedMachineDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(archivedmachine.Table, sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt))
+	if ps := amd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, amd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	amd.mutation.done = true
+	return affected, err
+}
+
+// ArchivedMachineDeleteOne is the builder for deleting a single ArchivedMachine entity.
+type ArchivedMachineDeleteOne struct {
+	amd *ArchivedMachineDelete
+}
+
+// Where appends a list predicates to the ArchivedMachineDelete builder.
+func (amdo *ArchivedMachineDeleteOne) Where(ps ...predicate.ArchivedMachine) *ArchivedMachineDeleteOne {
+	amdo.amd.mutation.Where(ps...)
+	return amdo
+}
+
+// Exec executes the deletion query.
+func (amdo *ArchivedMachineDeleteOne) Exec(ctx context.Context) error {
+	n, err := amdo.amd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{archivedmachine.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (amdo *ArchivedMachineDeleteOne) ExecX(ctx context.Context) {
+	if err := amdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,608 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedMachineQuery is the builder for querying ArchivedMachine entities.
+type ArchivedMachineQuery struct {
+	config
+	ctx         *QueryContext
+	order       []archivedmachine.OrderOption
+	inters      []Interceptor
+	predicates  []predicate.ArchivedMachine
+	withHistory *ArchivedTransitionQuery
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ArchivedMachineQuery builder.
+func (amq *ArchivedMachineQuery) Where(ps ...predicate.ArchivedMachine) *ArchivedMachineQuery {
+	amq.predicates = append(amq.predicates, ps...)
+	return amq
+}
+
+// Limit the number of records to be returned by this query.
+func (amq *ArchivedMachineQuery) Limit(limit int) *ArchivedMachineQuery {
+	amq.ctx.Limit = &limit
+	return amq
+}
+
+// Offset to start from.
+func (amq *ArchivedMachineQuery) Offset(offset int) *ArchivedMachineQuery {
+	amq.ctx.Offset = &offset
+	return amq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (amq *ArchivedMachineQuery) Unique(unique bool) *ArchivedMachineQuery {
+	amq.ctx.Unique = &unique
+	return amq
+}
+
+// Order specifies how the records should be ordered.
+func (amq *ArchivedMachineQuery) Order(o ...archivedmachine.OrderOption) *ArchivedMachineQuery {
+	amq.order = append(amq.order, o...)
+	return amq
+}
+
+// QueryHistory chains the current query on the "history" edge.
+func (amq *ArchivedMachineQuery) QueryHistory() *ArchivedTransitionQuery {
+	query := (&ArchivedTransitionClient{config: amq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := amq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := amq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(archivedmachine.Table, archivedmachine.FieldID, selector),
+			sqlgraph.To(archivedtransition.Table, archivedtransition.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, archivedmachine.HistoryTable, archivedmachine.HistoryColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(amq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first ArchivedMachine entity from the query.
+// Returns a *NotFoundError when no ArchivedMachine was found.
+func (amq *ArchivedMachineQuery) First(ctx context.Context) (*ArchivedMachine, error) {
+	nodes, err := amq.Limit(1).All(setContextOp(ctx, amq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{archivedmachine.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) FirstX(ctx context.Context) *ArchivedMachine {
+	node, err := amq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ArchivedMachine ID from the query.
+// Returns a *NotFoundError when no ArchivedMachine ID was found.
+func (amq *ArchivedMachineQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = amq.Limit(1).IDs(setContextOp(ctx, amq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{archivedmachine.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) FirstIDX(ctx context.Context) int {
+	id, err := amq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ArchivedMachine entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ArchivedMachine entity is found.
+// Returns a *NotFoundError when no ArchivedMachine entities are found.
+func (amq *ArchivedMachineQuery) Only(ctx context.Context) (*ArchivedMachine, error) {
+	nodes, err := amq.Limit(2).All(setContextOp(ctx, amq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{archivedmachine.Label}
+	default:
+		return nil, &NotSingularError{archivedmachine.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) OnlyX(ctx context.Context) *ArchivedMachine {
+	node, err := amq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ArchivedMachine ID in the query.
+// Returns a *NotSingularError when more than one ArchivedMachine ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (amq *ArchivedMachineQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = amq.Limit(2).IDs(setContextOp(ctx, amq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{archivedmachine.Label}
+	default:
+		err = &NotSingularError{archivedmachine.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) OnlyIDX(ctx context.Context) int {
+	id, err := amq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ArchivedMachines.
+func (amq *ArchivedMachineQuery) All(ctx context.Context) ([]*ArchivedMachine, error) {
+	ctx = setContextOp(ctx, amq.ctx, ent.OpQueryAll)
+	if err := amq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ArchivedMachine, *ArchivedMachineQuery]()
+	return withInterceptors[[]*ArchivedMachine](ctx, amq, qr, amq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) AllX(ctx context.Context) []*ArchivedMachine {
+	nodes, err := amq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ArchivedMachine IDs.
+func (amq *ArchivedMachineQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if amq.ctx.Unique == nil && amq.path != nil {
+		amq.Unique(true)
+	}
+	ctx = setContextOp(ctx, amq.ctx, ent.OpQueryIDs)
+	if err = amq.Select(archivedmachine.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) IDsX(ctx context.Context) []int {
+	ids, err := amq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (amq *ArchivedMachineQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, amq.ctx, ent.OpQueryCount)
+	if err := amq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, amq, querierCount[*ArchivedMachineQuery](), amq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) CountX(ctx context.Context) int {
+	count, err := amq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (amq *ArchivedMachineQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, amq.ctx, ent.OpQueryExist)
+	switch _, err := amq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (amq *ArchivedMachineQuery) ExistX(ctx context.Context) bool {
+	exist, err := amq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ArchivedMachineQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (amq *ArchivedMachineQuery) Clone() *ArchivedMachineQuery {
+	if amq == nil {
+		return nil
+	}
+	return &ArchivedMachineQuery{
+		config:      amq.config,
+		ctx:         amq.ctx.Clone(),
+		order:       append([]archivedmachine.OrderOption{}, amq.order...),
+		inters:      append([]Interceptor{}, amq.inters...),
+		predicates:  append([]predicate.ArchivedMachine{}, amq.predicates...),
+		withHistory: amq.withHistory.Clone(),
+		// clone intermediate query.
+		sql:  amq.sql.Clone(),
+		path: amq.path,
+	}
+}
+
+// WithHistory tells the query-builder to eager-load the nodes that are connected to
+// the "history" edge. The optional arguments are used to configure the query builder of the edge.
+func (amq *ArchivedMachineQuery) WithHistory(opts ...func(*ArchivedTransitionQuery)) *ArchivedMachineQuery {
+	query := (&ArchivedTransitionClient{config: amq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	amq.withHistory = query
+	return amq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		MachineID string `json:"machine_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ArchivedMachine.Query().
+//		GroupBy(archivedmachine.FieldMachineID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (amq *ArchivedMachineQuery) GroupBy(field string, fields ...string) *ArchivedMachineGroupBy {
+	amq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ArchivedMachineGroupBy{build: amq}
+	grbuild.flds = &amq.ctx.Fields
+	grbuild.label = archivedmachine.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		MachineID string `json:"machine_id,omitempty"`
+//	}
+//
+//	client.ArchivedMachine.Query().
+//		Select(archivedmachine.FieldMachineID).
+//		Scan(ctx, &v)
+func (amq *ArchivedMachineQuery) Select(fields ...string) *ArchivedMachineSelect {
+	amq.ctx.Fields = append(amq.ctx.Fields, fields...)
+	sbuild := &ArchivedMachineSelect{ArchivedMachineQuery: amq}
+	sbuild.label = archivedmachine.Label
+	sbuild.flds, sbuild.scan = &amq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ArchivedMachineSelect configured with the given aggregations.
+func (amq *ArchivedMachineQuery) Aggregate(fns ...AggregateFunc) *ArchivedMachineSelect {
+	return amq.Select().Aggregate(fns...)
+}
+
+func (amq *ArchivedMachineQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range amq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, amq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range amq.ctx.Fields {
+		if !archivedmachine.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if amq.path != nil {
+		prev, err := amq.path(ctx)
+		if err != nil {
+			return err
+		}
+		amq.sql = prev
+	}
+	return nil
+}
+
+func (amq *ArchivedMachineQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ArchivedMachine, error) {
+	var (
+		nodes       = []*ArchivedMachine{}
+		_spec       = amq.querySpec()
+		loadedTypes = [1]bool{
+			amq.withHistory != nil,
+		}
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ArchivedMachine).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ArchivedMachine{config: amq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, amq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := amq.withHistory; query != nil {
+		if err := amq.loadHistory(ctx, query, nodes,
+			func(n *ArchivedMachine) { n.Edges.History = []*ArchivedTransition{} },
+			func(n *ArchivedMachine, e *ArchivedTransition) { n.Edges.History = append(n.Edges.History, e) }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (amq *ArchivedMachineQuery) loadHistory(ctx context.Context, query *ArchivedTransitionQuery, nodes []*ArchivedMachine, init func(*ArchivedMachine), assign func(*ArchivedMachine, *ArchivedTransition)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*ArchivedMachine)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.ArchivedTransition(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(archivedmachine.HistoryColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.archived_machine_history
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "archived_machine_history" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "archived_machine_history" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+
+func (amq *ArchivedMachineQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := amq.querySpec()
+	_spec.Node.Columns = amq.ctx.Fields
+	if len(amq.ctx.Fields) > 0 {
+		_spec.Unique = amq.ctx.Unique != nil && *amq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, amq.driver, _spec)
+}
+
+func (amq *ArchivedMachineQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(archivedmachine.Table, archivedmachine.Columns, sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt))
+	_spec.From = amq.sql
+	if unique := amq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if amq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := amq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, archivedmachine.FieldID)
+		for i := range fields {
+			if fields[i] != archivedmachine.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := amq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := amq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := amq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := amq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (amq *ArchivedMachineQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(amq.driver.Dialect())
+	t1 := builder.Table(archivedmachine.Table)
+	columns := amq.ctx.Fields
+	if len(columns) == 0 {
+		columns = archivedmachine.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if amq.sql != nil {
+		selector = amq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if amq.ctx.Unique != nil && *amq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range amq.predicates {
+		p(selector)
+	}
+	for _, p := range amq.order {
+		p(selector)
+	}
+	if offset := amq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := amq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ArchivedMachineGroupBy is the group-by builder for ArchivedMachine entities.
+type ArchivedMachineGroupBy struct {
+	selector
+	build *ArchivedMachineQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (amgb *ArchivedMachineGroupBy) Aggregate(fns ...AggregateFunc) *ArchivedMachineGroupBy {
+	amgb.fns = append(amgb.fns, fns...)
+	return amgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (amgb *ArchivedMachineGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, amgb.build.ctx, ent.OpQueryGroupBy)
+	if err := amgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ArchivedMachineQuery, *ArchivedMachineGroupBy](ctx, amgb.build, amgb, amgb.build.inters, v)
+}
+
+func (amgb *ArchivedMachineGroupBy) sqlScan(ctx context.Context, root *ArchivedMachineQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(amgb.fns))
+	for _, fn := range amgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*amgb.flds)+len(amgb.fns))
+		for _, f := range *amgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*amgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := amgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ArchivedMachineSelect is the builder for selecting fields of ArchivedMachine entities.
+type ArchivedMachineSelect struct {
+	*ArchivedMachineQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ams *ArchivedMachineSelect) Aggregate(fns ...AggregateFunc) *ArchivedMachineSelect {
+	ams.fns = append(ams.fns, fns...)
+	return ams
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ams *ArchivedMachineSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ams.ctx, ent.OpQuerySelect)
+	if err := ams.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ArchivedMachineQuery, *ArchivedMachineSelect](ctx, ams.ArchivedMachineQuery, ams, ams.inters, v)
+}
+
+func (ams *ArchivedMachineSelect) sqlScan(ctx context.Context, root *ArchivedMachineQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ams.fns))
+	for _, fn := range ams.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ams.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ams.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,746 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedMachineUpdate is the builder for updating ArchivedMachine entities.
+type ArchivedMachineUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ArchivedMachineMutation
+}
+
+// Where appends a list predicates to the ArchivedMachineUpdate builder.
+func (amu *ArchivedMachineUpdate) Where(ps ...predicate.ArchivedMachine) *ArchivedMachineUpdate {
+	amu.mutation.Where(ps...)
+	return amu
+}
+
+// SetMachineID sets the "machine_id" field.
+func (amu *ArchivedMachineUpdate) SetMachineID(s string) *ArchivedMachineUpdate {
+	amu.mutation.SetMachineID(s)
+	return amu
+}
+
+// SetNillableMachineID sets the "machine_id" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableMachineID(s *string) *ArchivedMachineUpdate {
+	if s != nil {
+		amu.SetMachineID(*s)
+	}
+	return amu
+}
+
+// SetCurrentState sets the "current_state" field.
+func (amu *ArchivedMachineUpdate) SetCurrentState(s string) *ArchivedMachineUpdate {
+	amu.mutation.SetCurrentState(s)
+	return amu
+}
+
+// SetNillableCurrentState sets the "current_state" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableCurrentState(s *string) *ArchivedMachineUpdate {
+	if s != nil {
+		amu.SetCurrentState(*s)
+	}
+	return amu
+}
+
+// SetDefinitionName sets the "definition_name" field.
+func (amu *ArchivedMachineUpdate) SetDefinitionName(s string) *ArchivedMachineUpdate {
+	amu.mutation.SetDefinitionName(s)
+	return amu
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableDefinitionName(s *string) *ArchivedMachineUpdate {
+	if s != nil {
+		amu.SetDefinitionName(*s)
+	}
+	return amu
+}
+
+// SetDefinitionVersion sets the "definition_version" field.
+func (amu *ArchivedMachineUpdate) SetDefinitionVersion(i int) *ArchivedMachineUpdate {
+	amu.mutation.ResetDefinitionVersion()
+	amu.mutation.SetDefinitionVersion(i)
+	return amu
+}
+
+// SetNillableDefinitionVersion sets the "definition_version" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableDefinitionVersion(i *int) *ArchivedMachineUpdate {
+	if i != nil {
+		amu.SetDefinitionVersion(*i)
+	}
+	return amu
+}
+
+// AddDefinitionVersion adds i to the "definition_version" field.
+func (amu *ArchivedMachineUpdate) AddDefinitionVersion(i int) *ArchivedMachineUpdate {
+	amu.mutation.AddDefinitionVersion(i)
+	return amu
+}
+
+// SetData sets the "data" field.
+func (amu *ArchivedMachineUpdate) SetData(b []byte) *ArchivedMachineUpdate {
+	amu.mutation.SetData(b)
+	return amu
+}
+
+// ClearData clears the value of the "data" field.
+func (amu *ArchivedMachineUpdate) ClearData() *ArchivedMachineUpdate {
+	amu.mutation.ClearData()
+	return amu
+}
+
+// SetSuspended sets the "suspended" field.
+func (amu *ArchivedMachineUpdate) SetSuspended(b bool) *ArchivedMachineUpdate {
+	amu.mutation.SetSuspended(b)
+	return amu
+}
+
+// SetNillableSuspended sets the "suspended" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableSuspended(b *bool) *ArchivedMachineUpdate {
+	if b != nil {
+		amu.SetSuspended(*b)
+	}
+	return amu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (amu *ArchivedMachineUpdate) SetCreatedAt(t time.Time) *ArchivedMachineUpdate {
+	amu.mutation.SetCreatedAt(t)
+	return amu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableCreatedAt(t *time.Time) *ArchivedMachineUpdate {
+	if t != nil {
+		amu.SetCreatedAt(*t)
+	}
+	return amu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (amu *ArchivedMachineUpdate) SetUpdatedAt(t time.Time) *ArchivedMachineUpdate {
+	amu.mutation.SetUpdatedAt(t)
+	return amu
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableUpdatedAt(t *time.Time) *ArchivedMachineUpdate {
+	if t != nil {
+		amu.SetUpdatedAt(*t)
+	}
+	return amu
+}
+
+// SetEnteredAt sets the "entered_at" field.
+func (amu *ArchivedMachineUpdate) SetEnteredAt(t time.Time) *ArchivedMachineUpdate {
+	amu.mutation.SetEnteredAt(t)
+	return amu
+}
+
+// SetNillableEnteredAt sets the "entered_at" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableEnteredAt(t *time.Time) *ArchivedMachineUpdate {
+	if t != nil {
+		amu.SetEnteredAt(*t)
+	}
+	return amu
+}
+
+// ClearEnteredAt clears the value of the "entered_at" field.
+func (amu *ArchivedMachineUpdate) ClearEnteredAt() *ArchivedMachineUpdate {
+	amu.mutation.ClearEnteredAt()
+	return amu
+}
+
+// SetArchivedAt sets the "archived_at" field.
+func (amu *ArchivedMachineUpdate) SetArchivedAt(t time.Time) *ArchivedMachineUpdate {
+	amu.mutation.SetArchivedAt(t)
+	return amu
+}
+
+// SetNillableArchivedAt sets the "archived_at" field if the given value is not nil.
+func (amu *ArchivedMachineUpdate) SetNillableArchivedAt(t *time.Time) *ArchivedMachineUpdate {
+	if t != nil {
+		amu.SetArchivedAt(*t)
+	}
+	return amu
+}
+
+// AddHistoryIDs adds the "history" edge to the ArchivedTransition entity by IDs.
+func (amu *ArchivedMachineUpdate) AddHistoryIDs(ids ...int) *ArchivedMachineUpdate {
+	amu.mutation.AddHistoryIDs(ids...)
+	return amu
+}
+
+// AddHistory adds the "history" edges to the ArchivedTransition entity.
+func (amu *ArchivedMachineUpdate) AddHistory(a ...*ArchivedTransition) *ArchivedMachineUpdate {
+	ids := make([]int, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return amu.AddHistoryIDs(ids...)
+}
+
+// Mutation returns the ArchivedMachineMutation object of the builder.
+func (amu *ArchivedMachineUpdate) Mutation() *ArchivedMachineMutation {
+	return amu.mutation
+}
+
+// ClearHistory clears all "history" edges to the ArchivedTransition entity.
+func (amu *ArchivedMachineUpdate) ClearHistory() *ArchivedMachineUpdate {
+	amu.mutation.ClearHistory()
+	return amu
+}
+
+// RemoveHistoryIDs removes the "history" edge to ArchivedTransition entities by IDs.
+func (amu *ArchivedMachineUpdate) RemoveHistoryIDs(ids ...int) *ArchivedMachineUpdate {
+	amu.mutation.RemoveHistoryIDs(ids...)
+	return amu
+}
+
+// RemoveHistory removes "history" edges to ArchivedTransition entities.
+func (amu *ArchivedMachineUpdate) RemoveHistory(a ...*ArchivedTransition) *ArchivedMachineUpdate {
+	ids := make([]int, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return amu.RemoveHistoryIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (amu *ArchivedMachineUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, amu.sqlSave, amu.mutation, amu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (amu *ArchivedMachineUpdate) SaveX(ctx context.Context) int {
+	affected, err := amu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (amu *ArchivedMachineUpdate) Exec(ctx context.Context) error {
+	_, err := amu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (amu *ArchivedMachineUpdate) ExecX(ctx context.Context) {
+	if err := amu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (amu *ArchivedMachineUpdate) check() error {
+	if v, ok := amu.mutation.MachineID(); ok {
+		if err := archivedmachine.MachineIDValidator(v); err != nil {
+			return &ValidationError{Name: "machine_id", err: fmt.Errorf(`ent: validator failed for field "ArchivedMachine.machine_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (amu *ArchivedMachineUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := amu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(archivedmachine.Table, archivedmachine.Columns, sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt))
+	if ps := amu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := amu.mutation.MachineID(); ok {
+		_spec.SetField(archivedmachine.FieldMachineID, field.TypeString, value)
+	}
+	if value, ok := amu.mutation.CurrentState(); ok {
+		_spec.SetField(archivedmachine.FieldCurrentState, field.TypeString, value)
+	}
+	if value, ok := amu.mutation.DefinitionName(); ok {
+		_spec.SetField(archivedmachine.FieldDefinitionName, field.TypeString, value)
+	}
+	if value, ok := amu.mutation.DefinitionVersion(); ok {
+		_spec.SetField(archivedmachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := amu.mutation.AddedDefinitionVersion(); ok {
+		_spec.AddField(archivedmachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := amu.mutation.Data(); ok {
+		_spec.SetField(archivedmachine.FieldData, field.TypeBytes, value)
+	}
+	if amu.mutation.DataCleared() {
+		_spec.ClearField(archivedmachine.FieldData, field.TypeBytes)
+	}
+	if value, ok := amu.mutation.Suspended(); ok {
+		_spec.SetField(archivedmachine.FieldSuspended, field.TypeBool, value)
+	}
+	if value, ok := amu.mutation.CreatedAt(); ok {
+		_spec.SetField(archivedmachine.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := amu.mutation.UpdatedAt(); ok {
+		_spec.SetField(archivedmachine.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := amu.mutation.EnteredAt(); ok {
+		_spec.SetField(archivedmachine.FieldEnteredAt, field.TypeTime, value)
+	}
+	if amu.mutation.EnteredAtCleared() {
+		_spec.ClearField(archivedmachine.FieldEnteredAt, field.TypeTime)
+	}
+	if value, ok := amu.mutation.ArchivedAt(); ok {
+		_spec.SetField(archivedmachine.FieldArchivedAt, field.TypeTime, value)
+	}
+	if amu.mutation.HistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := amu.mutation.RemovedHistoryIDs(); len(nodes) > 0 && !amu.mutation.HistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := amu.mutation.HistoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, amu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{archivedmachine.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	amu.mutation.done = true
+	return n, nil
+}
+
+// ArchivedMachineUpdateOne is the builder for updating a single ArchivedMachine entity.
+type ArchivedMachineUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ArchivedMachineMutation
+}
+
+// SetMachineID sets the "machine_id" field.
+func (amuo *ArchivedMachineUpdateOne) SetMachineID(s string) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetMachineID(s)
+	return amuo
+}
+
+// SetNillableMachineID sets the "machine_id" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableMachineID(s *string) *ArchivedMachineUpdateOne {
+	if s != nil {
+		amuo.SetMachineID(*s)
+	}
+	return amuo
+}
+
+// SetCurrentState sets the "current_state" field.
+func (amuo *ArchivedMachineUpdateOne) SetCurrentState(s string) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetCurrentState(s)
+	return amuo
+}
+
+// SetNillableCurrentState sets the "current_state" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableCurrentState(s *string) *ArchivedMachineUpdateOne {
+	if s != nil {
+		amuo.SetCurrentState(*s)
+	}
+	return amuo
+}
+
+// SetDefinitionName sets the "definition_name" field.
+func (amuo *ArchivedMachineUpdateOne) SetDefinitionName(s string) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetDefinitionName(s)
+	return amuo
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableDefinitionName(s *string) *ArchivedMachineUpdateOne {
+	if s != nil {
+		amuo.SetDefinitionName(*s)
+	}
+	return amuo
+}
+
+// SetDefinitionVersion sets the "definition_version" field.
+func (amuo *ArchivedMachineUpdateOne) SetDefinitionVersion(i int) *ArchivedMachineUpdateOne {
+	amuo.mutation.ResetDefinitionVersion()
+	amuo.mutation.SetDefinitionVersion(i)
+	return amuo
+}
+
+// SetNillableDefinitionVersion sets the "definition_version" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableDefinitionVersion(i *int) *ArchivedMachineUpdateOne {
+	if i != nil {
+		amuo.SetDefinitionVersion(*i)
+	}
+	return amuo
+}
+
+// AddDefinitionVersion adds i to the "definition_version" field.
+func (amuo *ArchivedMachineUpdateOne) AddDefinitionVersion(i int) *ArchivedMachineUpdateOne {
+	amuo.mutation.AddDefinitionVersion(i)
+	return amuo
+}
+
+// SetData sets the "data" field.
+func (amuo *ArchivedMachineUpdateOne) SetData(b []byte) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetData(b)
+	return amuo
+}
+
+// ClearData clears the value of the "data" field.
+func (amuo *ArchivedMachineUpdateOne) ClearData() *ArchivedMachineUpdateOne {
+	amuo.mutation.ClearData()
+	return amuo
+}
+
+// SetSuspended sets the "suspended" field.
+func (amuo *ArchivedMachineUpdateOne) SetSuspended(b bool) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetSuspended(b)
+	return amuo
+}
+
+// SetNillableSuspended sets the "suspended" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableSuspended(b *bool) *ArchivedMachineUpdateOne {
+	if b != nil {
+		amuo.SetSuspended(*b)
+	}
+	return amuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (amuo *ArchivedMachineUpdateOne) SetCreatedAt(t time.Time) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetCreatedAt(t)
+	return amuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableCreatedAt(t *time.Time) *ArchivedMachineUpdateOne {
+	if t != nil {
+		amuo.SetCreatedAt(*t)
+	}
+	return amuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (amuo *ArchivedMachineUpdateOne) SetUpdatedAt(t time.Time) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetUpdatedAt(t)
+	return amuo
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableUpdatedAt(t *time.Time) *ArchivedMachineUpdateOne {
+	if t != nil {
+		amuo.SetUpdatedAt(*t)
+	}
+	return amuo
+}
+
+// SetEnteredAt sets the "entered_at" field.
+func (amuo *ArchivedMachineUpdateOne) SetEnteredAt(t time.Time) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetEnteredAt(t)
+	return amuo
+}
+
+// SetNillableEnteredAt sets the "entered_at" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableEnteredAt(t *time.Time) *ArchivedMachineUpdateOne {
+	if t != nil {
+		amuo.SetEnteredAt(*t)
+	}
+	return amuo
+}
+
+// ClearEnteredAt clears the value of the "entered_at" field.
+func (amuo *ArchivedMachineUpdateOne) ClearEnteredAt() *ArchivedMachineUpdateOne {
+	amuo.mutation.ClearEnteredAt()
+	return amuo
+}
+
+// SetArchivedAt sets the "archived_at" field.
+func (amuo *ArchivedMachineUpdateOne) SetArchivedAt(t time.Time) *ArchivedMachineUpdateOne {
+	amuo.mutation.SetArchivedAt(t)
+	return amuo
+}
+
+// SetNillableArchivedAt sets the "archived_at" field if the given value is not nil.
+func (amuo *ArchivedMachineUpdateOne) SetNillableArchivedAt(t *time.Time) *ArchivedMachineUpdateOne {
+	if t != nil {
+		amuo.SetArchivedAt(*t)
+	}
+	return amuo
+}
+
+// AddHistoryIDs adds the "history" edge to the ArchivedTransition entity by IDs.
+func (amuo *ArchivedMachineUpdateOne) AddHistoryIDs(ids ...int) *ArchivedMachineUpdateOne {
+	amuo.mutation.AddHistoryIDs(ids...)
+	return amuo
+}
+
+// AddHistory adds the "history" edges to the ArchivedTransition entity.
+func (amuo *ArchivedMachineUpdateOne) AddHistory(a ...*ArchivedTransition) *ArchivedMachineUpdateOne {
+	ids := make([]int, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return amuo.AddHistoryIDs(ids...)
+}
+
+// Mutation returns the ArchivedMachineMutation object of the builder.
+func (amuo *ArchivedMachineUpdateOne) Mutation() *ArchivedMachineMutation {
+	return amuo.mutation
+}
+
+// ClearHistory clears all "history" edges to the ArchivedTransition entity.
+func (amuo *ArchivedMachineUpdateOne) ClearHistory() *ArchivedMachineUpdateOne {
+	amuo.mutation.ClearHistory()
+	return amuo
+}
+
+// RemoveHistoryIDs removes the "history" edge to ArchivedTransition entities by IDs.
+func (amuo *ArchivedMachineUpdateOne) RemoveHistoryIDs(ids ...int) *ArchivedMachineUpdateOne {
+	amuo.mutation.RemoveHistoryIDs(ids...)
+	return amuo
+}
+
+// RemoveHistory removes "history" edges to ArchivedTransition entities.
+func (amuo *ArchivedMachineUpdateOne) RemoveHistory(a ...*ArchivedTransition) *ArchivedMachineUpdateOne {
+	ids := make([]int, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return amuo.RemoveHistoryIDs(ids...)
+}
+
+// Where appends a list predicates to the ArchivedMachineUpdate builder.
+func (amuo *ArchivedMachineUpdateOne) Where(ps ...predicate.ArchivedMachine) *ArchivedMachineUpdateOne {
+	amuo.mutation.Where(ps...)
+	return amuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (amuo *ArchivedMachineUpdateOne) Select(field string, fields ...string) *ArchivedMachineUpdateOne {
+	amuo.fields = append([]string{field}, fields...)
+	return amuo
+}
+
+// Save executes the query and returns the updated ArchivedMachine entity.
+func (amuo *ArchivedMachineUpdateOne) Save(ctx context.Context) (*ArchivedMachine, error) {
+	return withHooks(ctx, amuo.sqlSave, amuo.mutation, amuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (amuo *ArchivedMachineUpdateOne) SaveX(ctx context.Context) *ArchivedMachine {
+	node, err := amuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (amuo *ArchivedMachineUpdateOne) Exec(ctx context.Context) error {
+	_, err := amuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (amuo *ArchivedMachineUpdateOne) ExecX(ctx context.Context) {
+	if err := amuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (amuo *ArchivedMachineUpdateOne) check() error {
+	if v, ok := amuo.mutation.MachineID(); ok {
+		if err := archivedmachine.MachineIDValidator(v); err != nil {
+			return &ValidationError{Name: "machine_id", err: fmt.Errorf(`ent: validator failed for field "ArchivedMachine.machine_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (amuo *ArchivedMachineUpdateOne) sqlSave(ctx context.Context) (_node *ArchivedMachine, err error) {
+	if err := amuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(archivedmachine.Table, archivedmachine.Columns, sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt))
+	id, ok := amuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ArchivedMachine.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := amuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, archivedmachine.FieldID)
+		for _, f := range fields {
+			if !archivedmachine.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != archivedmachine.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := amuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := amuo.mutation.MachineID(); ok {
+		_spec.SetField(archivedmachine.FieldMachineID, field.TypeString, value)
+	}
+	if value, ok := amuo.mutation.CurrentState(); ok {
+		_spec.SetField(archivedmachine.FieldCurrentState, field.TypeString, value)
+	}
+	if value, ok := amuo.mutation.DefinitionName(); ok {
+		_spec.SetField(archivedmachine.FieldDefinitionName, field.TypeString, value)
+	}
+	if value, ok := amuo.mutation.DefinitionVersion(); ok {
+		_spec.SetField(archivedmachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := amuo.mutation.AddedDefinitionVersion(); ok {
+		_spec.AddField(archivedmachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := amuo.mutation.Data(); ok {
+		_spec.SetField(archivedmachine.FieldData, field.TypeBytes, value)
+	}
+	if amuo.mutation.DataCleared() {
+		_spec.ClearField(archivedmachine.FieldData, field.TypeBytes)
+	}
+	if value, ok := amuo.mutation.Suspended(); ok {
+		_spec.SetField(archivedmachine.FieldSuspended, field.TypeBool, value)
+	}
+	if value, ok := amuo.mutation.CreatedAt(); ok {
+		_spec.SetField(archivedmachine.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := amuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(archivedmachine.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := amuo.mutation.EnteredAt(); ok {
+		_spec.SetField(archivedmachine.FieldEnteredAt, field.TypeTime, value)
+	}
+	if amuo.mutation.EnteredAtCleared() {
+		_spec.ClearField(archivedmachine.FieldEnteredAt, field.TypeTime)
+	}
+	if value, ok := amuo.mutation.ArchivedAt(); ok {
+		_spec.SetField(archivedmachine.FieldArchivedAt, field.TypeTime, value)
+	}
+	if amuo.mutation.HistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := amuo.mutation.RemovedHistoryIDs(); len(nodes) > 0 && !amuo.mutation.HistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := amuo.mutation.HistoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   archivedmachine.HistoryTable,
+			Columns: []string{archivedmachine.HistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ArchivedMachine{config: amuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, amuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{archivedmachine.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	amuo.mutation.done = true
+	return _node, nil
+}
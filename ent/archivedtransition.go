@@ -0,0 +1,234 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ArchivedTransition is the model entity for the ArchivedTransition schema.
+type ArchivedTransition struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// FromState holds the value of the "from_state" field.
+	FromState string `json:"from_state,omitempty"`
+	// ToState holds the value of the "to_state" field.
+	ToState string `json:"to_state,omitempty"`
+	// Event holds the value of the "event" field.
+	Event string `json:"event,omitempty"`
+	// Timestamp holds the value of the "timestamp" field.
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind string `json:"kind,omitempty"`
+	// Reason holds the value of the "reason" field.
+	Reason string `json:"reason,omitempty"`
+	// Actor holds the value of the "actor" field.
+	Actor string `json:"actor,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// Compensation holds the value of the "compensation" field.
+	Compensation string `json:"compensation,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ArchivedTransitionQuery when eager-loading is set.
+	Edges                    ArchivedTransitionEdges `json:"edges"`
+	archived_machine_history *int
+	selectValues             sql.SelectValues
+}
+
+// ArchivedTransitionEdges holds the relations/edges for other nodes in the graph.
+type ArchivedTransitionEdges struct {
+	// Machine holds the value of the machine edge.
+	Machine *ArchivedMachine `json:"machine,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// MachineOrErr returns the Machine value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ArchivedTransitionEdges) MachineOrErr() (*ArchivedMachine, error) {
+	if e.Machine != nil {
+		return e.Machine, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: archivedmachine.Label}
+	}
+	return nil, &NotLoadedError{edge: "machine"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ArchivedTransition) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case archivedtransition.FieldID:
+			values[i] = new(sql.NullInt64)
+		case archivedtransition.FieldFromState, archivedtransition.FieldToState, archivedtransition.FieldEvent, archivedtransition.FieldKind, archivedtransition.FieldReason, archivedtransition.FieldActor, archivedtransition.FieldError, archivedtransition.FieldCompensation:
+			values[i] = new(sql.NullString)
+		case archivedtransition.FieldTimestamp:
+			values[i] = new(sql.NullTime)
+		case archivedtransition.ForeignKeys[0]: // archived_machine_history
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ArchivedTransition fields.
+func (at *ArchivedTransition) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case archivedtransition.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			at.ID = int(value.Int64)
+		case archivedtransition.FieldFromState:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field from_state", values[i])
+			} else if value.Valid {
+				at.FromState = value.String
+			}
+		case archivedtransition.FieldToState:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field to_state", values[i])
+			} else if value.Valid {
+				at.ToState = value.String
+			}
+		case archivedtransition.FieldEvent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field event", values[i])
+			} else if value.Valid {
+				at.Event = value.String
+			}
+		case archivedtransition.FieldTimestamp:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field timestamp", values[i])
+			} else if value.Valid {
+				at.Timestamp = value.Time
+			}
+		case archivedtransition.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				at.Kind = value.String
+			}
+		case archivedtransition.FieldReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field reason", values[i])
+			} else if value.Valid {
+				at.Reason = value.String
+			}
+		case archivedtransition.FieldActor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor", values[i])
+			} else if value.Valid {
+				at.Actor = value.String
+			}
+		case archivedtransition.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				at.Error = value.String
+			}
+		case archivedtransition.FieldCompensation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field compensation", values[i])
+			} else if value.Valid {
+				at.Compensation = value.String
+			}
+		case archivedtransition.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field archived_machine_history", value)
+			} else if value.Valid {
+				at.archived_machine_history = new(int)
+				*at.archived_machine_history = int(value.Int64)
+			}
+		default:
+			at.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ArchivedTransition.
+// This includes values selected through modifiers, order, etc.
+func (at *ArchivedTransition) Value(name string) (ent.Value, error) {
+	return at.selectValues.Get(name)
+}
+
+// QueryMachine queries the "machine" edge of the ArchivedTransition entity.
+func (at *ArchivedTransition) QueryMachine() *ArchivedMachineQuery {
+	return NewArchivedTransitionClient(at.config).QueryMachine(at)
+}
+
+// Update returns a builder for updating this ArchivedTransition.
+// Note that you need to call ArchivedTransition.Unwrap() before calling this method if this ArchivedTransition
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (at *ArchivedTransition) Update() *ArchivedTransitionUpdateOne {
+	return NewArchivedTransitionClient(at.config).UpdateOne(at)
+}
+
+// Unwrap unwraps the ArchivedTransition entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (at *ArchivedTransition) Unwrap() *ArchivedTransition {
+	_tx, ok := at.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ArchivedTransition is not a transactional entity")
+	}
+	at.config.driver = _tx.drv
+	return at
+}
+
+// String implements the fmt.Stringer.
+func (at *ArchivedTransition) String() string {
+	var builder strings.Builder
+	builder.WriteString("ArchivedTransition(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", at.ID))
+	builder.WriteString("from_state=")
+	builder.WriteString(at.FromState)
+	builder.WriteString(", ")
+	builder.WriteString("to_state=")
+	builder.WriteString(at.ToState)
+	builder.WriteString(", ")
+	builder.WriteString("event=")
+	builder.WriteString(at.Event)
+	builder.WriteString(", ")
+	builder.WriteString("timestamp=")
+	builder.WriteString(at.Timestamp.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(at.Kind)
+	builder.WriteString(", ")
+	builder.WriteString("reason=")
+	builder.WriteString(at.Reason)
+	builder.WriteString(", ")
+	builder.WriteString("actor=")
+	builder.WriteString(at.Actor)
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(at.Error)
+	builder.WriteString(", ")
+	builder.WriteString("compensation=")
+	builder.WriteString(at.Compensation)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ArchivedTransitions is a parsable slice of ArchivedTransition.
+type ArchivedTransitions []*ArchivedTransition
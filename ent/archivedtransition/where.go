@@ -0,0 +1,740 @@
+// Code generated by ent, DO NOT EDIT.
+
+package archivedtransition
+
+import (
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldID, id))
+}
+
+// FromState applies equality check predicate on the "from_state" field. It's identical to FromStateEQ.
+func FromState(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldFromState, v))
+}
+
+// ToState applies equality check predicate on the "to_state" field. It's identical to ToStateEQ.
+func ToState(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldToState, v))
+}
+
+// Event applies equality check predicate on the "event" field. It's identical to EventEQ.
+func Event(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldEvent, v))
+}
+
+// Timestamp applies equality check predicate on the "timestamp" field. It's identical to TimestampEQ.
+func Timestamp(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldTimestamp, v))
+}
+
+// Kind applies equality check predicate on the "kind" field. It's identical to KindEQ.
+func Kind(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldKind, v))
+}
+
+// Reason applies equality check predicate on the "reason" field. It's identical to ReasonEQ.
+func Reason(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldReason, v))
+}
+
+// Actor applies equality check predicate on the "actor" field. It's identical to ActorEQ.
+func Actor(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldActor, v))
+}
+
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldError, v))
+}
+
+// Compensation applies equality check predicate on the "compensation" field. It's identical to CompensationEQ.
+func Compensation(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldCompensation, v))
+}
+
+// FromStateEQ applies the EQ predicate on the "from_state" field.
+func FromStateEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldFromState, v))
+}
+
+// FromStateNEQ applies the NEQ predicate on the "from_state" field.
+func FromStateNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldFromState, v))
+}
+
+// FromStateIn applies the In predicate on the "from_state" field.
+func FromStateIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldFromState, vs...))
+}
+
+// FromStateNotIn applies the NotIn predicate on the "from_state" field.
+func FromStateNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldFromState, vs...))
+}
+
+// FromStateGT applies the GT predicate on the "from_state" field.
+func FromStateGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldFromState, v))
+}
+
+// FromStateGTE applies the GTE predicate on the "from_state" field.
+func FromStateGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldFromState, v))
+}
+
+// FromStateLT applies the LT predicate on the "from_state" field.
+func FromStateLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldFromState, v))
+}
+
+// FromStateLTE applies the LTE predicate on the "from_state" field.
+func FromStateLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldFromState, v))
+}
+
+// FromStateContains applies the Contains predicate on the "from_state" field.
+func FromStateContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldFromState, v))
+}
+
+// FromStateHasPrefix applies the HasPrefix predicate on the "from_state" field.
+func FromStateHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldFromState, v))
+}
+
+// FromStateHasSuffix applies the HasSuffix predicate on the "from_state" field.
+func FromStateHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldFromState, v))
+}
+
+// FromStateEqualFold applies the EqualFold predicate on the "from_state" field.
+func FromStateEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldFromState, v))
+}
+
+// FromStateContainsFold applies the ContainsFold predicate on the "from_state" field.
+func FromStateContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldFromState, v))
+}
+
+// ToStateEQ applies the EQ predicate on the "to_state" field.
+func ToStateEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldToState, v))
+}
+
+// ToStateNEQ applies the NEQ predicate on the "to_state" field.
+func ToStateNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldToState, v))
+}
+
+// ToStateIn applies the In predicate on the "to_state" field.
+func ToStateIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldToState, vs...))
+}
+
+// ToStateNotIn applies the NotIn predicate on the "to_state" field.
+func ToStateNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldToState, vs...))
+}
+
+// ToStateGT applies the GT predicate on the "to_state" field.
+func ToStateGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldToState, v))
+}
+
+// ToStateGTE applies the GTE predicate on the "to_state" field.
+func ToStateGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldToState, v))
+}
+
+// ToStateLT applies the LT predicate on the "to_state" field.
+func ToStateLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldToState, v))
+}
+
+// ToStateLTE applies the LTE predicate on the "to_state" field.
+func ToStateLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldToState, v))
+}
+
+// ToStateContains applies the Contains predicate on the "to_state" field.
+func ToStateContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldToState, v))
+}
+
+// ToStateHasPrefix applies the HasPrefix predicate on the "to_state" field.
+func ToStateHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldToState, v))
+}
+
+// ToStateHasSuffix applies the HasSuffix predicate on the "to_state" field.
+func ToStateHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldToState, v))
+}
+
+// ToStateEqualFold applies the EqualFold predicate on the "to_state" field.
+func ToStateEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldToState, v))
+}
+
+// ToStateContainsFold applies the ContainsFold predicate on the "to_state" field.
+func ToStateContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldToState, v))
+}
+
+// EventEQ applies the EQ predicate on the "event" field.
+func EventEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldEvent, v))
+}
+
+// EventNEQ applies the NEQ predicate on the "event" field.
+func EventNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldEvent, v))
+}
+
+// EventIn applies the In predicate on the "event" field.
+func EventIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldEvent, vs...))
+}
+
+// EventNotIn applies the NotIn predicate on the "event" field.
+func EventNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldEvent, vs...))
+}
+
+// EventGT applies the GT predicate on the "event" field.
+func EventGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldEvent, v))
+}
+
+// EventGTE applies the GTE predicate on the "event" field.
+func EventGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldEvent, v))
+}
+
+// EventLT applies the LT predicate on the "event" field.
+func EventLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldEvent, v))
+}
+
+// EventLTE applies the LTE predicate on the "event" field.
+func EventLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldEvent, v))
+}
+
+// EventContains applies the Contains predicate on the "event" field.
+func EventContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldEvent, v))
+}
+
+// EventHasPrefix applies the HasPrefix predicate on the "event" field.
+func EventHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldEvent, v))
+}
+
+// EventHasSuffix applies the HasSuffix predicate on the "event" field.
+func EventHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldEvent, v))
+}
+
+// EventEqualFold applies the EqualFold predicate on the "event" field.
+func EventEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldEvent, v))
+}
+
+// EventContainsFold applies the ContainsFold predicate on the "event" field.
+func EventContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldEvent, v))
+}
+
+// TimestampEQ applies the EQ predicate on the "timestamp" field.
+func TimestampEQ(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldTimestamp, v))
+}
+
+// TimestampNEQ applies the NEQ predicate on the "timestamp" field.
+func TimestampNEQ(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldTimestamp, v))
+}
+
+// TimestampIn applies the In predicate on the "timestamp" field.
+func TimestampIn(vs ...time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldTimestamp, vs...))
+}
+
+// TimestampNotIn applies the NotIn predicate on the "timestamp" field.
+func TimestampNotIn(vs ...time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldTimestamp, vs...))
+}
+
+// TimestampGT applies the GT predicate on the "timestamp" field.
+func TimestampGT(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldTimestamp, v))
+}
+
+// TimestampGTE applies the GTE predicate on the "timestamp" field.
+func TimestampGTE(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldTimestamp, v))
+}
+
+// TimestampLT applies the LT predicate on the "timestamp" field.
+func TimestampLT(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldTimestamp, v))
+}
+
+// TimestampLTE applies the LTE predicate on the "timestamp" field.
+func TimestampLTE(v time.Time) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldTimestamp, v))
+}
+
+// KindEQ applies the EQ predicate on the "kind" field.
+func KindEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldKind, v))
+}
+
+// KindNEQ applies the NEQ predicate on the "kind" field.
+func KindNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldKind, v))
+}
+
+// KindIn applies the In predicate on the "kind" field.
+func KindIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldKind, vs...))
+}
+
+// KindNotIn applies the NotIn predicate on the "kind" field.
+func KindNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldKind, vs...))
+}
+
+// KindGT applies the GT predicate on the "kind" field.
+func KindGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldKind, v))
+}
+
+// KindGTE applies the GTE predicate on the "kind" field.
+func KindGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldKind, v))
+}
+
+// KindLT applies the LT predicate on the "kind" field.
+func KindLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldKind, v))
+}
+
+// KindLTE applies the LTE predicate on the "kind" field.
+func KindLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldKind, v))
+}
+
+// KindContains applies the Contains predicate on the "kind" field.
+func KindContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldKind, v))
+}
+
+// KindHasPrefix applies the HasPrefix predicate on the "kind" field.
+func KindHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldKind, v))
+}
+
+// KindHasSuffix applies the HasSuffix predicate on the "kind" field.
+func KindHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldKind, v))
+}
+
+// KindEqualFold applies the EqualFold predicate on the "kind" field.
+func KindEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldKind, v))
+}
+
+// KindContainsFold applies the ContainsFold predicate on the "kind" field.
+func KindContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldKind, v))
+}
+
+// ReasonEQ applies the EQ predicate on the "reason" field.
+func ReasonEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldReason, v))
+}
+
+// ReasonNEQ applies the NEQ predicate on the "reason" field.
+func ReasonNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldReason, v))
+}
+
+// ReasonIn applies the In predicate on the "reason" field.
+func ReasonIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldReason, vs...))
+}
+
+// ReasonNotIn applies the NotIn predicate on the "reason" field.
+func ReasonNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldReason, vs...))
+}
+
+// ReasonGT applies the GT predicate on the "reason" field.
+func ReasonGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldReason, v))
+}
+
+// ReasonGTE applies the GTE predicate on the "reason" field.
+func ReasonGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldReason, v))
+}
+
+// ReasonLT applies the LT predicate on the "reason" field.
+func ReasonLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldReason, v))
+}
+
+// ReasonLTE applies the LTE predicate on the "reason" field.
+func ReasonLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldReason, v))
+}
+
+// ReasonContains applies the Contains predicate on the "reason" field.
+func ReasonContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldReason, v))
+}
+
+// ReasonHasPrefix applies the HasPrefix predicate on the "reason" field.
+func ReasonHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldReason, v))
+}
+
+// ReasonHasSuffix applies the HasSuffix predicate on the "reason" field.
+func ReasonHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldReason, v))
+}
+
+// ReasonIsNil applies the IsNil predicate on the "reason" field.
+func ReasonIsNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIsNull(FieldReason))
+}
+
+// ReasonNotNil applies the NotNil predicate on the "reason" field.
+func ReasonNotNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotNull(FieldReason))
+}
+
+// ReasonEqualFold applies the EqualFold predicate on the "reason" field.
+func ReasonEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldReason, v))
+}
+
+// ReasonContainsFold applies the ContainsFold predicate on the "reason" field.
+func ReasonContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldReason, v))
+}
+
+// ActorEQ applies the EQ predicate on the "actor" field.
+func ActorEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldActor, v))
+}
+
+// ActorNEQ applies the NEQ predicate on the "actor" field.
+func ActorNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldActor, v))
+}
+
+// ActorIn applies the In predicate on the "actor" field.
+func ActorIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldActor, vs...))
+}
+
+// ActorNotIn applies the NotIn predicate on the "actor" field.
+func ActorNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldActor, vs...))
+}
+
+// ActorGT applies the GT predicate on the "actor" field.
+func ActorGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldActor, v))
+}
+
+// ActorGTE applies the GTE predicate on the "actor" field.
+func ActorGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldActor, v))
+}
+
+// ActorLT applies the LT predicate on the "actor" field.
+func ActorLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldActor, v))
+}
+
+// ActorLTE applies the LTE predicate on the "actor" field.
+func ActorLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldActor, v))
+}
+
+// ActorContains applies the Contains predicate on the "actor" field.
+func ActorContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldActor, v))
+}
+
+// ActorHasPrefix applies the HasPrefix predicate on the "actor" field.
+func ActorHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldActor, v))
+}
+
+// ActorHasSuffix applies the HasSuffix predicate on the "actor" field.
+func ActorHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldActor, v))
+}
+
+// ActorIsNil applies the IsNil predicate on the "actor" field.
+func ActorIsNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIsNull(FieldActor))
+}
+
+// ActorNotNil applies the NotNil predicate on the "actor" field.
+func ActorNotNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotNull(FieldActor))
+}
+
+// ActorEqualFold applies the EqualFold predicate on the "actor" field.
+func ActorEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldActor, v))
+}
+
+// ActorContainsFold applies the ContainsFold predicate on the "actor" field.
+func ActorContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldActor, v))
+}
+
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorIsNil applies the IsNil predicate on the "error" field.
+func ErrorIsNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIsNull(FieldError))
+}
+
+// ErrorNotNil applies the NotNil predicate on the "error" field.
+func ErrorNotNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotNull(FieldError))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldError, v))
+}
+
+// CompensationEQ applies the EQ predicate on the "compensation" field.
+func CompensationEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEQ(FieldCompensation, v))
+}
+
+// CompensationNEQ applies the NEQ predicate on the "compensation" field.
+func CompensationNEQ(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNEQ(FieldCompensation, v))
+}
+
+// CompensationIn applies the In predicate on the "compensation" field.
+func CompensationIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIn(FieldCompensation, vs...))
+}
+
+// CompensationNotIn applies the NotIn predicate on the "compensation" field.
+func CompensationNotIn(vs ...string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotIn(FieldCompensation, vs...))
+}
+
+// CompensationGT applies the GT predicate on the "compensation" field.
+func CompensationGT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGT(FieldCompensation, v))
+}
+
+// CompensationGTE applies the GTE predicate on the "compensation" field.
+func CompensationGTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldGTE(FieldCompensation, v))
+}
+
+// CompensationLT applies the LT predicate on the "compensation" field.
+func CompensationLT(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLT(FieldCompensation, v))
+}
+
+// CompensationLTE applies the LTE predicate on the "compensation" field.
+func CompensationLTE(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldLTE(FieldCompensation, v))
+}
+
+// CompensationContains applies the Contains predicate on the "compensation" field.
+func CompensationContains(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContains(FieldCompensation, v))
+}
+
+// CompensationHasPrefix applies the HasPrefix predicate on the "compensation" field.
+func CompensationHasPrefix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasPrefix(FieldCompensation, v))
+}
+
+// CompensationHasSuffix applies the HasSuffix predicate on the "compensation" field.
+func CompensationHasSuffix(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldHasSuffix(FieldCompensation, v))
+}
+
+// CompensationIsNil applies the IsNil predicate on the "compensation" field.
+func CompensationIsNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldIsNull(FieldCompensation))
+}
+
+// CompensationNotNil applies the NotNil predicate on the "compensation" field.
+func CompensationNotNil() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldNotNull(FieldCompensation))
+}
+
+// CompensationEqualFold applies the EqualFold predicate on the "compensation" field.
+func CompensationEqualFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldEqualFold(FieldCompensation, v))
+}
+
+// CompensationContainsFold applies the ContainsFold predicate on the "compensation" field.
+func CompensationContainsFold(v string) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.FieldContainsFold(FieldCompensation, v))
+}
+
+// HasMachine applies the HasEdge predicate on the "machine" edge.
+func HasMachine() predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, MachineTable, MachineColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasMachineWith applies the HasEdge predicate on the "machine" edge with a given conditions (other predicates).
+func HasMachineWith(preds ...predicate.ArchivedMachine) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(func(s *sql.Selector) {
+		step := newMachineStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ArchivedTransition) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ArchivedTransition) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ArchivedTransition) predicate.ArchivedTransition {
+	return predicate.ArchivedTransition(sql.NotPredicates(p))
+}
@@ -0,0 +1,364 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedTransitionCreate is the builder for creating a ArchivedTransition entity.
+type ArchivedTransitionCreate struct {
+	config
+	mutation *ArchivedTransitionMutation
+	hooks    []Hook
+}
+
+// SetFromState sets the "from_state" field.
+func (atc *ArchivedTransitionCreate) SetFromState(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetFromState(s)
+	return atc
+}
+
+// SetToState sets the "to_state" field.
+func (atc *ArchivedTransitionCreate) SetToState(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetToState(s)
+	return atc
+}
+
+// SetEvent sets the "event" field.
+func (atc *ArchivedTransitionCreate) SetEvent(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetEvent(s)
+	return atc
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (atc *ArchivedTransitionCreate) SetTimestamp(t time.Time) *ArchivedTransitionCreate {
+	atc.mutation.SetTimestamp(t)
+	return atc
+}
+
+// SetNillableTimestamp sets the "timestamp" field if the given value is not nil.
+func (atc *ArchivedTransitionCreate) SetNillableTimestamp(t *time.Time) *ArchivedTransitionCreate {
+	if t != nil {
+		atc.SetTimestamp(*t)
+	}
+	return atc
+}
+
+// SetKind sets the "kind" field.
+func (atc *ArchivedTransitionCreate) SetKind(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetKind(s)
+	return atc
+}
+
+// SetReason sets the "reason" field.
+func (atc *ArchivedTransitionCreate) SetReason(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetReason(s)
+	return atc
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (atc *ArchivedTransitionCreate) SetNillableReason(s *string) *ArchivedTransitionCreate {
+	if s != nil {
+		atc.SetReason(*s)
+	}
+	return atc
+}
+
+// SetActor sets the "actor" field.
+func (atc *ArchivedTransitionCreate) SetActor(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetActor(s)
+	return atc
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (atc *ArchivedTransitionCreate) SetNillableActor(s *string) *ArchivedTransitionCreate {
+	if s != nil {
+		atc.SetActor(*s)
+	}
+	return atc
+}
+
+// SetError sets the "error" field.
+func (atc *ArchivedTransitionCreate) SetError(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetError(s)
+	return atc
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (atc *ArchivedTransitionCreate) SetNillableError(s *string) *ArchivedTransitionCreate {
+	if s != nil {
+		atc.SetError(*s)
+	}
+	return atc
+}
+
+// SetCompensation sets the "compensation" field.
+func (atc *ArchivedTransitionCreate) SetCompensation(s string) *ArchivedTransitionCreate {
+	atc.mutation.SetCompensation(s)
+	return atc
+}
+
+// SetNillableCompensation sets the "compensation" field if the given value is not nil.
+func (atc *ArchivedTransitionCreate) SetNillableCompensation(s *string) *ArchivedTransitionCreate {
+	if s != nil {
+		atc.SetCompensation(*s)
+	}
+	return atc
+}
+
+// SetMachineID sets the "machine" edge to the ArchivedMachine entity by ID.
+func (atc *ArchivedTransitionCreate) SetMachineID(id int) *ArchivedTransitionCreate {
+	atc.mutation.SetMachineID(id)
+	return atc
+}
+
+// SetNillableMachineID sets the "machine" edge to the ArchivedMachine entity by ID if the given value is not nil.
+func (atc *ArchivedTransitionCreate) SetNillableMachineID(id *int) *ArchivedTransitionCreate {
+	if id != nil {
+		atc = atc.SetMachineID(*id)
+	}
+	return atc
+}
+
+// SetMachine sets the "machine" edge to the ArchivedMachine entity.
+func (atc *ArchivedTransitionCreate) SetMachine(a *ArchivedMachine) *ArchivedTransitionCreate {
+	return atc.SetMachineID(a.ID)
+}
+
+// Mutation returns the ArchivedTransitionMutation object of the builder.
+func (atc *ArchivedTransitionCreate) Mutation() *ArchivedTransitionMutation {
+	return atc.mutation
+}
+
+// Save creates the ArchivedTransition in the database.
+func (atc *ArchivedTransitionCreate) Save(ctx context.Context) (*ArchivedTransition, error) {
+	atc.defaults()
+	return withHooks(ctx, atc.sqlSave, atc.mutation, atc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (atc *ArchivedTransitionCreate) SaveX(ctx context.Context) *ArchivedTransition {
+	v, err := atc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (atc *ArchivedTransitionCreate) Exec(ctx context.Context) error {
+	_, err := atc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atc *ArchivedTransitionCreate) ExecX(ctx context.Context) {
+	if err := atc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (atc *ArchivedTransitionCreate) defaults() {
+	if _, ok := atc.mutation.Timestamp(); !ok {
+		v := archivedtransition.DefaultTimestamp()
+		atc.mutation.SetTimestamp(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atc *ArchivedTransitionCreate) check() error {
+	if _, ok := atc.mutation.FromState(); !ok {
+		return &ValidationError{Name: "from_state", err: errors.New(`ent: missing required field "ArchivedTransition.from_state"`)}
+	}
+	if _, ok := atc.mutation.ToState(); !ok {
+		return &ValidationError{Name: "to_state", err: errors.New(`ent: missing required field "ArchivedTransition.to_state"`)}
+	}
+	if _, ok := atc.mutation.Event(); !ok {
+		return &ValidationError{Name: "event", err: errors.New(`ent: missing required field "ArchivedTransition.event"`)}
+	}
+	if _, ok := atc.mutation.Timestamp(); !ok {
+		return &ValidationError{Name: "timestamp", err: errors.New(`ent: missing required field "ArchivedTransition.timestamp"`)}
+	}
+	if _, ok := atc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "ArchivedTransition.kind"`)}
+	}
+	return nil
+}
+
+func (atc *ArchivedTransitionCreate) sqlSave(ctx context.Context) (*ArchivedTransition, error) {
+	if err := atc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := atc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, atc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	atc.mutation.id = &_node.ID
+	atc.mutation.done = true
+	return _node, nil
+}
+
+func (atc *ArchivedTransitionCreate) createSpec() (*ArchivedTransition, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ArchivedTransition{config: atc.config}
+		_spec = sqlgraph.NewCreateSpec(archivedtransition.Table, sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt))
+	)
+	if value, ok := atc.mutation.FromState(); ok {
+		_spec.SetField(archivedtransition.FieldFromState, field.TypeString, value)
+		_node.FromState = value
+	}
+	if value, ok := atc.mutation.ToState(); ok {
+		_spec.SetField(archivedtransition.FieldToState, field.TypeString, value)
+		_node.ToState = value
+	}
+	if value, ok := atc.mutation.Event(); ok {
+		_spec.SetField(archivedtransition.FieldEvent, field.TypeString, value)
+		_node.Event = value
+	}
+	if value, ok := atc.mutation.Timestamp(); ok {
+		_spec.SetField(archivedtransition.FieldTimestamp, field.TypeTime, value)
+		_node.Timestamp = value
+	}
+	if value, ok := atc.mutation.Kind(); ok {
+		_spec.SetField(archivedtransition.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
+	if value, ok := atc.mutation.Reason(); ok {
+		_spec.SetField(archivedtransition.FieldReason, field.TypeString, value)
+		_node.Reason = value
+	}
+	if value, ok := atc.mutation.Actor(); ok {
+		_spec.SetField(archivedtransition.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := atc.mutation.Error(); ok {
+		_spec.SetField(archivedtransition.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := atc.mutation.Compensation(); ok {
+		_spec.SetField(archivedtransition.FieldCompensation, field.TypeString, value)
+		_node.Compensation = value
+	}
+	if nodes := atc.mutation.MachineIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   archivedtransition.MachineTable,
+			Columns: []string{archivedtransition.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.archived_machine_history = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ArchivedTransitionCreateBulk is the builder for creating many ArchivedTransition entities in bulk.
+type ArchivedTransitionCreateBulk struct {
+	config
+	err      error
+	builders []*ArchivedTransitionCreate
+}
+
+// Save creates the ArchivedTransition entities in the database.
+func (atcb *ArchivedTransitionCreateBulk) Save(ctx context.Context) ([]*ArchivedTransition, error) {
+	if atcb.err != nil {
+		return nil, atcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(atcb.builders))
+	nodes := make([]*ArchivedTransition, len(atcb.builders))
+	mutators := make([]Mutator, len(atcb.builders))
+	for i := range atcb.builders {
+		func(i int, root context.Context) {
+			builder := atcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ArchivedTransitionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, atcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, atcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, atcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atcb *ArchivedTransitionCreateBulk) SaveX(ctx context.Context) []*ArchivedTransition {
+	v, err := atcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (atcb *ArchivedTransitionCreateBulk) Exec(ctx context.Context) error {
+	_, err := atcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atcb *ArchivedTransitionCreateBulk) ExecX(ctx context.Context) {
+	if err := atcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,89 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedTransitionDelete is the builder for deleting a ArchivedTransition entity.
+type ArchivedTransitionDelete struct {
+	config
+	hooks    []Hook
+	mutation *ArchivedTransitionMutation
+}
+
+// Where appends a list predicates to the ArchivedTransitionDelete builder.
+func (atd *ArchivedTransitionDelete) Where(ps ...predicate.ArchivedTransition) *ArchivedTransitionDelete {
+	atd.mutation.Where(ps...)
+	return atd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (atd *ArchivedTransitionDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, atd.sqlExec, atd.mutation, atd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atd *ArchivedTransitionDelete) ExecX(ctx context.Context) int {
+	n, err := atd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (atd *ArchivedTransitionDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(archivedtransition.Table, sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt))
+	if ps := atd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, atd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	atd.mutation.done = true
+	return affected, err
+}
+
+// ArchivedTransitionDeleteOne is the builder for deleting a single ArchivedTransition entity.
+type ArchivedTransitionDeleteOne struct {
+	atd *ArchivedTransitionDelete
+}
+
+// Where appends a list predicates to the ArchivedTransitionDelete builder.
+func (atdo *ArchivedTransitionDeleteOne) Where(ps ...predicate.ArchivedTransition) *ArchivedTransitionDeleteOne {
+	atdo.atd.mutation.Where(ps...)
+	return atdo
+}
+
+// Exec executes the deletion query.
+func (atdo *ArchivedTransitionDeleteOne) Exec(ctx context.Context) error {
+	n, err := atdo.atd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{archivedtransition.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atdo *ArchivedTransitionDeleteOne) ExecX(ctx context.Context) {
+	if err := atdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,615 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedTransitionQuery is the builder for querying ArchivedTransition entities.
+type ArchivedTransitionQuery struct {
+	config
+	ctx         *QueryContext
+	order       []archivedtransition.OrderOption
+	inters      []Interceptor
+	predicates  []predicate.ArchivedTransition
+	withMachine *ArchivedMachineQuery
+	withFKs     bool
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ArchivedTransitionQuery builder.
+func (atq *ArchivedTransitionQuery) Where(ps ...predicate.ArchivedTransition) *ArchivedTransitionQuery {
+	atq.predicates = append(atq.predicates, ps...)
+	return atq
+}
+
+// Limit the number of records to be returned by this query.
+func (atq *ArchivedTransitionQuery) Limit(limit int) *ArchivedTransitionQuery {
+	atq.ctx.Limit = &limit
+	return atq
+}
+
+// Offset to start from.
+func (atq *ArchivedTransitionQuery) Offset(offset int) *ArchivedTransitionQuery {
+	atq.ctx.Offset = &offset
+	return atq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (atq *ArchivedTransitionQuery) Unique(unique bool) *ArchivedTransitionQuery {
+	atq.ctx.Unique = &unique
+	return atq
+}
+
+// Order specifies how the records should be ordered.
+func (atq *ArchivedTransitionQuery) Order(o ...archivedtransition.OrderOption) *ArchivedTransitionQuery {
+	atq.order = append(atq.order, o...)
+	return atq
+}
+
+// QueryMachine chains the current query on the "machine" edge.
+func (atq *ArchivedTransitionQuery) QueryMachine() *ArchivedMachineQuery {
+	query := (&ArchivedMachineClient{config: atq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := atq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := atq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(archivedtransition.Table, archivedtransition.FieldID, selector),
+			sqlgraph.To(archivedmachine.Table, archivedmachine.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, archivedtransition.MachineTable, archivedtransition.MachineColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(atq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first ArchivedTransition entity from the query.
+// Returns a *NotFoundError when no ArchivedTransition was found.
+func (atq *ArchivedTransitionQuery) First(ctx context.Context) (*ArchivedTransition, error) {
+	nodes, err := atq.Limit(1).All(setContextOp(ctx, atq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{archivedtransition.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) FirstX(ctx context.Context) *ArchivedTransition {
+	node, err := atq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ArchivedTransition ID from the query.
+// Returns a *NotFoundError when no ArchivedTransition ID was found.
+func (atq *ArchivedTransitionQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = atq.Limit(1).IDs(setContextOp(ctx, atq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{archivedtransition.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) FirstIDX(ctx context.Context) int {
+	id, err := atq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ArchivedTransition entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ArchivedTransition entity is found.
+// Returns a *NotFoundError when no ArchivedTransition entities are found.
+func (atq *ArchivedTransitionQuery) Only(ctx context.Context) (*ArchivedTransition, error) {
+	nodes, err := atq.Limit(2).All(setContextOp(ctx, atq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{archivedtransition.Label}
+	default:
+		return nil, &NotSingularError{archivedtransition.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) OnlyX(ctx context.Context) *ArchivedTransition {
+	node, err := atq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ArchivedTransition ID in the query.
+// Returns a *NotSingularError when more than one ArchivedTransition ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (atq *ArchivedTransitionQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = atq.Limit(2).IDs(setContextOp(ctx, atq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{archivedtransition.Label}
+	default:
+		err = &NotSingularError{archivedtransition.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) OnlyIDX(ctx context.Context) int {
+	id, err := atq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ArchivedTransitions.
+func (atq *ArchivedTransitionQuery) All(ctx context.Context) ([]*ArchivedTransition, error) {
+	ctx = setContextOp(ctx, atq.ctx, ent.OpQueryAll)
+	if err := atq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ArchivedTransition, *ArchivedTransitionQuery]()
+	return withInterceptors[[]*ArchivedTransition](ctx, atq, qr, atq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) AllX(ctx context.Context) []*ArchivedTransition {
+	nodes, err := atq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ArchivedTransition IDs.
+func (atq *ArchivedTransitionQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if atq.ctx.Unique == nil && atq.path != nil {
+		atq.Unique(true)
+	}
+	ctx = setContextOp(ctx, atq.ctx, ent.OpQueryIDs)
+	if err = atq.Select(archivedtransition.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) IDsX(ctx context.Context) []int {
+	ids, err := atq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (atq *ArchivedTransitionQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, atq.ctx, ent.OpQueryCount)
+	if err := atq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, atq, querierCount[*ArchivedTransitionQuery](), atq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) CountX(ctx context.Context) int {
+	count, err := atq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (atq *ArchivedTransitionQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, atq.ctx, ent.OpQueryExist)
+	switch _, err := atq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (atq *ArchivedTransitionQuery) ExistX(ctx context.Context) bool {
+	exist, err := atq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ArchivedTransitionQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (atq *ArchivedTransitionQuery) Clone() *ArchivedTransitionQuery {
+	if atq == nil {
+		return nil
+	}
+	return &ArchivedTransitionQuery{
+		config:      atq.config,
+		ctx:         atq.ctx.Clone(),
+		order:       append([]archivedtransition.OrderOption{}, atq.order...),
+		inters:      append([]Interceptor{}, atq.inters...),
+		predicates:  append([]predicate.ArchivedTransition{}, atq.predicates...),
+		withMachine: atq.withMachine.Clone(),
+		// clone intermediate query.
+		sql:  atq.sql.Clone(),
+		path: atq.path,
+	}
+}
+
+// WithMachine tells the query-builder to eager-load the nodes that are connected to
+// the "machine" edge. The optional arguments are used to configure the query builder of the edge.
+func (atq *ArchivedTransitionQuery) WithMachine(opts ...func(*ArchivedMachineQuery)) *ArchivedTransitionQuery {
+	query := (&ArchivedMachineClient{config: atq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	atq.withMachine = query
+	return atq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		FromState string `json:"from_state,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ArchivedTransition.Query().
+//		GroupBy(archivedtransition.FieldFromState).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (atq *ArchivedTransitionQuery) GroupBy(field string, fields ...string) *ArchivedTransitionGroupBy {
+	atq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ArchivedTransitionGroupBy{build: atq}
+	grbuild.flds = &atq.ctx.Fields
+	grbuild.label = archivedtransition.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		FromState string `json:"from_state,omitempty"`
+//	}
+//
+//	client.ArchivedTransition.Query().
+//		Select(archivedtransition.FieldFromState).
+//		Scan(ctx, &v)
+func (atq *ArchivedTransitionQuery) Select(fields ...string) *ArchivedTransitionSelect {
+	atq.ctx.Fields = append(atq.ctx.Fields, fields...)
+	sbuild := &ArchivedTransitionSelect{ArchivedTransitionQuery: atq}
+	sbuild.label = archivedtransition.Label
+	sbuild.flds, sbuild.scan = &atq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ArchivedTransitionSelect configured with the given aggregations.
+func (atq *ArchivedTransitionQuery) Aggregate(fns ...AggregateFunc) *ArchivedTransitionSelect {
+	return atq.Select().Aggregate(fns...)
+}
+
+func (atq *ArchivedTransitionQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range atq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, atq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range atq.ctx.Fields {
+		if !archivedtransition.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if atq.path != nil {
+		prev, err := atq.path(ctx)
+		if err != nil {
+			return err
+		}
+		atq.sql = prev
+	}
+	return nil
+}
+
+func (atq *ArchivedTransitionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ArchivedTransition, error) {
+	var (
+		nodes       = []*ArchivedTransition{}
+		withFKs     = atq.withFKs
+		_spec       = atq.querySpec()
+		loadedTypes = [1]bool{
+			atq.withMachine != nil,
+		}
+	)
+	if atq.withMachine != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, archivedtransition.ForeignKeys...)
+	}
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ArchivedTransition).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ArchivedTransition{config: atq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, atq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := atq.withMachine; query != nil {
+		if err := atq.loadMachine(ctx, query, nodes, nil,
+			func(n *ArchivedTransition, e *ArchivedMachine) { n.Edges.Machine = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (atq *ArchivedTransitionQuery) loadMachine(ctx context.Context, query *ArchivedMachineQuery, nodes []*ArchivedTransition, init func(*ArchivedTransition), assign func(*ArchivedTransition, *ArchivedMachine)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*ArchivedTransition)
+	for i := range nodes {
+		if nodes[i].archived_machine_history == nil {
+			continue
+		}
+		fk := *nodes[i].archived_machine_history
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(archivedmachine.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "archived_machine_history" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (atq *ArchivedTransitionQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := atq.querySpec()
+	_spec.Node.Columns = atq.ctx.Fields
+	if len(atq.ctx.Fields) > 0 {
+		_spec.Unique = atq.ctx.Unique != nil && *atq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, atq.driver, _spec)
+}
+
+func (atq *ArchivedTransitionQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(archivedtransition.Table, archivedtransition.Columns, sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt))
+	_spec.From = atq.sql
+	if unique := atq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if atq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := atq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, archivedtransition.FieldID)
+		for i := range fields {
+			if fields[i] != archivedtransition.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := atq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := atq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := atq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := atq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (atq *ArchivedTransitionQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(atq.driver.Dialect())
+	t1 := builder.Table(archivedtransition.Table)
+	columns := atq.ctx.Fields
+	if len(columns) == 0 {
+		columns = archivedtransition.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if atq.sql != nil {
+		selector = atq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if atq.ctx.Unique != nil && *atq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range atq.predicates {
+		p(selector)
+	}
+	for _, p := range atq.order {
+		p(selector)
+	}
+	if offset := atq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := atq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ArchivedTransitionGroupBy is the group-by builder for ArchivedTransition entities.
+type ArchivedTransitionGroupBy struct {
+	selector
+	build *ArchivedTransitionQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (atgb *ArchivedTransitionGroupBy) Aggregate(fns ...AggregateFunc) *ArchivedTransitionGroupBy {
+	atgb.fns = append(atgb.fns, fns...)
+	return atgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (atgb *ArchivedTransitionGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, atgb.build.ctx, ent.OpQueryGroupBy)
+	if err := atgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ArchivedTransitionQuery, *ArchivedTransitionGroupBy](ctx, atgb.build, atgb, atgb.build.inters, v)
+}
+
+func (atgb *ArchivedTransitionGroupBy) sqlScan(ctx context.Context, root *ArchivedTransitionQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(atgb.fns))
+	for _, fn := range atgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*atgb.flds)+len(atgb.fns))
+		for _, f := range *atgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*atgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := atgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ArchivedTransitionSelect is the builder for selecting fields of ArchivedTransition entities.
+type ArchivedTransitionSelect struct {
+	*ArchivedTransitionQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ats *ArchivedTransitionSelect) Aggregate(fns ...AggregateFunc) *ArchivedTransitionSelect {
+	ats.fns = append(ats.fns, fns...)
+	return ats
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ats *ArchivedTransitionSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ats.ctx, ent.OpQuerySelect)
+	if err := ats.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ArchivedTransitionQuery, *ArchivedTransitionSelect](ctx, ats.ArchivedTransitionQuery, ats, ats.inters, v)
+}
+
+func (ats *ArchivedTransitionSelect) sqlScan(ctx context.Context, root *ArchivedTransitionQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ats.fns))
+	for _, fn := range ats.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ats.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ats.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
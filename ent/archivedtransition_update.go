@@ -0,0 +1,664 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedTransitionUpdate is the builder for updating ArchivedTransition entities.
+type ArchivedTransitionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ArchivedTransitionMutation
+}
+
+// Where appends a list predicates to the ArchivedTransitionUpdate builder.
+func (atu *ArchivedTransitionUpdate) Where(ps ...predicate.ArchivedTransition) *ArchivedTransitionUpdate {
+	atu.mutation.Where(ps...)
+	return atu
+}
+
+// SetFromState sets the "from_state" field.
+func (atu *ArchivedTransitionUpdate) SetFromState(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetFromState(s)
+	return atu
+}
+
+// SetNillableFromState sets the "from_state" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableFromState(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetFromState(*s)
+	}
+	return atu
+}
+
+// SetToState sets the "to_state" field.
+func (atu *ArchivedTransitionUpdate) SetToState(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetToState(s)
+	return atu
+}
+
+// SetNillableToState sets the "to_state" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableToState(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetToState(*s)
+	}
+	return atu
+}
+
+// SetEvent sets the "event" field.
+func (atu *ArchivedTransitionUpdate) SetEvent(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetEvent(s)
+	return atu
+}
+
+// SetNillableEvent sets the "event" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableEvent(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetEvent(*s)
+	}
+	return atu
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (atu *ArchivedTransitionUpdate) SetTimestamp(t time.Time) *ArchivedTransitionUpdate {
+	atu.mutation.SetTimestamp(t)
+	return atu
+}
+
+// SetNillableTimestamp sets the "timestamp" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableTimestamp(t *time.Time) *ArchivedTransitionUpdate {
+	if t != nil {
+		atu.SetTimestamp(*t)
+	}
+	return atu
+}
+
+// SetKind sets the "kind" field.
+func (atu *ArchivedTransitionUpdate) SetKind(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetKind(s)
+	return atu
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableKind(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetKind(*s)
+	}
+	return atu
+}
+
+// SetReason sets the "reason" field.
+func (atu *ArchivedTransitionUpdate) SetReason(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetReason(s)
+	return atu
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableReason(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetReason(*s)
+	}
+	return atu
+}
+
+// ClearReason clears the value of the "reason" field.
+func (atu *ArchivedTransitionUpdate) ClearReason() *ArchivedTransitionUpdate {
+	atu.mutation.ClearReason()
+	return atu
+}
+
+// SetActor sets the "actor" field.
+func (atu *ArchivedTransitionUpdate) SetActor(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetActor(s)
+	return atu
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableActor(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetActor(*s)
+	}
+	return atu
+}
+
+// ClearActor clears the value of the "actor" field.
+func (atu *ArchivedTransitionUpdate) ClearActor() *ArchivedTransitionUpdate {
+	atu.mutation.ClearActor()
+	return atu
+}
+
+// SetError sets the "error" field.
+func (atu *ArchivedTransitionUpdate) SetError(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetError(s)
+	return atu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableError(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetError(*s)
+	}
+	return atu
+}
+
+// ClearError clears the value of the "error" field.
+func (atu *ArchivedTransitionUpdate) ClearError() *ArchivedTransitionUpdate {
+	atu.mutation.ClearError()
+	return atu
+}
+
+// SetCompensation sets the "compensation" field.
+func (atu *ArchivedTransitionUpdate) SetCompensation(s string) *ArchivedTransitionUpdate {
+	atu.mutation.SetCompensation(s)
+	return atu
+}
+
+// SetNillableCompensation sets the "compensation" field if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableCompensation(s *string) *ArchivedTransitionUpdate {
+	if s != nil {
+		atu.SetCompensation(*s)
+	}
+	return atu
+}
+
+// ClearCompensation clears the value of the "compensation" field.
+func (atu *ArchivedTransitionUpdate) ClearCompensation() *ArchivedTransitionUpdate {
+	atu.mutation.ClearCompensation()
+	return atu
+}
+
+// SetMachineID sets the "machine" edge to the ArchivedMachine entity by ID.
+func (atu *ArchivedTransitionUpdate) SetMachineID(id int) *ArchivedTransitionUpdate {
+	atu.mutation.SetMachineID(id)
+	return atu
+}
+
+// SetNillableMachineID sets the "machine" edge to the ArchivedMachine entity by ID if the given value is not nil.
+func (atu *ArchivedTransitionUpdate) SetNillableMachineID(id *int) *ArchivedTransitionUpdate {
+	if id != nil {
+		atu = atu.SetMachineID(*id)
+	}
+	return atu
+}
+
+// SetMachine sets the "machine" edge to the ArchivedMachine entity.
+func (atu *ArchivedTransitionUpdate) SetMachine(a *ArchivedMachine) *ArchivedTransitionUpdate {
+	return atu.SetMachineID(a.ID)
+}
+
+// Mutation returns the ArchivedTransitionMutation object of the builder.
+func (atu *ArchivedTransitionUpdate) Mutation() *ArchivedTransitionMutation {
+	return atu.mutation
+}
+
+// ClearMachine clears the "machine" edge to the ArchivedMachine entity.
+func (atu *ArchivedTransitionUpdate) ClearMachine() *ArchivedTransitionUpdate {
+	atu.mutation.ClearMachine()
+	return atu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (atu *ArchivedTransitionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, atu.sqlSave, atu.mutation, atu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atu *ArchivedTransitionUpdate) SaveX(ctx context.Context) int {
+	affected, err := atu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (atu *ArchivedTransitionUpdate) Exec(ctx context.Context) error {
+	_, err := atu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atu *ArchivedTransitionUpdate) ExecX(ctx context.Context) {
+	if err := atu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (atu *ArchivedTransitionUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(archivedtransition.Table, archivedtransition.Columns, sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt))
+	if ps := atu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := atu.mutation.FromState(); ok {
+		_spec.SetField(archivedtransition.FieldFromState, field.TypeString, value)
+	}
+	if value, ok := atu.mutation.ToState(); ok {
+		_spec.SetField(archivedtransition.FieldToState, field.TypeString, value)
+	}
+	if value, ok := atu.mutation.Event(); ok {
+		_spec.SetField(archivedtransition.FieldEvent, field.TypeString, value)
+	}
+	if value, ok := atu.mutation.Timestamp(); ok {
+		_spec.SetField(archivedtransition.FieldTimestamp, field.TypeTime, value)
+	}
+	if value, ok := atu.mutation.Kind(); ok {
+		_spec.SetField(archivedtransition.FieldKind, field.TypeString, value)
+	}
+	if value, ok := atu.mutation.Reason(); ok {
+		_spec.SetField(archivedtransition.FieldReason, field.TypeString, value)
+	}
+	if atu.mutation.ReasonCleared() {
+		_spec.ClearField(archivedtransition.FieldReason, field.TypeString)
+	}
+	if value, ok := atu.mutation.Actor(); ok {
+		_spec.SetField(archivedtransition.FieldActor, field.TypeString, value)
+	}
+	if atu.mutation.ActorCleared() {
+		_spec.ClearField(archivedtransition.FieldActor, field.TypeString)
+	}
+	if value, ok := atu.mutation.Error(); ok {
+		_spec.SetField(archivedtransition.FieldError, field.TypeString, value)
+	}
+	if atu.mutation.ErrorCleared() {
+		_spec.ClearField(archivedtransition.FieldError, field.TypeString)
+	}
+	if value, ok := atu.mutation.Compensation(); ok {
+		_spec.SetField(archivedtransition.FieldCompensation, field.TypeString, value)
+	}
+	if atu.mutation.CompensationCleared() {
+		_spec.ClearField(archivedtransition.FieldCompensation, field.TypeString)
+	}
+	if atu.mutation.MachineCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   archivedtransition.MachineTable,
+			Columns: []string{archivedtransition.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := atu.mutation.MachineIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   archivedtransition.MachineTable,
+			Columns: []string{archivedtransition.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, atu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{archivedtransition.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	atu.mutation.done = true
+	return n, nil
+}
+
+// ArchivedTransitionUpdateOne is the builder for updating a single ArchivedTransition entity.
+type ArchivedTransitionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ArchivedTransitionMutation
+}
+
+// SetFromState sets the "from_state" field.
+func (atuo *ArchivedTransitionUpdateOne) SetFromState(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetFromState(s)
+	return atuo
+}
+
+// SetNillableFromState sets the "from_state" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableFromState(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetFromState(*s)
+	}
+	return atuo
+}
+
+// SetToState sets the "to_state" field.
+func (atuo *ArchivedTransitionUpdateOne) SetToState(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetToState(s)
+	return atuo
+}
+
+// SetNillableToState sets the "to_state" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableToState(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetToState(*s)
+	}
+	return atuo
+}
+
+// SetEvent sets the "event" field.
+func (atuo *ArchivedTransitionUpdateOne) SetEvent(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetEvent(s)
+	return atuo
+}
+
+// SetNillableEvent sets the "event" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableEvent(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetEvent(*s)
+	}
+	return atuo
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (atuo *ArchivedTransitionUpdateOne) SetTimestamp(t time.Time) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetTimestamp(t)
+	return atuo
+}
+
+// SetNillableTimestamp sets the "timestamp" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableTimestamp(t *time.Time) *ArchivedTransitionUpdateOne {
+	if t != nil {
+		atuo.SetTimestamp(*t)
+	}
+	return atuo
+}
+
+// SetKind sets the "kind" field.
+func (atuo *ArchivedTransitionUpdateOne) SetKind(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetKind(s)
+	return atuo
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableKind(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetKind(*s)
+	}
+	return atuo
+}
+
+// SetReason sets the "reason" field.
+func (atuo *ArchivedTransitionUpdateOne) SetReason(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetReason(s)
+	return atuo
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableReason(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetReason(*s)
+	}
+	return atuo
+}
+
+// ClearReason clears the value of the "reason" field.
+func (atuo *ArchivedTransitionUpdateOne) ClearReason() *ArchivedTransitionUpdateOne {
+	atuo.mutation.ClearReason()
+	return atuo
+}
+
+// SetActor sets the "actor" field.
+func (atuo *ArchivedTransitionUpdateOne) SetActor(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetActor(s)
+	return atuo
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableActor(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetActor(*s)
+	}
+	return atuo
+}
+
+// ClearActor clears the value of the "actor" field.
+func (atuo *ArchivedTransitionUpdateOne) ClearActor() *ArchivedTransitionUpdateOne {
+	atuo.mutation.ClearActor()
+	return atuo
+}
+
+// SetError sets the "error" field.
+func (atuo *ArchivedTransitionUpdateOne) SetError(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetError(s)
+	return atuo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableError(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetError(*s)
+	}
+	return atuo
+}
+
+// ClearError clears the value of the "error" field.
+func (atuo *ArchivedTransitionUpdateOne) ClearError() *ArchivedTransitionUpdateOne {
+	atuo.mutation.ClearError()
+	return atuo
+}
+
+// SetCompensation sets the "compensation" field.
+func (atuo *ArchivedTransitionUpdateOne) SetCompensation(s string) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetCompensation(s)
+	return atuo
+}
+
+// SetNillableCompensation sets the "compensation" field if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableCompensation(s *string) *ArchivedTransitionUpdateOne {
+	if s != nil {
+		atuo.SetCompensation(*s)
+	}
+	return atuo
+}
+
+// ClearCompensation clears the value of the "compensation" field.
+func (atuo *ArchivedTransitionUpdateOne) ClearCompensation() *ArchivedTransitionUpdateOne {
+	atuo.mutation.ClearCompensation()
+	return atuo
+}
+
+// SetMachineID sets the "machine" edge to the ArchivedMachine entity by ID.
+func (atuo *ArchivedTransitionUpdateOne) SetMachineID(id int) *ArchivedTransitionUpdateOne {
+	atuo.mutation.SetMachineID(id)
+	return atuo
+}
+
+// SetNillableMachineID sets the "machine" edge to the ArchivedMachine entity by ID if the given value is not nil.
+func (atuo *ArchivedTransitionUpdateOne) SetNillableMachineID(id *int) *ArchivedTransitionUpdateOne {
+	if id != nil {
+		atuo = atuo.SetMachineID(*id)
+	}
+	return atuo
+}
+
+// SetMachine sets the "machine" edge to the ArchivedMachine entity.
+func (atuo *ArchivedTransitionUpdateOne) SetMachine(a *ArchivedMachine) *ArchivedTransitionUpdateOne {
+	return atuo.SetMachineID(a.ID)
+}
+
+// Mutation returns the ArchivedTransitionMutation object of the builder.
+func (atuo *ArchivedTransitionUpdateOne) Mutation() *ArchivedTransitionMutation {
+	return atuo.mutation
+}
+
+// ClearMachine clears the "machine" edge to the ArchivedMachine entity.
+func (atuo *ArchivedTransitionUpdateOne) ClearMachine() *ArchivedTransitionUpdateOne {
+	atuo.mutation.ClearMachine()
+	return atuo
+}
+
+// Where appends a list predicates to the ArchivedTransitionUpdate builder.
+func (atuo *ArchivedTransitionUpdateOne) Where(ps ...predicate.ArchivedTransition) *ArchivedTransitionUpdateOne {
+	atuo.mutation.Where(ps...)
+	return atuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (atuo *ArchivedTransitionUpdateOne) Select(field string, fields ...string) *ArchivedTransitionUpdateOne {
+	atuo.fields = append([]string{field}, fields...)
+	return atuo
+}
+
+// Save executes the query and returns the updated ArchivedTransition entity.
+func (atuo *ArchivedTransitionUpdateOne) Save(ctx context.Context) (*ArchivedTransition, error) {
+	return withHooks(ctx, atuo.sqlSave, atuo.mutation, atuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atuo *ArchivedTransitionUpdateOne) SaveX(ctx context.Context) *ArchivedTransition {
+	node, err := atuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (atuo *ArchivedTransitionUpdateOne) Exec(ctx context.Context) error {
+	_, err := atuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atuo *ArchivedTransitionUpdateOne) ExecX(ctx context.Context) {
+	if err := atuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (atuo *ArchivedTransitionUpdateOne) sqlSave(ctx context.Context) (_node *ArchivedTransition, err error) {
+	_spec := sqlgraph.NewUpdateSpec(archivedtransition.Table, archivedtransition.Columns, sqlgraph.NewFieldSpec(archivedtransition.FieldID, field.TypeInt))
+	id, ok := atuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ArchivedTransition.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := atuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, archivedtransition.FieldID)
+		for _, f := range fields {
+			if !archivedtransition.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != archivedtransition.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := atuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := atuo.mutation.FromState(); ok {
+		_spec.SetField(archivedtransition.FieldFromState, field.TypeString, value)
+	}
+	if value, ok := atuo.mutation.ToState(); ok {
+		_spec.SetField(archivedtransition.FieldToState, field.TypeString, value)
+	}
+	if value, ok := atuo.mutation.Event(); ok {
+		_spec.SetField(archivedtransition.FieldEvent, field.TypeString, value)
+	}
+	if value, ok := atuo.mutation.Timestamp(); ok {
+		_spec.SetField(archivedtransition.FieldTimestamp, field.TypeTime, value)
+	}
+	if value, ok := atuo.mutation.Kind(); ok {
+		_spec.SetField(archivedtransition.FieldKind, field.TypeString, value)
+	}
+	if value, ok := atuo.mutation.Reason(); ok {
+		_spec.SetField(archivedtransition.FieldReason, field.TypeString, value)
+	}
+	if atuo.mutation.ReasonCleared() {
+		_spec.ClearField(archivedtransition.FieldReason, field.TypeString)
+	}
+	if value, ok := atuo.mutation.Actor(); ok {
+		_spec.SetField(archivedtransition.FieldActor, field.TypeString, value)
+	}
+	if atuo.mutation.ActorCleared() {
+		_spec.ClearField(archivedtransition.FieldActor, field.TypeString)
+	}
+	if value, ok := atuo.mutation.Error(); ok {
+		_spec.SetField(archivedtransition.FieldError, field.TypeString, value)
+	}
+	if atuo.mutation.ErrorCleared() {
+		_spec.ClearField(archivedtransition.FieldError, field.TypeString)
+	}
+	if value, ok := atuo.mutation.Compensation(); ok {
+		_spec.SetField(archivedtransition.FieldCompensation, field.TypeString, value)
+	}
+	if atuo.mutation.CompensationCleared() {
+		_spec.ClearField(archivedtransition.FieldCompensation, field.TypeString)
+	}
+	if atuo.mutation.MachineCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   archivedtransition.MachineTable,
+			Columns: []string{archivedtransition.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := atuo.mutation.MachineIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   archivedtransition.MachineTable,
+			Columns: []string{archivedtransition.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(archivedmachine.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ArchivedTransition{config: atuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, atuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{archivedtransition.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	atuo.mutation.done = true
+	return _node, nil
+}
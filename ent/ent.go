@@ -9,6 +9,14 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/deadletter"
+	"github.com/shinhauhuang/go-fsm/ent/job"
+	"github.com/shinhauhuang/go-fsm/ent/lease"
+	"github.com/shinhauhuang/go-fsm/ent/outboxmessage"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/schedule"
 	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 	"github.com/shinhauhuang/go-fsm/ent/statetransition"
 
@@ -75,8 +83,16 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			statemachine.Table:    statemachine.ValidColumn,
-			statetransition.Table: statetransition.ValidColumn,
+			archivedmachine.Table:    archivedmachine.ValidColumn,
+			archivedtransition.Table: archivedtransition.ValidColumn,
+			deadletter.Table:         deadletter.ValidColumn,
+			job.Table:                job.ValidColumn,
+			lease.Table:              lease.ValidColumn,
+			outboxmessage.Table:      outboxmessage.ValidColumn,
+			processedevent.Table:     processedevent.ValidColumn,
+			schedule.Table:           schedule.ValidColumn,
+			statemachine.Table:       statemachine.ValidColumn,
+			statetransition.Table:    statetransition.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -8,17 +8,255 @@ import (
 )
 
 var (
+	// ArchivedMachinesColumns holds the columns for the "archived_machines" table.
+	ArchivedMachinesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "machine_id", Type: field.TypeString},
+		{Name: "current_state", Type: field.TypeString},
+		{Name: "definition_name", Type: field.TypeString, Default: ""},
+		{Name: "definition_version", Type: field.TypeInt, Default: 0},
+		{Name: "data", Type: field.TypeBytes, Nullable: true},
+		{Name: "suspended", Type: field.TypeBool, Default: false},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "entered_at", Type: field.TypeTime, Nullable: true},
+		{Name: "archived_at", Type: field.TypeTime},
+	}
+	// ArchivedMachinesTable holds the schema information for the "archived_machines" table.
+	ArchivedMachinesTable = &schema.Table{
+		Name:       "archived_machines",
+		Columns:    ArchivedMachinesColumns,
+		PrimaryKey: []*schema.Column{ArchivedMachinesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "archivedmachine_machine_id",
+				Unique:  false,
+				Columns: []*schema.Column{ArchivedMachinesColumns[1]},
+			},
+			{
+				Name:    "archivedmachine_archived_at",
+				Unique:  false,
+				Columns: []*schema.Column{ArchivedMachinesColumns[10]},
+			},
+		},
+	}
+	// ArchivedTransitionsColumns holds the columns for the "archived_transitions" table.
+	ArchivedTransitionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "from_state", Type: field.TypeString},
+		{Name: "to_state", Type: field.TypeString},
+		{Name: "event", Type: field.TypeString},
+		{Name: "timestamp", Type: field.TypeTime},
+		{Name: "kind", Type: field.TypeString},
+		{Name: "reason", Type: field.TypeString, Nullable: true},
+		{Name: "actor", Type: field.TypeString, Nullable: true},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "compensation", Type: field.TypeString, Nullable: true},
+		{Name: "archived_machine_history", Type: field.TypeInt, Nullable: true},
+	}
+	// ArchivedTransitionsTable holds the schema information for the "archived_transitions" table.
+	ArchivedTransitionsTable = &schema.Table{
+		Name:       "archived_transitions",
+		Columns:    ArchivedTransitionsColumns,
+		PrimaryKey: []*schema.Column{ArchivedTransitionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "archived_transitions_archived_machines_history",
+				Columns:    []*schema.Column{ArchivedTransitionsColumns[10]},
+				RefColumns: []*schema.Column{ArchivedMachinesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// DeadLettersColumns holds the columns for the "dead_letters" table.
+	DeadLettersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "machine_id", Type: field.TypeString},
+		{Name: "event", Type: field.TypeString},
+		{Name: "args", Type: field.TypeBytes, Nullable: true},
+		{Name: "idempotency_key", Type: field.TypeString, Nullable: true},
+		{Name: "phase", Type: field.TypeString, Nullable: true},
+		{Name: "error", Type: field.TypeString},
+		{Name: "attempts", Type: field.TypeInt, Default: 1},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "resolved", "discarded"}, Default: "pending"},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// DeadLettersTable holds the schema information for the "dead_letters" table.
+	DeadLettersTable = &schema.Table{
+		Name:       "dead_letters",
+		Columns:    DeadLettersColumns,
+		PrimaryKey: []*schema.Column{DeadLettersColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "deadletter_status_machine_id",
+				Unique:  false,
+				Columns: []*schema.Column{DeadLettersColumns[8], DeadLettersColumns[1]},
+			},
+		},
+	}
+	// JobsColumns holds the columns for the "jobs" table.
+	JobsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "state", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeBytes, Nullable: true},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "running", "succeeded", "failed"}, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_run_at", Type: field.TypeTime},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "state_machine_jobs", Type: field.TypeInt},
+	}
+	// JobsTable holds the schema information for the "jobs" table.
+	JobsTable = &schema.Table{
+		Name:       "jobs",
+		Columns:    JobsColumns,
+		PrimaryKey: []*schema.Column{JobsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "jobs_state_machines_jobs",
+				Columns:    []*schema.Column{JobsColumns[10]},
+				RefColumns: []*schema.Column{StateMachinesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "job_status_next_run_at",
+				Unique:  false,
+				Columns: []*schema.Column{JobsColumns[4], JobsColumns[6]},
+			},
+		},
+	}
+	// LeasesColumns holds the columns for the "leases" table.
+	LeasesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "holder", Type: field.TypeString},
+		{Name: "expires_at", Type: field.TypeTime},
+	}
+	// LeasesTable holds the schema information for the "leases" table.
+	LeasesTable = &schema.Table{
+		Name:       "leases",
+		Columns:    LeasesColumns,
+		PrimaryKey: []*schema.Column{LeasesColumns[0]},
+	}
+	// OutboxMessagesColumns holds the columns for the "outbox_messages" table.
+	OutboxMessagesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "topic", Type: field.TypeString},
+		{Name: "key", Type: field.TypeString, Default: ""},
+		{Name: "payload", Type: field.TypeBytes, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeTime},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "published_at", Type: field.TypeTime, Nullable: true},
+	}
+	// OutboxMessagesTable holds the schema information for the "outbox_messages" table.
+	OutboxMessagesTable = &schema.Table{
+		Name:       "outbox_messages",
+		Columns:    OutboxMessagesColumns,
+		PrimaryKey: []*schema.Column{OutboxMessagesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "outboxmessage_published_at_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxMessagesColumns[8], OutboxMessagesColumns[6]},
+			},
+		},
+	}
+	// ProcessedEventsColumns holds the columns for the "processed_events" table.
+	ProcessedEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key", Type: field.TypeString},
+		{Name: "event", Type: field.TypeString},
+		{Name: "to_state", Type: field.TypeString},
+		{Name: "processed_at", Type: field.TypeTime},
+		{Name: "state_machine_processed_events", Type: field.TypeInt},
+	}
+	// ProcessedEventsTable holds the schema information for the "processed_events" table.
+	ProcessedEventsTable = &schema.Table{
+		Name:       "processed_events",
+		Columns:    ProcessedEventsColumns,
+		PrimaryKey: []*schema.Column{ProcessedEventsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "processed_events_state_machines_processed_events",
+				Columns:    []*schema.Column{ProcessedEventsColumns[5]},
+				RefColumns: []*schema.Column{StateMachinesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "processedevent_key_state_machine_processed_events",
+				Unique:  true,
+				Columns: []*schema.Column{ProcessedEventsColumns[1], ProcessedEventsColumns[5]},
+			},
+			{
+				Name:    "processedevent_processed_at",
+				Unique:  false,
+				Columns: []*schema.Column{ProcessedEventsColumns[4]},
+			},
+		},
+	}
+	// SchedulesColumns holds the columns for the "schedules" table.
+	SchedulesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "cron", Type: field.TypeString, Nullable: true},
+		{Name: "event", Type: field.TypeString},
+		{Name: "args", Type: field.TypeBytes, Nullable: true},
+		{Name: "machine_id", Type: field.TypeString, Nullable: true},
+		{Name: "definition_name", Type: field.TypeString, Nullable: true},
+		{Name: "state", Type: field.TypeString, Nullable: true},
+		{Name: "next_run_at", Type: field.TypeTime},
+		{Name: "last_run_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// SchedulesTable holds the schema information for the "schedules" table.
+	SchedulesTable = &schema.Table{
+		Name:       "schedules",
+		Columns:    SchedulesColumns,
+		PrimaryKey: []*schema.Column{SchedulesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "schedule_enabled_next_run_at",
+				Unique:  false,
+				Columns: []*schema.Column{SchedulesColumns[11], SchedulesColumns[8]},
+			},
+		},
+	}
 	// StateMachinesColumns holds the columns for the "state_machines" table.
 	StateMachinesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "machine_id", Type: field.TypeString, Unique: true},
 		{Name: "current_state", Type: field.TypeString},
+		{Name: "definition_name", Type: field.TypeString, Default: ""},
+		{Name: "definition_version", Type: field.TypeInt, Default: 0},
+		{Name: "created_at", Type: field.TypeTime, Default: schema.Expr("CURRENT_TIMESTAMP")},
+		{Name: "updated_at", Type: field.TypeTime, Default: schema.Expr("CURRENT_TIMESTAMP")},
+		{Name: "entered_at", Type: field.TypeTime, Nullable: true},
+		{Name: "suspended", Type: field.TypeBool, Default: false},
+		{Name: "data", Type: field.TypeBytes, Nullable: true},
 	}
 	// StateMachinesTable holds the schema information for the "state_machines" table.
 	StateMachinesTable = &schema.Table{
 		Name:       "state_machines",
 		Columns:    StateMachinesColumns,
 		PrimaryKey: []*schema.Column{StateMachinesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "statemachine_definition_name_current_state_entered_at",
+				Unique:  false,
+				Columns: []*schema.Column{StateMachinesColumns[3], StateMachinesColumns[2], StateMachinesColumns[7]},
+			},
+		},
 	}
 	// StateTransitionsColumns holds the columns for the "state_transitions" table.
 	StateTransitionsColumns = []*schema.Column{
@@ -27,6 +265,11 @@ var (
 		{Name: "to_state", Type: field.TypeString},
 		{Name: "event", Type: field.TypeString},
 		{Name: "timestamp", Type: field.TypeTime},
+		{Name: "kind", Type: field.TypeEnum, Enums: []string{"transition", "migration", "failed", "override", "suspend", "resume", "reset"}, Default: "transition"},
+		{Name: "reason", Type: field.TypeString, Nullable: true},
+		{Name: "actor", Type: field.TypeString, Nullable: true},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "compensation", Type: field.TypeEnum, Enums: []string{"none", "succeeded", "failed"}, Default: "none"},
 		{Name: "state_machine_history", Type: field.TypeInt, Nullable: true},
 	}
 	// StateTransitionsTable holds the schema information for the "state_transitions" table.
@@ -37,19 +280,52 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "state_transitions_state_machines_history",
-				Columns:    []*schema.Column{StateTransitionsColumns[5]},
+				Columns:    []*schema.Column{StateTransitionsColumns[10]},
 				RefColumns: []*schema.Column{StateMachinesColumns[0]},
-				OnDelete:   schema.SetNull,
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "statetransition_state_machine_history",
+				Unique:  false,
+				Columns: []*schema.Column{StateTransitionsColumns[10]},
+			},
+			{
+				Name:    "statetransition_timestamp",
+				Unique:  false,
+				Columns: []*schema.Column{StateTransitionsColumns[4]},
+			},
+			{
+				Name:    "statetransition_event",
+				Unique:  false,
+				Columns: []*schema.Column{StateTransitionsColumns[3]},
+			},
+			{
+				Name:    "statetransition_actor",
+				Unique:  false,
+				Columns: []*schema.Column{StateTransitionsColumns[7]},
 			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		ArchivedMachinesTable,
+		ArchivedTransitionsTable,
+		DeadLettersTable,
+		JobsTable,
+		LeasesTable,
+		OutboxMessagesTable,
+		ProcessedEventsTable,
+		SchedulesTable,
 		StateMachinesTable,
 		StateTransitionsTable,
 	}
 )
 
 func init() {
+	ArchivedTransitionsTable.ForeignKeys[0].RefTable = ArchivedMachinesTable
+	JobsTable.ForeignKeys[0].RefTable = StateMachinesTable
+	ProcessedEventsTable.ForeignKeys[0].RefTable = StateMachinesTable
 	StateTransitionsTable.ForeignKeys[0].RefTable = StateMachinesTable
 }
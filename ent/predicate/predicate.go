@@ -6,6 +6,30 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// ArchivedMachine is the predicate function for archivedmachine builders.
+type ArchivedMachine func(*sql.Selector)
+
+// ArchivedTransition is the predicate function for archivedtransition builders.
+type ArchivedTransition func(*sql.Selector)
+
+// DeadLetter is the predicate function for deadletter builders.
+type DeadLetter func(*sql.Selector)
+
+// Job is the predicate function for job builders.
+type Job func(*sql.Selector)
+
+// Lease is the predicate function for lease builders.
+type Lease func(*sql.Selector)
+
+// OutboxMessage is the predicate function for outboxmessage builders.
+type OutboxMessage func(*sql.Selector)
+
+// ProcessedEvent is the predicate function for processedevent builders.
+type ProcessedEvent func(*sql.Selector)
+
+// Schedule is the predicate function for schedule builders.
+type Schedule func(*sql.Selector)
+
 // StateMachine is the predicate function for statemachine builders.
 type StateMachine func(*sql.Selector)
 
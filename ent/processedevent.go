@@ -0,0 +1,179 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ProcessedEvent is the model entity for the ProcessedEvent schema.
+type ProcessedEvent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Key holds the value of the "key" field.
+	Key string `json:"key,omitempty"`
+	// Event holds the value of the "event" field.
+	Event string `json:"event,omitempty"`
+	// ToState holds the value of the "to_state" field.
+	ToState string `json:"to_state,omitempty"`
+	// ProcessedAt holds the value of the "processed_at" field.
+	ProcessedAt time.Time `json:"processed_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ProcessedEventQuery when eager-loading is set.
+	Edges                          ProcessedEventEdges `json:"edges"`
+	state_machine_processed_events *int
+	selectValues                   sql.SelectValues
+}
+
+// ProcessedEventEdges holds the relations/edges for other nodes in the graph.
+type ProcessedEventEdges struct {
+	// Machine holds the value of the machine edge.
+	Machine *StateMachine `json:"machine,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// MachineOrErr returns the Machine value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ProcessedEventEdges) MachineOrErr() (*StateMachine, error) {
+	if e.Machine != nil {
+		return e.Machine, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: statemachine.Label}
+	}
+	return nil, &NotLoadedError{edge: "machine"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ProcessedEvent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case processedevent.FieldID:
+			values[i] = new(sql.NullInt64)
+		case processedevent.FieldKey, processedevent.FieldEvent, processedevent.FieldToState:
+			values[i] = new(sql.NullString)
+		case processedevent.FieldProcessedAt:
+			values[i] = new(sql.NullTime)
+		case processedevent.ForeignKeys[0]: // state_machine_processed_events
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ProcessedEvent fields.
+func (pe *ProcessedEvent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case processedevent.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pe.ID = int(value.Int64)
+		case processedevent.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				pe.Key = value.String
+			}
+		case processedevent.FieldEvent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field event", values[i])
+			} else if value.Valid {
+				pe.Event = value.String
+			}
+		case processedevent.FieldToState:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field to_state", values[i])
+			} else if value.Valid {
+				pe.ToState = value.String
+			}
+		case processedevent.FieldProcessedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field processed_at", values[i])
+			} else if value.Valid {
+				pe.ProcessedAt = value.Time
+			}
+		case processedevent.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field state_machine_processed_events", value)
+			} else if value.Valid {
+				pe.state_machine_processed_events = new(int)
+				*pe.state_machine_processed_events = int(value.Int64)
+			}
+		default:
+			pe.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ProcessedEvent.
+// This includes values selected through modifiers, order, etc.
+func (pe *ProcessedEvent) Value(name string) (ent.Value, error) {
+	return pe.selectValues.Get(name)
+}
+
+// QueryMachine queries the "machine" edge of the ProcessedEvent entity.
+func (pe *ProcessedEvent) QueryMachine() *StateMachineQuery {
+	return NewProcessedEventClient(pe.config).QueryMachine(pe)
+}
+
+// Update returns a builder for updating this ProcessedEvent.
+// Note that you need to call ProcessedEvent.Unwrap() before calling this method if this ProcessedEvent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pe *ProcessedEvent) Update() *ProcessedEventUpdateOne {
+	return NewProcessedEventClient(pe.config).UpdateOne(pe)
+}
+
+// Unwrap unwraps the ProcessedEvent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pe *ProcessedEvent) Unwrap() *ProcessedEvent {
+	_tx, ok := pe.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ProcessedEvent is not a transactional entity")
+	}
+	pe.config.driver = _tx.drv
+	return pe
+}
+
+// String implements the fmt.Stringer.
+func (pe *ProcessedEvent) String() string {
+	var builder strings.Builder
+	builder.WriteString("ProcessedEvent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pe.ID))
+	builder.WriteString("key=")
+	builder.WriteString(pe.Key)
+	builder.WriteString(", ")
+	builder.WriteString("event=")
+	builder.WriteString(pe.Event)
+	builder.WriteString(", ")
+	builder.WriteString("to_state=")
+	builder.WriteString(pe.ToState)
+	builder.WriteString(", ")
+	builder.WriteString("processed_at=")
+	builder.WriteString(pe.ProcessedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ProcessedEvents is a parsable slice of ProcessedEvent.
+type ProcessedEvents []*ProcessedEvent
@@ -0,0 +1,115 @@
+// Code generated by ent, DO NOT EDIT.
+
+package processedevent
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the processedevent type in the database.
+	Label = "processed_event"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldKey holds the string denoting the key field in the database.
+	FieldKey = "key"
+	// FieldEvent holds the string denoting the event field in the database.
+	FieldEvent = "event"
+	// FieldToState holds the string denoting the to_state field in the database.
+	FieldToState = "to_state"
+	// FieldProcessedAt holds the string denoting the processed_at field in the database.
+	FieldProcessedAt = "processed_at"
+	// EdgeMachine holds the string denoting the machine edge name in mutations.
+	EdgeMachine = "machine"
+	// Table holds the table name of the processedevent in the database.
+	Table = "processed_events"
+	// MachineTable is the table that holds the machine relation/edge.
+	MachineTable = "processed_events"
+	// MachineInverseTable is the table name for the StateMachine entity.
+	// It exists in this package in order to avoid circular dependency with the "statemachine" package.
+	MachineInverseTable = "state_machines"
+	// MachineColumn is the table column denoting the machine relation/edge.
+	MachineColumn = "state_machine_processed_events"
+)
+
+// Columns holds all SQL columns for processedevent fields.
+var Columns = []string{
+	FieldID,
+	FieldKey,
+	FieldEvent,
+	FieldToState,
+	FieldProcessedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "processed_events"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"state_machine_processed_events",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	KeyValidator func(string) error
+	// DefaultProcessedAt holds the default value on creation for the "processed_at" field.
+	DefaultProcessedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the ProcessedEvent queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByKey orders the results by the key field.
+func ByKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKey, opts...).ToFunc()
+}
+
+// ByEvent orders the results by the event field.
+func ByEvent(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEvent, opts...).ToFunc()
+}
+
+// ByToState orders the results by the to_state field.
+func ByToState(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldToState, opts...).ToFunc()
+}
+
+// ByProcessedAt orders the results by the processed_at field.
+func ByProcessedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProcessedAt, opts...).ToFunc()
+}
+
+// ByMachineField orders the results by machine field.
+func ByMachineField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newMachineStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newMachineStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(MachineInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, MachineTable, MachineColumn),
+	)
+}
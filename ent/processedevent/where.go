@@ -0,0 +1,350 @@
+// Code generated by ent, DO NOT EDIT.
+
+package processedevent
+
+import (
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldID, id))
+}
+
+// Key applies equality check predicate on the "key" field. It's identical to KeyEQ.
+func Key(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldKey, v))
+}
+
+// Event applies equality check predicate on the "event" field. It's identical to EventEQ.
+func Event(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEvent, v))
+}
+
+// ToState applies equality check predicate on the "to_state" field. It's identical to ToStateEQ.
+func ToState(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldToState, v))
+}
+
+// ProcessedAt applies equality check predicate on the "processed_at" field. It's identical to ProcessedAtEQ.
+func ProcessedAt(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// KeyEQ applies the EQ predicate on the "key" field.
+func KeyEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldKey, v))
+}
+
+// KeyNEQ applies the NEQ predicate on the "key" field.
+func KeyNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldKey, v))
+}
+
+// KeyIn applies the In predicate on the "key" field.
+func KeyIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldKey, vs...))
+}
+
+// KeyNotIn applies the NotIn predicate on the "key" field.
+func KeyNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldKey, vs...))
+}
+
+// KeyGT applies the GT predicate on the "key" field.
+func KeyGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldKey, v))
+}
+
+// KeyGTE applies the GTE predicate on the "key" field.
+func KeyGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldKey, v))
+}
+
+// KeyLT applies the LT predicate on the "key" field.
+func KeyLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldKey, v))
+}
+
+// KeyLTE applies the LTE predicate on the "key" field.
+func KeyLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldKey, v))
+}
+
+// KeyContains applies the Contains predicate on the "key" field.
+func KeyContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldKey, v))
+}
+
+// KeyHasPrefix applies the HasPrefix predicate on the "key" field.
+func KeyHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldKey, v))
+}
+
+// KeyHasSuffix applies the HasSuffix predicate on the "key" field.
+func KeyHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldKey, v))
+}
+
+// KeyEqualFold applies the EqualFold predicate on the "key" field.
+func KeyEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldKey, v))
+}
+
+// KeyContainsFold applies the ContainsFold predicate on the "key" field.
+func KeyContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldKey, v))
+}
+
+// EventEQ applies the EQ predicate on the "event" field.
+func EventEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldEvent, v))
+}
+
+// EventNEQ applies the NEQ predicate on the "event" field.
+func EventNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldEvent, v))
+}
+
+// EventIn applies the In predicate on the "event" field.
+func EventIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldEvent, vs...))
+}
+
+// EventNotIn applies the NotIn predicate on the "event" field.
+func EventNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldEvent, vs...))
+}
+
+// EventGT applies the GT predicate on the "event" field.
+func EventGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldEvent, v))
+}
+
+// EventGTE applies the GTE predicate on the "event" field.
+func EventGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldEvent, v))
+}
+
+// EventLT applies the LT predicate on the "event" field.
+func EventLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldEvent, v))
+}
+
+// EventLTE applies the LTE predicate on the "event" field.
+func EventLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldEvent, v))
+}
+
+// EventContains applies the Contains predicate on the "event" field.
+func EventContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldEvent, v))
+}
+
+// EventHasPrefix applies the HasPrefix predicate on the "event" field.
+func EventHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldEvent, v))
+}
+
+// EventHasSuffix applies the HasSuffix predicate on the "event" field.
+func EventHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldEvent, v))
+}
+
+// EventEqualFold applies the EqualFold predicate on the "event" field.
+func EventEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldEvent, v))
+}
+
+// EventContainsFold applies the ContainsFold predicate on the "event" field.
+func EventContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldEvent, v))
+}
+
+// ToStateEQ applies the EQ predicate on the "to_state" field.
+func ToStateEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldToState, v))
+}
+
+// ToStateNEQ applies the NEQ predicate on the "to_state" field.
+func ToStateNEQ(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldToState, v))
+}
+
+// ToStateIn applies the In predicate on the "to_state" field.
+func ToStateIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldToState, vs...))
+}
+
+// ToStateNotIn applies the NotIn predicate on the "to_state" field.
+func ToStateNotIn(vs ...string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldToState, vs...))
+}
+
+// ToStateGT applies the GT predicate on the "to_state" field.
+func ToStateGT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldToState, v))
+}
+
+// ToStateGTE applies the GTE predicate on the "to_state" field.
+func ToStateGTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldToState, v))
+}
+
+// ToStateLT applies the LT predicate on the "to_state" field.
+func ToStateLT(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldToState, v))
+}
+
+// ToStateLTE applies the LTE predicate on the "to_state" field.
+func ToStateLTE(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldToState, v))
+}
+
+// ToStateContains applies the Contains predicate on the "to_state" field.
+func ToStateContains(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContains(FieldToState, v))
+}
+
+// ToStateHasPrefix applies the HasPrefix predicate on the "to_state" field.
+func ToStateHasPrefix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasPrefix(FieldToState, v))
+}
+
+// ToStateHasSuffix applies the HasSuffix predicate on the "to_state" field.
+func ToStateHasSuffix(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldHasSuffix(FieldToState, v))
+}
+
+// ToStateEqualFold applies the EqualFold predicate on the "to_state" field.
+func ToStateEqualFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEqualFold(FieldToState, v))
+}
+
+// ToStateContainsFold applies the ContainsFold predicate on the "to_state" field.
+func ToStateContainsFold(v string) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldContainsFold(FieldToState, v))
+}
+
+// ProcessedAtEQ applies the EQ predicate on the "processed_at" field.
+func ProcessedAtEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtNEQ applies the NEQ predicate on the "processed_at" field.
+func ProcessedAtNEQ(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNEQ(FieldProcessedAt, v))
+}
+
+// ProcessedAtIn applies the In predicate on the "processed_at" field.
+func ProcessedAtIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtNotIn applies the NotIn predicate on the "processed_at" field.
+func ProcessedAtNotIn(vs ...time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldNotIn(FieldProcessedAt, vs...))
+}
+
+// ProcessedAtGT applies the GT predicate on the "processed_at" field.
+func ProcessedAtGT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGT(FieldProcessedAt, v))
+}
+
+// ProcessedAtGTE applies the GTE predicate on the "processed_at" field.
+func ProcessedAtGTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldGTE(FieldProcessedAt, v))
+}
+
+// ProcessedAtLT applies the LT predicate on the "processed_at" field.
+func ProcessedAtLT(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLT(FieldProcessedAt, v))
+}
+
+// ProcessedAtLTE applies the LTE predicate on the "processed_at" field.
+func ProcessedAtLTE(v time.Time) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.FieldLTE(FieldProcessedAt, v))
+}
+
+// HasMachine applies the HasEdge predicate on the "machine" edge.
+func HasMachine() predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, MachineTable, MachineColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasMachineWith applies the HasEdge predicate on the "machine" edge with a given conditions (other predicates).
+func HasMachineWith(preds ...predicate.StateMachine) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(func(s *sql.Selector) {
+		step := newMachineStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ProcessedEvent) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ProcessedEvent) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ProcessedEvent) predicate.ProcessedEvent {
+	return predicate.ProcessedEvent(sql.NotPredicates(p))
+}
@@ -0,0 +1,279 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ProcessedEventCreate is the builder for creating a ProcessedEvent entity.
+type ProcessedEventCreate struct {
+	config
+	mutation *ProcessedEventMutation
+	hooks    []Hook
+}
+
+// SetKey sets the "key" field.
+func (pec *ProcessedEventCreate) SetKey(s string) *ProcessedEventCreate {
+	pec.mutation.SetKey(s)
+	return pec
+}
+
+// SetEvent sets the "event" field.
+func (pec *ProcessedEventCreate) SetEvent(s string) *ProcessedEventCreate {
+	pec.mutation.SetEvent(s)
+	return pec
+}
+
+// SetToState sets the "to_state" field.
+func (pec *ProcessedEventCreate) SetToState(s string) *ProcessedEventCreate {
+	pec.mutation.SetToState(s)
+	return pec
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (pec *ProcessedEventCreate) SetProcessedAt(t time.Time) *ProcessedEventCreate {
+	pec.mutation.SetProcessedAt(t)
+	return pec
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (pec *ProcessedEventCreate) SetNillableProcessedAt(t *time.Time) *ProcessedEventCreate {
+	if t != nil {
+		pec.SetProcessedAt(*t)
+	}
+	return pec
+}
+
+// SetMachineID sets the "machine" edge to the StateMachine entity by ID.
+func (pec *ProcessedEventCreate) SetMachineID(id int) *ProcessedEventCreate {
+	pec.mutation.SetMachineID(id)
+	return pec
+}
+
+// SetMachine sets the "machine" edge to the StateMachine entity.
+func (pec *ProcessedEventCreate) SetMachine(s *StateMachine) *ProcessedEventCreate {
+	return pec.SetMachineID(s.ID)
+}
+
+// Mutation returns the ProcessedEventMutation object of the builder.
+func (pec *ProcessedEventCreate) Mutation() *ProcessedEventMutation {
+	return pec.mutation
+}
+
+// Save creates the ProcessedEvent in the database.
+func (pec *ProcessedEventCreate) Save(ctx context.Context) (*ProcessedEvent, error) {
+	pec.defaults()
+	return withHooks(ctx, pec.sqlSave, pec.mutation, pec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pec *ProcessedEventCreate) SaveX(ctx context.Context) *ProcessedEvent {
+	v, err := pec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pec *ProcessedEventCreate) Exec(ctx context.Context) error {
+	_, err := pec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pec *ProcessedEventCreate) ExecX(ctx context.Context) {
+	if err := pec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pec *ProcessedEventCreate) defaults() {
+	if _, ok := pec.mutation.ProcessedAt(); !ok {
+		v := processedevent.DefaultProcessedAt()
+		pec.mutation.SetProcessedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pec *ProcessedEventCreate) check() error {
+	if _, ok := pec.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "ProcessedEvent.key"`)}
+	}
+	if v, ok := pec.mutation.Key(); ok {
+		if err := processedevent.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.key": %w`, err)}
+		}
+	}
+	if _, ok := pec.mutation.Event(); !ok {
+		return &ValidationError{Name: "event", err: errors.New(`ent: missing required field "ProcessedEvent.event"`)}
+	}
+	if _, ok := pec.mutation.ToState(); !ok {
+		return &ValidationError{Name: "to_state", err: errors.New(`ent: missing required field "ProcessedEvent.to_state"`)}
+	}
+	if _, ok := pec.mutation.ProcessedAt(); !ok {
+		return &ValidationError{Name: "processed_at", err: errors.New(`ent: missing required field "ProcessedEvent.processed_at"`)}
+	}
+	if len(pec.mutation.MachineIDs()) == 0 {
+		return &ValidationError{Name: "machine", err: errors.New(`ent: missing required edge "ProcessedEvent.machine"`)}
+	}
+	return nil
+}
+
+func (pec *ProcessedEventCreate) sqlSave(ctx context.Context) (*ProcessedEvent, error) {
+	if err := pec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	pec.mutation.id = &_node.ID
+	pec.mutation.done = true
+	return _node, nil
+}
+
+func (pec *ProcessedEventCreate) createSpec() (*ProcessedEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ProcessedEvent{config: pec.config}
+		_spec = sqlgraph.NewCreateSpec(processedevent.Table, sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt))
+	)
+	if value, ok := pec.mutation.Key(); ok {
+		_spec.SetField(processedevent.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := pec.mutation.Event(); ok {
+		_spec.SetField(processedevent.FieldEvent, field.TypeString, value)
+		_node.Event = value
+	}
+	if value, ok := pec.mutation.ToState(); ok {
+		_spec.SetField(processedevent.FieldToState, field.TypeString, value)
+		_node.ToState = value
+	}
+	if value, ok := pec.mutation.ProcessedAt(); ok {
+		_spec.SetField(processedevent.FieldProcessedAt, field.TypeTime, value)
+		_node.ProcessedAt = value
+	}
+	if nodes := pec.mutation.MachineIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   processedevent.MachineTable,
+			Columns: []string{processedevent.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(statemachine.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.state_machine_processed_events = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ProcessedEventCreateBulk is the builder for creating many ProcessedEvent entities in bulk.
+type ProcessedEventCreateBulk struct {
+	config
+	err      error
+	builders []*ProcessedEventCreate
+}
+
+// Save creates the ProcessedEvent entities in the database.
+func (pecb *ProcessedEventCreateBulk) Save(ctx context.Context) ([]*ProcessedEvent, error) {
+	if pecb.err != nil {
+		return nil, pecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pecb.builders))
+	nodes := make([]*ProcessedEvent, len(pecb.builders))
+	mutators := make([]Mutator, len(pecb.builders))
+	for i := range pecb.builders {
+		func(i int, root context.Context) {
+			builder := pecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ProcessedEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pecb *ProcessedEventCreateBulk) SaveX(ctx context.Context) []*ProcessedEvent {
+	v, err := pecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pecb *ProcessedEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := pecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pecb *ProcessedEventCreateBulk) ExecX(ctx context.Context) {
+	if err := pecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
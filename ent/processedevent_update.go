@@ -0,0 +1,438 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ProcessedEventUpdate is the builder for updating ProcessedEvent entities.
+type ProcessedEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ProcessedEventMutation
+}
+
+// Where appends a list predicates to the ProcessedEventUpdate builder.
+func (peu *ProcessedEventUpdate) Where(ps ...predicate.ProcessedEvent) *ProcessedEventUpdate {
+	peu.mutation.Where(ps...)
+	return peu
+}
+
+// SetKey sets the "key" field.
+func (peu *ProcessedEventUpdate) SetKey(s string) *ProcessedEventUpdate {
+	peu.mutation.SetKey(s)
+	return peu
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableKey(s *string) *ProcessedEventUpdate {
+	if s != nil {
+		peu.SetKey(*s)
+	}
+	return peu
+}
+
+// SetEvent sets the "event" field.
+func (peu *ProcessedEventUpdate) SetEvent(s string) *ProcessedEventUpdate {
+	peu.mutation.SetEvent(s)
+	return peu
+}
+
+// SetNillableEvent sets the "event" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableEvent(s *string) *ProcessedEventUpdate {
+	if s != nil {
+		peu.SetEvent(*s)
+	}
+	return peu
+}
+
+// SetToState sets the "to_state" field.
+func (peu *ProcessedEventUpdate) SetToState(s string) *ProcessedEventUpdate {
+	peu.mutation.SetToState(s)
+	return peu
+}
+
+// SetNillableToState sets the "to_state" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableToState(s *string) *ProcessedEventUpdate {
+	if s != nil {
+		peu.SetToState(*s)
+	}
+	return peu
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (peu *ProcessedEventUpdate) SetProcessedAt(t time.Time) *ProcessedEventUpdate {
+	peu.mutation.SetProcessedAt(t)
+	return peu
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (peu *ProcessedEventUpdate) SetNillableProcessedAt(t *time.Time) *ProcessedEventUpdate {
+	if t != nil {
+		peu.SetProcessedAt(*t)
+	}
+	return peu
+}
+
+// SetMachineID sets the "machine" edge to the StateMachine entity by ID.
+func (peu *ProcessedEventUpdate) SetMachineID(id int) *ProcessedEventUpdate {
+	peu.mutation.SetMachineID(id)
+	return peu
+}
+
+// SetMachine sets the "machine" edge to the StateMachine entity.
+func (peu *ProcessedEventUpdate) SetMachine(s *StateMachine) *ProcessedEventUpdate {
+	return peu.SetMachineID(s.ID)
+}
+
+// Mutation returns the ProcessedEventMutation object of the builder.
+func (peu *ProcessedEventUpdate) Mutation() *ProcessedEventMutation {
+	return peu.mutation
+}
+
+// ClearMachine clears the "machine" edge to the StateMachine entity.
+func (peu *ProcessedEventUpdate) ClearMachine() *ProcessedEventUpdate {
+	peu.mutation.ClearMachine()
+	return peu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (peu *ProcessedEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, peu.sqlSave, peu.mutation, peu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (peu *ProcessedEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := peu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (peu *ProcessedEventUpdate) Exec(ctx context.Context) error {
+	_, err := peu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (peu *ProcessedEventUpdate) ExecX(ctx context.Context) {
+	if err := peu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (peu *ProcessedEventUpdate) check() error {
+	if v, ok := peu.mutation.Key(); ok {
+		if err := processedevent.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.key": %w`, err)}
+		}
+	}
+	if peu.mutation.MachineCleared() && len(peu.mutation.MachineIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ProcessedEvent.machine"`)
+	}
+	return nil
+}
+
+func (peu *ProcessedEventUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := peu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(processedevent.Table, processedevent.Columns, sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt))
+	if ps := peu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := peu.mutation.Key(); ok {
+		_spec.SetField(processedevent.FieldKey, field.TypeString, value)
+	}
+	if value, ok := peu.mutation.Event(); ok {
+		_spec.SetField(processedevent.FieldEvent, field.TypeString, value)
+	}
+	if value, ok := peu.mutation.ToState(); ok {
+		_spec.SetField(processedevent.FieldToState, field.TypeString, value)
+	}
+	if value, ok := peu.mutation.ProcessedAt(); ok {
+		_spec.SetField(processedevent.FieldProcessedAt, field.TypeTime, value)
+	}
+	if peu.mutation.MachineCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   processedevent.MachineTable,
+			Columns: []string{processedevent.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(statemachine.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := peu.mutation.MachineIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   processedevent.MachineTable,
+			Columns: []string{processedevent.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(statemachine.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, peu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{processedevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	peu.mutation.done = true
+	return n, nil
+}
+
+// ProcessedEventUpdateOne is the builder for updating a single ProcessedEvent entity.
+type ProcessedEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ProcessedEventMutation
+}
+
+// SetKey sets the "key" field.
+func (peuo *ProcessedEventUpdateOne) SetKey(s string) *ProcessedEventUpdateOne {
+	peuo.mutation.SetKey(s)
+	return peuo
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableKey(s *string) *ProcessedEventUpdateOne {
+	if s != nil {
+		peuo.SetKey(*s)
+	}
+	return peuo
+}
+
+// SetEvent sets the "event" field.
+func (peuo *ProcessedEventUpdateOne) SetEvent(s string) *ProcessedEventUpdateOne {
+	peuo.mutation.SetEvent(s)
+	return peuo
+}
+
+// SetNillableEvent sets the "event" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableEvent(s *string) *ProcessedEventUpdateOne {
+	if s != nil {
+		peuo.SetEvent(*s)
+	}
+	return peuo
+}
+
+// SetToState sets the "to_state" field.
+func (peuo *ProcessedEventUpdateOne) SetToState(s string) *ProcessedEventUpdateOne {
+	peuo.mutation.SetToState(s)
+	return peuo
+}
+
+// SetNillableToState sets the "to_state" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableToState(s *string) *ProcessedEventUpdateOne {
+	if s != nil {
+		peuo.SetToState(*s)
+	}
+	return peuo
+}
+
+// SetProcessedAt sets the "processed_at" field.
+func (peuo *ProcessedEventUpdateOne) SetProcessedAt(t time.Time) *ProcessedEventUpdateOne {
+	peuo.mutation.SetProcessedAt(t)
+	return peuo
+}
+
+// SetNillableProcessedAt sets the "processed_at" field if the given value is not nil.
+func (peuo *ProcessedEventUpdateOne) SetNillableProcessedAt(t *time.Time) *ProcessedEventUpdateOne {
+	if t != nil {
+		peuo.SetProcessedAt(*t)
+	}
+	return peuo
+}
+
+// SetMachineID sets the "machine" edge to the StateMachine entity by ID.
+func (peuo *ProcessedEventUpdateOne) SetMachineID(id int) *ProcessedEventUpdateOne {
+	peuo.mutation.SetMachineID(id)
+	return peuo
+}
+
+// SetMachine sets the "machine" edge to the StateMachine entity.
+func (peuo *ProcessedEventUpdateOne) SetMachine(s *StateMachine) *ProcessedEventUpdateOne {
+	return peuo.SetMachineID(s.ID)
+}
+
+// Mutation returns the ProcessedEventMutation object of the builder.
+func (peuo *ProcessedEventUpdateOne) Mutation() *ProcessedEventMutation {
+	return peuo.mutation
+}
+
+// ClearMachine clears the "machine" edge to the StateMachine entity.
+func (peuo *ProcessedEventUpdateOne) ClearMachine() *ProcessedEventUpdateOne {
+	peuo.mutation.ClearMachine()
+	return peuo
+}
+
+// Where appends a list predicates to the ProcessedEventUpdate builder.
+func (peuo *ProcessedEventUpdateOne) Where(ps ...predicate.ProcessedEvent) *ProcessedEventUpdateOne {
+	peuo.mutation.Where(ps...)
+	return peuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (peuo *ProcessedEventUpdateOne) Select(field string, fields ...string) *ProcessedEventUpdateOne {
+	peuo.fields = append([]string{field}, fields...)
+	return peuo
+}
+
+// Save executes the query and returns the updated ProcessedEvent entity.
+func (peuo *ProcessedEventUpdateOne) Save(ctx context.Context) (*ProcessedEvent, error) {
+	return withHooks(ctx, peuo.sqlSave, peuo.mutation, peuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (peuo *ProcessedEventUpdateOne) SaveX(ctx context.Context) *ProcessedEvent {
+	node, err := peuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (peuo *ProcessedEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := peuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (peuo *ProcessedEventUpdateOne) ExecX(ctx context.Context) {
+	if err := peuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (peuo *ProcessedEventUpdateOne) check() error {
+	if v, ok := peuo.mutation.Key(); ok {
+		if err := processedevent.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "ProcessedEvent.key": %w`, err)}
+		}
+	}
+	if peuo.mutation.MachineCleared() && len(peuo.mutation.MachineIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ProcessedEvent.machine"`)
+	}
+	return nil
+}
+
+func (peuo *ProcessedEventUpdateOne) sqlSave(ctx context.Context) (_node *ProcessedEvent, err error) {
+	if err := peuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(processedevent.Table, processedevent.Columns, sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt))
+	id, ok := peuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ProcessedEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := peuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, processedevent.FieldID)
+		for _, f := range fields {
+			if !processedevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != processedevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := peuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := peuo.mutation.Key(); ok {
+		_spec.SetField(processedevent.FieldKey, field.TypeString, value)
+	}
+	if value, ok := peuo.mutation.Event(); ok {
+		_spec.SetField(processedevent.FieldEvent, field.TypeString, value)
+	}
+	if value, ok := peuo.mutation.ToState(); ok {
+		_spec.SetField(processedevent.FieldToState, field.TypeString, value)
+	}
+	if value, ok := peuo.mutation.ProcessedAt(); ok {
+		_spec.SetField(processedevent.FieldProcessedAt, field.TypeTime, value)
+	}
+	if peuo.mutation.MachineCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   processedevent.MachineTable,
+			Columns: []string{processedevent.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(statemachine.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := peuo.mutation.MachineIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   processedevent.MachineTable,
+			Columns: []string{processedevent.MachineColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(statemachine.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ProcessedEvent{config: peuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, peuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{processedevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	peuo.mutation.done = true
+	return _node, nil
+}
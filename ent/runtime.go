@@ -5,6 +5,14 @@ package ent
 import (
 	"time"
 
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/deadletter"
+	"github.com/shinhauhuang/go-fsm/ent/job"
+	"github.com/shinhauhuang/go-fsm/ent/lease"
+	"github.com/shinhauhuang/go-fsm/ent/outboxmessage"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/schedule"
 	"github.com/shinhauhuang/go-fsm/ent/schema"
 	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 	"github.com/shinhauhuang/go-fsm/ent/statetransition"
@@ -14,6 +22,130 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	archivedmachineFields := schema.ArchivedMachine{}.Fields()
+	_ = archivedmachineFields
+	// archivedmachineDescMachineID is the schema descriptor for machine_id field.
+	archivedmachineDescMachineID := archivedmachineFields[0].Descriptor()
+	// archivedmachine.MachineIDValidator is a validator for the "machine_id" field. It is called by the builders before save.
+	archivedmachine.MachineIDValidator = archivedmachineDescMachineID.Validators[0].(func(string) error)
+	// archivedmachineDescDefinitionName is the schema descriptor for definition_name field.
+	archivedmachineDescDefinitionName := archivedmachineFields[2].Descriptor()
+	// archivedmachine.DefaultDefinitionName holds the default value on creation for the definition_name field.
+	archivedmachine.DefaultDefinitionName = archivedmachineDescDefinitionName.Default.(string)
+	// archivedmachineDescDefinitionVersion is the schema descriptor for definition_version field.
+	archivedmachineDescDefinitionVersion := archivedmachineFields[3].Descriptor()
+	// archivedmachine.DefaultDefinitionVersion holds the default value on creation for the definition_version field.
+	archivedmachine.DefaultDefinitionVersion = archivedmachineDescDefinitionVersion.Default.(int)
+	// archivedmachineDescSuspended is the schema descriptor for suspended field.
+	archivedmachineDescSuspended := archivedmachineFields[5].Descriptor()
+	// archivedmachine.DefaultSuspended holds the default value on creation for the suspended field.
+	archivedmachine.DefaultSuspended = archivedmachineDescSuspended.Default.(bool)
+	// archivedmachineDescArchivedAt is the schema descriptor for archived_at field.
+	archivedmachineDescArchivedAt := archivedmachineFields[9].Descriptor()
+	// archivedmachine.DefaultArchivedAt holds the default value on creation for the archived_at field.
+	archivedmachine.DefaultArchivedAt = archivedmachineDescArchivedAt.Default.(func() time.Time)
+	archivedtransitionFields := schema.ArchivedTransition{}.Fields()
+	_ = archivedtransitionFields
+	// archivedtransitionDescTimestamp is the schema descriptor for timestamp field.
+	archivedtransitionDescTimestamp := archivedtransitionFields[3].Descriptor()
+	// archivedtransition.DefaultTimestamp holds the default value on creation for the timestamp field.
+	archivedtransition.DefaultTimestamp = archivedtransitionDescTimestamp.Default.(func() time.Time)
+	deadletterFields := schema.DeadLetter{}.Fields()
+	_ = deadletterFields
+	// deadletterDescAttempts is the schema descriptor for attempts field.
+	deadletterDescAttempts := deadletterFields[6].Descriptor()
+	// deadletter.DefaultAttempts holds the default value on creation for the attempts field.
+	deadletter.DefaultAttempts = deadletterDescAttempts.Default.(int)
+	// deadletterDescCreatedAt is the schema descriptor for created_at field.
+	deadletterDescCreatedAt := deadletterFields[8].Descriptor()
+	// deadletter.DefaultCreatedAt holds the default value on creation for the created_at field.
+	deadletter.DefaultCreatedAt = deadletterDescCreatedAt.Default.(func() time.Time)
+	// deadletterDescUpdatedAt is the schema descriptor for updated_at field.
+	deadletterDescUpdatedAt := deadletterFields[9].Descriptor()
+	// deadletter.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	deadletter.DefaultUpdatedAt = deadletterDescUpdatedAt.Default.(func() time.Time)
+	// deadletter.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	deadletter.UpdateDefaultUpdatedAt = deadletterDescUpdatedAt.UpdateDefault.(func() time.Time)
+	jobFields := schema.Job{}.Fields()
+	_ = jobFields
+	// jobDescName is the schema descriptor for name field.
+	jobDescName := jobFields[0].Descriptor()
+	// job.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	job.NameValidator = jobDescName.Validators[0].(func(string) error)
+	// jobDescAttempts is the schema descriptor for attempts field.
+	jobDescAttempts := jobFields[4].Descriptor()
+	// job.DefaultAttempts holds the default value on creation for the attempts field.
+	job.DefaultAttempts = jobDescAttempts.Default.(int)
+	// jobDescNextRunAt is the schema descriptor for next_run_at field.
+	jobDescNextRunAt := jobFields[5].Descriptor()
+	// job.DefaultNextRunAt holds the default value on creation for the next_run_at field.
+	job.DefaultNextRunAt = jobDescNextRunAt.Default.(func() time.Time)
+	// jobDescCreatedAt is the schema descriptor for created_at field.
+	jobDescCreatedAt := jobFields[7].Descriptor()
+	// job.DefaultCreatedAt holds the default value on creation for the created_at field.
+	job.DefaultCreatedAt = jobDescCreatedAt.Default.(func() time.Time)
+	// jobDescUpdatedAt is the schema descriptor for updated_at field.
+	jobDescUpdatedAt := jobFields[8].Descriptor()
+	// job.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	job.DefaultUpdatedAt = jobDescUpdatedAt.Default.(func() time.Time)
+	// job.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	job.UpdateDefaultUpdatedAt = jobDescUpdatedAt.UpdateDefault.(func() time.Time)
+	leaseFields := schema.Lease{}.Fields()
+	_ = leaseFields
+	// leaseDescName is the schema descriptor for name field.
+	leaseDescName := leaseFields[0].Descriptor()
+	// lease.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	lease.NameValidator = leaseDescName.Validators[0].(func(string) error)
+	outboxmessageFields := schema.OutboxMessage{}.Fields()
+	_ = outboxmessageFields
+	// outboxmessageDescTopic is the schema descriptor for topic field.
+	outboxmessageDescTopic := outboxmessageFields[0].Descriptor()
+	// outboxmessage.TopicValidator is a validator for the "topic" field. It is called by the builders before save.
+	outboxmessage.TopicValidator = outboxmessageDescTopic.Validators[0].(func(string) error)
+	// outboxmessageDescKey is the schema descriptor for key field.
+	outboxmessageDescKey := outboxmessageFields[1].Descriptor()
+	// outboxmessage.DefaultKey holds the default value on creation for the key field.
+	outboxmessage.DefaultKey = outboxmessageDescKey.Default.(string)
+	// outboxmessageDescCreatedAt is the schema descriptor for created_at field.
+	outboxmessageDescCreatedAt := outboxmessageFields[3].Descriptor()
+	// outboxmessage.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outboxmessage.DefaultCreatedAt = outboxmessageDescCreatedAt.Default.(func() time.Time)
+	// outboxmessageDescAttempts is the schema descriptor for attempts field.
+	outboxmessageDescAttempts := outboxmessageFields[4].Descriptor()
+	// outboxmessage.DefaultAttempts holds the default value on creation for the attempts field.
+	outboxmessage.DefaultAttempts = outboxmessageDescAttempts.Default.(int)
+	// outboxmessageDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	outboxmessageDescNextAttemptAt := outboxmessageFields[5].Descriptor()
+	// outboxmessage.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	outboxmessage.DefaultNextAttemptAt = outboxmessageDescNextAttemptAt.Default.(func() time.Time)
+	processedeventFields := schema.ProcessedEvent{}.Fields()
+	_ = processedeventFields
+	// processedeventDescKey is the schema descriptor for key field.
+	processedeventDescKey := processedeventFields[0].Descriptor()
+	// processedevent.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	processedevent.KeyValidator = processedeventDescKey.Validators[0].(func(string) error)
+	// processedeventDescProcessedAt is the schema descriptor for processed_at field.
+	processedeventDescProcessedAt := processedeventFields[3].Descriptor()
+	// processedevent.DefaultProcessedAt holds the default value on creation for the processed_at field.
+	processedevent.DefaultProcessedAt = processedeventDescProcessedAt.Default.(func() time.Time)
+	scheduleFields := schema.Schedule{}.Fields()
+	_ = scheduleFields
+	// scheduleDescName is the schema descriptor for name field.
+	scheduleDescName := scheduleFields[0].Descriptor()
+	// schedule.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	schedule.NameValidator = scheduleDescName.Validators[0].(func(string) error)
+	// scheduleDescEvent is the schema descriptor for event field.
+	scheduleDescEvent := scheduleFields[2].Descriptor()
+	// schedule.EventValidator is a validator for the "event" field. It is called by the builders before save.
+	schedule.EventValidator = scheduleDescEvent.Validators[0].(func(string) error)
+	// scheduleDescEnabled is the schema descriptor for enabled field.
+	scheduleDescEnabled := scheduleFields[10].Descriptor()
+	// schedule.DefaultEnabled holds the default value on creation for the enabled field.
+	schedule.DefaultEnabled = scheduleDescEnabled.Default.(bool)
+	// scheduleDescCreatedAt is the schema descriptor for created_at field.
+	scheduleDescCreatedAt := scheduleFields[11].Descriptor()
+	// schedule.DefaultCreatedAt holds the default value on creation for the created_at field.
+	schedule.DefaultCreatedAt = scheduleDescCreatedAt.Default.(func() time.Time)
 	statemachineFields := schema.StateMachine{}.Fields()
 	_ = statemachineFields
 	// statemachineDescMachineID is the schema descriptor for machine_id field.
@@ -24,6 +156,32 @@ func init() {
 	statemachineDescCurrentState := statemachineFields[1].Descriptor()
 	// statemachine.CurrentStateValidator is a validator for the "current_state" field. It is called by the builders before save.
 	statemachine.CurrentStateValidator = statemachineDescCurrentState.Validators[0].(func(string) error)
+	// statemachineDescDefinitionName is the schema descriptor for definition_name field.
+	statemachineDescDefinitionName := statemachineFields[2].Descriptor()
+	// statemachine.DefaultDefinitionName holds the default value on creation for the definition_name field.
+	statemachine.DefaultDefinitionName = statemachineDescDefinitionName.Default.(string)
+	// statemachineDescDefinitionVersion is the schema descriptor for definition_version field.
+	statemachineDescDefinitionVersion := statemachineFields[3].Descriptor()
+	// statemachine.DefaultDefinitionVersion holds the default value on creation for the definition_version field.
+	statemachine.DefaultDefinitionVersion = statemachineDescDefinitionVersion.Default.(int)
+	// statemachineDescCreatedAt is the schema descriptor for created_at field.
+	statemachineDescCreatedAt := statemachineFields[4].Descriptor()
+	// statemachine.DefaultCreatedAt holds the default value on creation for the created_at field.
+	statemachine.DefaultCreatedAt = statemachineDescCreatedAt.Default.(func() time.Time)
+	// statemachineDescUpdatedAt is the schema descriptor for updated_at field.
+	statemachineDescUpdatedAt := statemachineFields[5].Descriptor()
+	// statemachine.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	statemachine.DefaultUpdatedAt = statemachineDescUpdatedAt.Default.(func() time.Time)
+	// statemachine.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	statemachine.UpdateDefaultUpdatedAt = statemachineDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// statemachineDescEnteredAt is the schema descriptor for entered_at field.
+	statemachineDescEnteredAt := statemachineFields[6].Descriptor()
+	// statemachine.DefaultEnteredAt holds the default value on creation for the entered_at field.
+	statemachine.DefaultEnteredAt = statemachineDescEnteredAt.Default.(func() time.Time)
+	// statemachineDescSuspended is the schema descriptor for suspended field.
+	statemachineDescSuspended := statemachineFields[7].Descriptor()
+	// statemachine.DefaultSuspended holds the default value on creation for the suspended field.
+	statemachine.DefaultSuspended = statemachineDescSuspended.Default.(bool)
 	statetransitionFields := schema.StateTransition{}.Fields()
 	_ = statetransitionFields
 	// statetransitionDescTimestamp is the schema descriptor for timestamp field.
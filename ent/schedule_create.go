@@ -0,0 +1,395 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/schedule"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ScheduleCreate is the builder for creating a Schedule entity.
+type ScheduleCreate struct {
+	config
+	mutation *ScheduleMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (sc *ScheduleCreate) SetName(s string) *ScheduleCreate {
+	sc.mutation.SetName(s)
+	return sc
+}
+
+// SetCron sets the "cron" field.
+func (sc *ScheduleCreate) SetCron(s string) *ScheduleCreate {
+	sc.mutation.SetCron(s)
+	return sc
+}
+
+// SetNillableCron sets the "cron" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableCron(s *string) *ScheduleCreate {
+	if s != nil {
+		sc.SetCron(*s)
+	}
+	return sc
+}
+
+// SetEvent sets the "event" field.
+func (sc *ScheduleCreate) SetEvent(s string) *ScheduleCreate {
+	sc.mutation.SetEvent(s)
+	return sc
+}
+
+// SetArgs sets the "args" field.
+func (sc *ScheduleCreate) SetArgs(b []byte) *ScheduleCreate {
+	sc.mutation.SetArgs(b)
+	return sc
+}
+
+// SetMachineID sets the "machine_id" field.
+func (sc *ScheduleCreate) SetMachineID(s string) *ScheduleCreate {
+	sc.mutation.SetMachineID(s)
+	return sc
+}
+
+// SetNillableMachineID sets the "machine_id" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableMachineID(s *string) *ScheduleCreate {
+	if s != nil {
+		sc.SetMachineID(*s)
+	}
+	return sc
+}
+
+// SetDefinitionName sets the "definition_name" field.
+func (sc *ScheduleCreate) SetDefinitionName(s string) *ScheduleCreate {
+	sc.mutation.SetDefinitionName(s)
+	return sc
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableDefinitionName(s *string) *ScheduleCreate {
+	if s != nil {
+		sc.SetDefinitionName(*s)
+	}
+	return sc
+}
+
+// SetState sets the "state" field.
+func (sc *ScheduleCreate) SetState(s string) *ScheduleCreate {
+	sc.mutation.SetState(s)
+	return sc
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableState(s *string) *ScheduleCreate {
+	if s != nil {
+		sc.SetState(*s)
+	}
+	return sc
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (sc *ScheduleCreate) SetNextRunAt(t time.Time) *ScheduleCreate {
+	sc.mutation.SetNextRunAt(t)
+	return sc
+}
+
+// SetLastRunAt sets the "last_run_at" field.
+func (sc *ScheduleCreate) SetLastRunAt(t time.Time) *ScheduleCreate {
+	sc.mutation.SetLastRunAt(t)
+	return sc
+}
+
+// SetNillableLastRunAt sets the "last_run_at" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableLastRunAt(t *time.Time) *ScheduleCreate {
+	if t != nil {
+		sc.SetLastRunAt(*t)
+	}
+	return sc
+}
+
+// SetLastError sets the "last_error" field.
+func (sc *ScheduleCreate) SetLastError(s string) *ScheduleCreate {
+	sc.mutation.SetLastError(s)
+	return sc
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableLastError(s *string) *ScheduleCreate {
+	if s != nil {
+		sc.SetLastError(*s)
+	}
+	return sc
+}
+
+// SetEnabled sets the "enabled" field.
+func (sc *ScheduleCreate) SetEnabled(b bool) *ScheduleCreate {
+	sc.mutation.SetEnabled(b)
+	return sc
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableEnabled(b *bool) *ScheduleCreate {
+	if b != nil {
+		sc.SetEnabled(*b)
+	}
+	return sc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sc *ScheduleCreate) SetCreatedAt(t time.Time) *ScheduleCreate {
+	sc.mutation.SetCreatedAt(t)
+	return sc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sc *ScheduleCreate) SetNillableCreatedAt(t *time.Time) *ScheduleCreate {
+	if t != nil {
+		sc.SetCreatedAt(*t)
+	}
+	return sc
+}
+
+// Mutation returns the ScheduleMutation object of the builder.
+func (sc *ScheduleCreate) Mutation() *ScheduleMutation {
+	return sc.mutation
+}
+
+// Save creates the Schedule in the database.
+func (sc *ScheduleCreate) Save(ctx context.Context) (*Schedule, error) {
+	sc.defaults()
+	return withHooks(ctx, sc.sqlSave, sc.mutation, sc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sc *ScheduleCreate) SaveX(ctx context.Context) *Schedule {
+	v, err := sc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sc *ScheduleCreate) Exec(ctx context.Context) error {
+	_, err := sc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sc *ScheduleCreate) ExecX(ctx context.Context) {
+	if err := sc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sc *ScheduleCreate) defaults() {
+	if _, ok := sc.mutation.Enabled(); !ok {
+		v := schedule.DefaultEnabled
+		sc.mutation.SetEnabled(v)
+	}
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		v := schedule.DefaultCreatedAt()
+		sc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sc *ScheduleCreate) check() error {
+	if _, ok := sc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Schedule.name"`)}
+	}
+	if v, ok := sc.mutation.Name(); ok {
+		if err := schedule.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Schedule.name": %w`, err)}
+		}
+	}
+	if _, ok := sc.mutation.Event(); !ok {
+		return &ValidationError{Name: "event", err: errors.New(`ent: missing required field "Schedule.event"`)}
+	}
+	if v, ok := sc.mutation.Event(); ok {
+		if err := schedule.EventValidator(v); err != nil {
+			return &ValidationError{Name: "event", err: fmt.Errorf(`ent: validator failed for field "Schedule.event": %w`, err)}
+		}
+	}
+	if _, ok := sc.mutation.NextRunAt(); !ok {
+		return &ValidationError{Name: "next_run_at", err: errors.New(`ent: missing required field "Schedule.next_run_at"`)}
+	}
+	if _, ok := sc.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "Schedule.enabled"`)}
+	}
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Schedule.created_at"`)}
+	}
+	return nil
+}
+
+func (sc *ScheduleCreate) sqlSave(ctx context.Context) (*Schedule, error) {
+	if err := sc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	sc.mutation.id = &_node.ID
+	sc.mutation.done = true
+	return _node, nil
+}
+
+func (sc *ScheduleCreate) createSpec() (*Schedule, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Schedule{config: sc.config}
+		_spec = sqlgraph.NewCreateSpec(schedule.Table, sqlgraph.NewFieldSpec(schedule.FieldID, field.TypeInt))
+	)
+	if value, ok := sc.mutation.Name(); ok {
+		_spec.SetField(schedule.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := sc.mutation.Cron(); ok {
+		_spec.SetField(schedule.FieldCron, field.TypeString, value)
+		_node.Cron = value
+	}
+	if value, ok := sc.mutation.Event(); ok {
+		_spec.SetField(schedule.FieldEvent, field.TypeString, value)
+		_node.Event = value
+	}
+	if value, ok := sc.mutation.Args(); ok {
+		_spec.SetField(schedule.FieldArgs, field.TypeBytes, value)
+		_node.Args = value
+	}
+	if value, ok := sc.mutation.MachineID(); ok {
+		_spec.SetField(schedule.FieldMachineID, field.TypeString, value)
+		_node.MachineID = value
+	}
+	if value, ok := sc.mutation.DefinitionName(); ok {
+		_spec.SetField(schedule.FieldDefinitionName, field.TypeString, value)
+		_node.DefinitionName = value
+	}
+	if value, ok := sc.mutation.State(); ok {
+		_spec.SetField(schedule.FieldState, field.TypeString, value)
+		_node.State = value
+	}
+	if value, ok := sc.mutation.NextRunAt(); ok {
+		_spec.SetField(schedule.FieldNextRunAt, field.TypeTime, value)
+		_node.NextRunAt = value
+	}
+	if value, ok := sc.mutation.LastRunAt(); ok {
+		_spec.SetField(schedule.FieldLastRunAt, field.TypeTime, value)
+		_node.LastRunAt = &value
+	}
+	if value, ok := sc.mutation.LastError(); ok {
+		_spec.SetField(schedule.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := sc.mutation.Enabled(); ok {
+		_spec.SetField(schedule.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := sc.mutation.CreatedAt(); ok {
+		_spec.SetField(schedule.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// ScheduleCreateBulk is the builder for creating many Schedule entities in bulk.
+type ScheduleCreateBulk struct {
+	config
+	err      error
+	builders []*ScheduleCreate
+}
+
+// Save creates the Schedule entities in the database.
+func (scb *ScheduleCreateBulk) Save(ctx context.Context) ([]*Schedule, error) {
+	if scb.err != nil {
+		return nil, scb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(scb.builders))
+	nodes := make([]*Schedule, len(scb.builders))
+	mutators := make([]Mutator, len(scb.builders))
+	for i := range scb.builders {
+		func(i int, root context.Context) {
+			builder := scb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ScheduleMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, scb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, scb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, scb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (scb *ScheduleCreateBulk) SaveX(ctx context.Context) []*Schedule {
+	v, err := scb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (scb *ScheduleCreateBulk) Exec(ctx context.Context) error {
+	_, err := scb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scb *ScheduleCreateBulk) ExecX(ctx context.Context) {
+	if err := scb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,697 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/schedule"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ScheduleUpdate is the builder for updating Schedule entities.
+type ScheduleUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ScheduleMutation
+}
+
+// Where appends a list predicates to the ScheduleUpdate builder.
+func (su *ScheduleUpdate) Where(ps ...predicate.Schedule) *ScheduleUpdate {
+	su.mutation.Where(ps...)
+	return su
+}
+
+// SetName sets the "name" field.
+func (su *ScheduleUpdate) SetName(s string) *ScheduleUpdate {
+	su.mutation.SetName(s)
+	return su
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableName(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetName(*s)
+	}
+	return su
+}
+
+// SetCron sets the "cron" field.
+func (su *ScheduleUpdate) SetCron(s string) *ScheduleUpdate {
+	su.mutation.SetCron(s)
+	return su
+}
+
+// SetNillableCron sets the "cron" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableCron(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetCron(*s)
+	}
+	return su
+}
+
+// ClearCron clears the value of the "cron" field.
+func (su *ScheduleUpdate) ClearCron() *ScheduleUpdate {
+	su.mutation.ClearCron()
+	return su
+}
+
+// SetEvent sets the "event" field.
+func (su *ScheduleUpdate) SetEvent(s string) *ScheduleUpdate {
+	su.mutation.SetEvent(s)
+	return su
+}
+
+// SetNillableEvent sets the "event" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableEvent(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetEvent(*s)
+	}
+	return su
+}
+
+// SetArgs sets the "args" field.
+func (su *ScheduleUpdate) SetArgs(b []byte) *ScheduleUpdate {
+	su.mutation.SetArgs(b)
+	return su
+}
+
+// ClearArgs clears the value of the "args" field.
+func (su *ScheduleUpdate) ClearArgs() *ScheduleUpdate {
+	su.mutation.ClearArgs()
+	return su
+}
+
+// SetMachineID sets the "machine_id" field.
+func (su *ScheduleUpdate) SetMachineID(s string) *ScheduleUpdate {
+	su.mutation.SetMachineID(s)
+	return su
+}
+
+// SetNillableMachineID sets the "machine_id" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableMachineID(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetMachineID(*s)
+	}
+	return su
+}
+
+// ClearMachineID clears the value of the "machine_id" field.
+func (su *ScheduleUpdate) ClearMachineID() *ScheduleUpdate {
+	su.mutation.ClearMachineID()
+	return su
+}
+
+// SetDefinitionName sets the "definition_name" field.
+func (su *ScheduleUpdate) SetDefinitionName(s string) *ScheduleUpdate {
+	su.mutation.SetDefinitionName(s)
+	return su
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableDefinitionName(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetDefinitionName(*s)
+	}
+	return su
+}
+
+// ClearDefinitionName clears the value of the "definition_name" field.
+func (su *ScheduleUpdate) ClearDefinitionName() *ScheduleUpdate {
+	su.mutation.ClearDefinitionName()
+	return su
+}
+
+// SetState sets the "state" field.
+func (su *ScheduleUpdate) SetState(s string) *ScheduleUpdate {
+	su.mutation.SetState(s)
+	return su
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableState(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetState(*s)
+	}
+	return su
+}
+
+// ClearState clears the value of the "state" field.
+func (su *ScheduleUpdate) ClearState() *ScheduleUpdate {
+	su.mutation.ClearState()
+	return su
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (su *ScheduleUpdate) SetNextRunAt(t time.Time) *ScheduleUpdate {
+	su.mutation.SetNextRunAt(t)
+	return su
+}
+
+// SetNillableNextRunAt sets the "next_run_at" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableNextRunAt(t *time.Time) *ScheduleUpdate {
+	if t != nil {
+		su.SetNextRunAt(*t)
+	}
+	return su
+}
+
+// SetLastRunAt sets the "last_run_at" field.
+func (su *ScheduleUpdate) SetLastRunAt(t time.Time) *ScheduleUpdate {
+	su.mutation.SetLastRunAt(t)
+	return su
+}
+
+// SetNillableLastRunAt sets the "last_run_at" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableLastRunAt(t *time.Time) *ScheduleUpdate {
+	if t != nil {
+		su.SetLastRunAt(*t)
+	}
+	return su
+}
+
+// ClearLastRunAt clears the value of the "last_run_at" field.
+func (su *ScheduleUpdate) ClearLastRunAt() *ScheduleUpdate {
+	su.mutation.ClearLastRunAt()
+	return su
+}
+
+// SetLastError sets the "last_error" field.
+func (su *ScheduleUpdate) SetLastError(s string) *ScheduleUpdate {
+	su.mutation.SetLastError(s)
+	return su
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableLastError(s *string) *ScheduleUpdate {
+	if s != nil {
+		su.SetLastError(*s)
+	}
+	return su
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (su *ScheduleUpdate) ClearLastError() *ScheduleUpdate {
+	su.mutation.ClearLastError()
+	return su
+}
+
+// SetEnabled sets the "enabled" field.
+func (su *ScheduleUpdate) SetEnabled(b bool) *ScheduleUpdate {
+	su.mutation.SetEnabled(b)
+	return su
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (su *ScheduleUpdate) SetNillableEnabled(b *bool) *ScheduleUpdate {
+	if b != nil {
+		su.SetEnabled(*b)
+	}
+	return su
+}
+
+// Mutation returns the ScheduleMutation object of the builder.
+func (su *ScheduleUpdate) Mutation() *ScheduleMutation {
+	return su.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (su *ScheduleUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, su.sqlSave, su.mutation, su.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (su *ScheduleUpdate) SaveX(ctx context.Context) int {
+	affected, err := su.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (su *ScheduleUpdate) Exec(ctx context.Context) error {
+	_, err := su.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (su *ScheduleUpdate) ExecX(ctx context.Context) {
+	if err := su.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (su *ScheduleUpdate) check() error {
+	if v, ok := su.mutation.Name(); ok {
+		if err := schedule.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Schedule.name": %w`, err)}
+		}
+	}
+	if v, ok := su.mutation.Event(); ok {
+		if err := schedule.EventValidator(v); err != nil {
+			return &ValidationError{Name: "event", err: fmt.Errorf(`ent: validator failed for field "Schedule.event": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (su *ScheduleUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := su.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(schedule.Table, schedule.Columns, sqlgraph.NewFieldSpec(schedule.FieldID, field.TypeInt))
+	if ps := su.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := su.mutation.Name(); ok {
+		_spec.SetField(schedule.FieldName, field.TypeString, value)
+	}
+	if value, ok := su.mutation.Cron(); ok {
+		_spec.SetField(schedule.FieldCron, field.TypeString, value)
+	}
+	if su.mutation.CronCleared() {
+		_spec.ClearField(schedule.FieldCron, field.TypeString)
+	}
+	if value, ok := su.mutation.Event(); ok {
+		_spec.SetField(schedule.FieldEvent, field.TypeString, value)
+	}
+	if value, ok := su.mutation.Args(); ok {
+		_spec.SetField(schedule.FieldArgs, field.TypeBytes, value)
+	}
+	if su.mutation.ArgsCleared() {
+		_spec.ClearField(schedule.FieldArgs, field.TypeBytes)
+	}
+	if value, ok := su.mutation.MachineID(); ok {
+		_spec.SetField(schedule.FieldMachineID, field.TypeString, value)
+	}
+	if su.mutation.MachineIDCleared() {
+		_spec.ClearField(schedule.FieldMachineID, field.TypeString)
+	}
+	if value, ok := su.mutation.DefinitionName(); ok {
+		_spec.SetField(schedule.FieldDefinitionName, field.TypeString, value)
+	}
+	if su.mutation.DefinitionNameCleared() {
+		_spec.ClearField(schedule.FieldDefinitionName, field.TypeString)
+	}
+	if value, ok := su.mutation.State(); ok {
+		_spec.SetField(schedule.FieldState, field.TypeString, value)
+	}
+	if su.mutation.StateCleared() {
+		_spec.ClearField(schedule.FieldState, field.TypeString)
+	}
+	if value, ok := su.mutation.NextRunAt(); ok {
+		_spec.SetField(schedule.FieldNextRunAt, field.TypeTime, value)
+	}
+	if value, ok := su.mutation.LastRunAt(); ok {
+		_spec.SetField(schedule.FieldLastRunAt, field.TypeTime, value)
+	}
+	if su.mutation.LastRunAtCleared() {
+		_spec.ClearField(schedule.FieldLastRunAt, field.TypeTime)
+	}
+	if value, ok := su.mutation.LastError(); ok {
+		_spec.SetField(schedule.FieldLastError, field.TypeString, value)
+	}
+	if su.mutation.LastErrorCleared() {
+		_spec.ClearField(schedule.FieldLastError, field.TypeString)
+	}
+	if value, ok := su.mutation.Enabled(); ok {
+		_spec.SetField(schedule.FieldEnabled, field.TypeBool, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, su.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{schedule.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	su.mutation.done = true
+	return n, nil
+}
+
+// ScheduleUpdateOne is the builder for updating a single Schedule entity.
+type ScheduleUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ScheduleMutation
+}
+
+// SetName sets the "name" field.
+func (suo *ScheduleUpdateOne) SetName(s string) *ScheduleUpdateOne {
+	suo.mutation.SetName(s)
+	return suo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableName(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetName(*s)
+	}
+	return suo
+}
+
+// SetCron sets the "cron" field.
+func (suo *ScheduleUpdateOne) SetCron(s string) *ScheduleUpdateOne {
+	suo.mutation.SetCron(s)
+	return suo
+}
+
+// SetNillableCron sets the "cron" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableCron(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetCron(*s)
+	}
+	return suo
+}
+
+// ClearCron clears the value of the "cron" field.
+func (suo *ScheduleUpdateOne) ClearCron() *ScheduleUpdateOne {
+	suo.mutation.ClearCron()
+	return suo
+}
+
+// SetEvent sets the "event" field.
+func (suo *ScheduleUpdateOne) SetEvent(s string) *ScheduleUpdateOne {
+	suo.mutation.SetEvent(s)
+	return suo
+}
+
+// SetNillableEvent sets the "event" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableEvent(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetEvent(*s)
+	}
+	return suo
+}
+
+// SetArgs sets the "args" field.
+func (suo *ScheduleUpdateOne) SetArgs(b []byte) *ScheduleUpdateOne {
+	suo.mutation.SetArgs(b)
+	return suo
+}
+
+// ClearArgs clears the value of the "args" field.
+func (suo *ScheduleUpdateOne) ClearArgs() *ScheduleUpdateOne {
+	suo.mutation.ClearArgs()
+	return suo
+}
+
+// SetMachineID sets the "machine_id" field.
+func (suo *ScheduleUpdateOne) SetMachineID(s string) *ScheduleUpdateOne {
+	suo.mutation.SetMachineID(s)
+	return suo
+}
+
+// SetNillableMachineID sets the "machine_id" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableMachineID(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetMachineID(*s)
+	}
+	return suo
+}
+
+// ClearMachineID clears the value of the "machine_id" field.
+func (suo *ScheduleUpdateOne) ClearMachineID() *ScheduleUpdateOne {
+	suo.mutation.ClearMachineID()
+	return suo
+}
+
+// SetDefinitionName sets the "definition_name" field.
+func (suo *ScheduleUpdateOne) SetDefinitionName(s string) *ScheduleUpdateOne {
+	suo.mutation.SetDefinitionName(s)
+	return suo
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableDefinitionName(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetDefinitionName(*s)
+	}
+	return suo
+}
+
+// ClearDefinitionName clears the value of the "definition_name" field.
+func (suo *ScheduleUpdateOne) ClearDefinitionName() *ScheduleUpdateOne {
+	suo.mutation.ClearDefinitionName()
+	return suo
+}
+
+// SetState sets the "state" field.
+func (suo *ScheduleUpdateOne) SetState(s string) *ScheduleUpdateOne {
+	suo.mutation.SetState(s)
+	return suo
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableState(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetState(*s)
+	}
+	return suo
+}
+
+// ClearState clears the value of the "state" field.
+func (suo *ScheduleUpdateOne) ClearState() *ScheduleUpdateOne {
+	suo.mutation.ClearState()
+	return suo
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (suo *ScheduleUpdateOne) SetNextRunAt(t time.Time) *ScheduleUpdateOne {
+	suo.mutation.SetNextRunAt(t)
+	return suo
+}
+
+// SetNillableNextRunAt sets the "next_run_at" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableNextRunAt(t *time.Time) *ScheduleUpdateOne {
+	if t != nil {
+		suo.SetNextRunAt(*t)
+	}
+	return suo
+}
+
+// SetLastRunAt sets the "last_run_at" field.
+func (suo *ScheduleUpdateOne) SetLastRunAt(t time.Time) *ScheduleUpdateOne {
+	suo.mutation.SetLastRunAt(t)
+	return suo
+}
+
+// SetNillableLastRunAt sets the "last_run_at" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableLastRunAt(t *time.Time) *ScheduleUpdateOne {
+	if t != nil {
+		suo.SetLastRunAt(*t)
+	}
+	return suo
+}
+
+// ClearLastRunAt clears the value of the "last_run_at" field.
+func (suo *ScheduleUpdateOne) ClearLastRunAt() *ScheduleUpdateOne {
+	suo.mutation.ClearLastRunAt()
+	return suo
+}
+
+// SetLastError sets the "last_error" field.
+func (suo *ScheduleUpdateOne) SetLastError(s string) *ScheduleUpdateOne {
+	suo.mutation.SetLastError(s)
+	return suo
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableLastError(s *string) *ScheduleUpdateOne {
+	if s != nil {
+		suo.SetLastError(*s)
+	}
+	return suo
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (suo *ScheduleUpdateOne) ClearLastError() *ScheduleUpdateOne {
+	suo.mutation.ClearLastError()
+	return suo
+}
+
+// SetEnabled sets the "enabled" field.
+func (suo *ScheduleUpdateOne) SetEnabled(b bool) *ScheduleUpdateOne {
+	suo.mutation.SetEnabled(b)
+	return suo
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (suo *ScheduleUpdateOne) SetNillableEnabled(b *bool) *ScheduleUpdateOne {
+	if b != nil {
+		suo.SetEnabled(*b)
+	}
+	return suo
+}
+
+// Mutation returns the ScheduleMutation object of the builder.
+func (suo *ScheduleUpdateOne) Mutation() *ScheduleMutation {
+	return suo.mutation
+}
+
+// Where appends a list predicates to the ScheduleUpdate builder.
+func (suo *ScheduleUpdateOne) Where(ps ...predicate.Schedule) *ScheduleUpdateOne {
+	suo.mutation.Where(ps...)
+	return suo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (suo *ScheduleUpdateOne) Select(field string, fields ...string) *ScheduleUpdateOne {
+	suo.fields = append([]string{field}, fields...)
+	return suo
+}
+
+// Save executes the query and returns the updated Schedule entity.
+func (suo *ScheduleUpdateOne) Save(ctx context.Context) (*Schedule, error) {
+	return withHooks(ctx, suo.sqlSave, suo.mutation, suo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (suo *ScheduleUpdateOne) SaveX(ctx context.Context) *Schedule {
+	node, err := suo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (suo *ScheduleUpdateOne) Exec(ctx context.Context) error {
+	_, err := suo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (suo *ScheduleUpdateOne) ExecX(ctx context.Context) {
+	if err := suo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (suo *ScheduleUpdateOne) check() error {
+	if v, ok := suo.mutation.Name(); ok {
+		if err := schedule.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Schedule.name": %w`, err)}
+		}
+	}
+	if v, ok := suo.mutation.Event(); ok {
+		if err := schedule.EventValidator(v); err != nil {
+			return &ValidationError{Name: "event", err: fmt.Errorf(`ent: validator failed for field "Schedule.event": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (suo *ScheduleUpdateOne) sqlSave(ctx context.Context) (_node *Schedule, err error) {
+	if err := suo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(schedule.Table, schedule.Columns, sqlgraph.NewFieldSpec(schedule.FieldID, field.TypeInt))
+	id, ok := suo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Schedule.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := suo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, schedule.FieldID)
+		for _, f := range fields {
+			if !schedule.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != schedule.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := suo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := suo.mutation.Name(); ok {
+		_spec.SetField(schedule.FieldName, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.Cron(); ok {
+		_spec.SetField(schedule.FieldCron, field.TypeString, value)
+	}
+	if suo.mutation.CronCleared() {
+		_spec.ClearField(schedule.FieldCron, field.TypeString)
+	}
+	if value, ok := suo.mutation.Event(); ok {
+		_spec.SetField(schedule.FieldEvent, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.Args(); ok {
+		_spec.SetField(schedule.FieldArgs, field.TypeBytes, value)
+	}
+	if suo.mutation.ArgsCleared() {
+		_spec.ClearField(schedule.FieldArgs, field.TypeBytes)
+	}
+	if value, ok := suo.mutation.MachineID(); ok {
+		_spec.SetField(schedule.FieldMachineID, field.TypeString, value)
+	}
+	if suo.mutation.MachineIDCleared() {
+		_spec.ClearField(schedule.FieldMachineID, field.TypeString)
+	}
+	if value, ok := suo.mutation.DefinitionName(); ok {
+		_spec.SetField(schedule.FieldDefinitionName, field.TypeString, value)
+	}
+	if suo.mutation.DefinitionNameCleared() {
+		_spec.ClearField(schedule.FieldDefinitionName, field.TypeString)
+	}
+	if value, ok := suo.mutation.State(); ok {
+		_spec.SetField(schedule.FieldState, field.TypeString, value)
+	}
+	if suo.mutation.StateCleared() {
+		_spec.ClearField(schedule.FieldState, field.TypeString)
+	}
+	if value, ok := suo.mutation.NextRunAt(); ok {
+		_spec.SetField(schedule.FieldNextRunAt, field.TypeTime, value)
+	}
+	if value, ok := suo.mutation.LastRunAt(); ok {
+		_spec.SetField(schedule.FieldLastRunAt, field.TypeTime, value)
+	}
+	if suo.mutation.LastRunAtCleared() {
+		_spec.ClearField(schedule.FieldLastRunAt, field.TypeTime)
+	}
+	if value, ok := suo.mutation.LastError(); ok {
+		_spec.SetField(schedule.FieldLastError, field.TypeString, value)
+	}
+	if suo.mutation.LastErrorCleared() {
+		_spec.ClearField(schedule.FieldLastError, field.TypeString)
+	}
+	if value, ok := suo.mutation.Enabled(); ok {
+		_spec.SetField(schedule.FieldEnabled, field.TypeBool, value)
+	}
+	_node = &Schedule{config: suo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, suo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{schedule.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	suo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ArchivedMachine holds the schema definition for the ArchivedMachine entity.
+// It keeps a completed machine after it was moved out of the state_machines table.
+type ArchivedMachine struct {
+	ent.Schema
+}
+
+// Fields of the ArchivedMachine.
+func (ArchivedMachine) Fields() []ent.Field {
+	return []ent.Field{
+		// Not unique: a machine ID may be reused and its machine archived again.
+		field.String("machine_id").
+			NotEmpty(),
+		field.String("current_state"),
+		field.String("definition_name").
+			Default(""),
+		field.Int("definition_version").
+			Default(0),
+		field.Bytes("data").
+			Optional(),
+		field.Bool("suspended").
+			Default(false),
+		// Created, updated and state entry times of the machine before it was archived.
+		field.Time("created_at"),
+		field.Time("updated_at"),
+		field.Time("entered_at").
+			Optional().
+			Nillable(),
+		field.Time("archived_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the ArchivedMachine.
+func (ArchivedMachine) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("history", ArchivedTransition.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the ArchivedMachine.
+func (ArchivedMachine) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("machine_id"),
+		index.Fields("archived_at"),
+	}
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// ArchivedTransition holds the schema definition for the ArchivedTransition entity.
+// It is a history entry of an archived machine, copied from the state_transitions table.
+type ArchivedTransition struct {
+	ent.Schema
+}
+
+// Fields of the ArchivedTransition.
+func (ArchivedTransition) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("from_state"),
+		field.String("to_state"),
+		field.String("event"),
+		field.Time("timestamp").
+			Default(time.Now),
+		// Kind and compensation are kept as strings so that archives outlive changes to
+		// the values of the StateTransition enums.
+		field.String("kind"),
+		field.String("reason").
+			Optional(),
+		field.String("actor").
+			Optional(),
+		field.String("error").
+			Optional(),
+		field.String("compensation").
+			Optional(),
+	}
+}
+
+// Edges of the ArchivedTransition.
+func (ArchivedTransition) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("machine", ArchivedMachine.Type).
+			Ref("history").
+			Unique(),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DeadLetter holds the schema definition for the DeadLetter entity.
+// It records an event whose transition failed, so it can be inspected and retried.
+type DeadLetter struct {
+	ent.Schema
+}
+
+// Fields of the DeadLetter.
+func (DeadLetter) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("machine_id"),
+		field.String("event"),
+		// Args holds the arguments of the event, encoded as a JSON array.
+		field.Bytes("args").
+			Optional(),
+		field.String("idempotency_key").
+			Optional(),
+		// Phase of the transition that failed, empty when a middleware rejected the event.
+		field.String("phase").
+			Optional(),
+		field.String("error"),
+		field.Int("attempts").
+			Default(1),
+		field.Enum("status").
+			Values("pending", "resolved", "discarded").
+			Default("pending"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the DeadLetter.
+func (DeadLetter) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "machine_id"),
+	}
+}
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Job holds the schema definition for the Job entity.
+// A job is an asynchronous action enqueued when a machine enters a state.
+type Job struct {
+	ent.Schema
+}
+
+// Fields of the Job.
+func (Job) Fields() []ent.Field {
+	return []ent.Field{
+		// Name of the async action registered with the manager.
+		field.String("name").
+			NotEmpty(),
+		// State whose entry enqueued the job.
+		field.String("state"),
+		// Payload holds the arguments of the transition, encoded as a JSON array.
+		field.Bytes("payload").
+			Optional(),
+		field.Enum("status").
+			Values("pending", "running", "succeeded", "failed").
+			Default("pending"),
+		field.Int("attempts").
+			Default(0),
+		// NextRunAt is when a pending job is due, or when the lease of a running job expires.
+		field.Time("next_run_at").
+			Default(time.Now),
+		field.String("last_error").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Job.
+func (Job) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("machine", StateMachine.Type).
+			Ref("jobs").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Job.
+func (Job) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "next_run_at"),
+	}
+}
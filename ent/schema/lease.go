@@ -0,0 +1,23 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Lease holds the schema definition for the Lease entity.
+// A lease elects a single holder among processes competing for the same task.
+type Lease struct {
+	ent.Schema
+}
+
+// Fields of the Lease.
+func (Lease) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Unique().
+			NotEmpty(),
+		field.String("holder"),
+		field.Time("expires_at"),
+	}
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// OutboxMessage holds the schema definition for the OutboxMessage entity.
+// Messages are written in the transaction of a state change and delivered by a relay.
+type OutboxMessage struct {
+	ent.Schema
+}
+
+// Fields of the OutboxMessage.
+func (OutboxMessage) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("topic").
+			NotEmpty(),
+		// Key groups messages that must be delivered in order, such as the ID of a machine.
+		field.String("key").
+			Default(""),
+		field.Bytes("payload").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		// Delivery bookkeeping maintained by the relay.
+		field.Int("attempts").
+			Default(0),
+		field.Time("next_attempt_at").
+			Default(time.Now),
+		field.String("last_error").
+			Optional(),
+		field.Time("published_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the OutboxMessage.
+func (OutboxMessage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("published_at", "next_attempt_at"),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ProcessedEvent holds the schema definition for the ProcessedEvent entity.
+// It records the idempotency keys of the events applied to a machine.
+type ProcessedEvent struct {
+	ent.Schema
+}
+
+// Fields of the ProcessedEvent.
+func (ProcessedEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			NotEmpty(),
+		field.String("event"),
+		// To is the state the event moved the machine to.
+		field.String("to_state"),
+		field.Time("processed_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the ProcessedEvent.
+func (ProcessedEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("machine", StateMachine.Type).
+			Ref("processed_events").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the ProcessedEvent.
+func (ProcessedEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		// A key is applied at most once per machine.
+		index.Fields("key").
+			Edges("machine").
+			Unique(),
+		index.Fields("processed_at"),
+	}
+}
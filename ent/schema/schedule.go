@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Schedule holds the schema definition for the Schedule entity.
+// A schedule sends an event to one machine, or to the machines selected by definition and state,
+// at a fixed time or on a cron expression.
+type Schedule struct {
+	ent.Schema
+}
+
+// Fields of the Schedule.
+func (Schedule) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			Unique().
+			NotEmpty(),
+		// Cron is empty for one-off schedules.
+		field.String("cron").
+			Optional(),
+		field.String("event").
+			NotEmpty(),
+		// Args holds the arguments of the event, encoded as a JSON array.
+		field.Bytes("args").
+			Optional(),
+		// Target machine, or selector of the target machines when empty.
+		field.String("machine_id").
+			Optional(),
+		field.String("definition_name").
+			Optional(),
+		field.String("state").
+			Optional(),
+		field.Time("next_run_at"),
+		field.Time("last_run_at").
+			Optional().
+			Nillable(),
+		field.String("last_error").
+			Optional(),
+		// Enabled is cleared once a one-off schedule has run.
+		field.Bool("enabled").
+			Default(true),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the Schedule.
+func (Schedule) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("enabled", "next_run_at"),
+	}
+}
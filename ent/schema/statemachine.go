@@ -1,9 +1,13 @@
 package schema
 
 import (
+	"time"
+
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // StateMachine holds the schema definition for the StateMachine entity.
@@ -19,6 +23,33 @@ func (StateMachine) Fields() []ent.Field {
 			NotEmpty(),
 		field.String("current_state").
 			NotEmpty(),
+		// Name and version of the definition the machine was created with.
+		// Empty for machines created from a bare transition list.
+		field.String("definition_name").
+			Default(""),
+		field.Int("definition_version").
+			Default(0),
+		// The database defaults fill in machines created before these times were recorded.
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.DefaultExpr("CURRENT_TIMESTAMP")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Annotations(entsql.DefaultExpr("CURRENT_TIMESTAMP")),
+		// EnteredAt is when the machine entered its current state. It is empty for machines
+		// created before it was recorded until fsm.BackfillEnteredAt derives it from history.
+		field.Time("entered_at").
+			Optional().
+			Nillable().
+			Default(time.Now),
+		// Suspended machines reject events until they are resumed.
+		field.Bool("suspended").
+			Default(false),
+		// Data is an opaque payload kept with the machine, such as the context of a saga.
+		field.Bytes("data").
+			Optional(),
 	}
 }
 
@@ -27,6 +58,22 @@ func (StateMachine) Edges() []ent.Edge {
 	return []ent.Edge{
 		// Create a one-to-many relationship with StateTransition.
 		// This means a StateMachine can have many history records.
-		edge.To("history", StateTransition.Type),
+		// Records are deleted with their machine.
+		edge.To("history", StateTransition.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		// Idempotency keys of the events applied to the machine.
+		edge.To("processed_events", ProcessedEvent.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		// Asynchronous actions enqueued by the machine.
+		edge.To("jobs", Job.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the StateMachine.
+func (StateMachine) Indexes() []ent.Index {
+	return []ent.Index{
+		// Machines of a definition by state and time in state, as checked against SLAs.
+		index.Fields("definition_name", "current_state", "entered_at"),
 	}
 }
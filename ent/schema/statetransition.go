@@ -6,6 +6,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // StateTransition holds the schema definition for the StateTransition entity.
@@ -21,6 +22,25 @@ func (StateTransition) Fields() []ent.Field {
 		field.String("event"),
 		field.Time("timestamp").
 			Default(time.Now),
+		// Kind distinguishes regular transitions from administrative history entries
+		// and from failed attempts, which did not change the state.
+		field.Enum("kind").
+			Values("transition", "migration", "failed", "override", "suspend", "resume", "reset").
+			Default("transition"),
+		// Reason is a free-form explanation recorded with administrative entries.
+		field.String("reason").
+			Optional(),
+		// Actor identifies who requested an administrative entry, such as a forced state
+		// or a suspension.
+		field.String("actor").
+			Optional(),
+		// Error is the cause of a failed attempt.
+		field.String("error").
+			Optional(),
+		// Compensation is the outcome of the compensation actions run after a failed attempt.
+		field.Enum("compensation").
+			Values("none", "succeeded", "failed").
+			Default("none"),
 	}
 }
 
@@ -34,3 +54,14 @@ func (StateTransition) Edges() []ent.Edge {
 			Unique(),
 	}
 }
+
+// Indexes of the StateTransition.
+func (StateTransition) Indexes() []ent.Index {
+	return []ent.Index{
+		// History of a machine.
+		index.Edges("machine"),
+		index.Fields("timestamp"),
+		index.Fields("event"),
+		index.Fields("actor"),
+	}
+}
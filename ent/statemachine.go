@@ -5,6 +5,7 @@ package ent
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 
@@ -21,6 +22,20 @@ type StateMachine struct {
 	MachineID string `json:"machine_id,omitempty"`
 	// CurrentState holds the value of the "current_state" field.
 	CurrentState string `json:"current_state,omitempty"`
+	// DefinitionName holds the value of the "definition_name" field.
+	DefinitionName string `json:"definition_name,omitempty"`
+	// DefinitionVersion holds the value of the "definition_version" field.
+	DefinitionVersion int `json:"definition_version,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// EnteredAt holds the value of the "entered_at" field.
+	EnteredAt *time.Time `json:"entered_at,omitempty"`
+	// Suspended holds the value of the "suspended" field.
+	Suspended bool `json:"suspended,omitempty"`
+	// Data holds the value of the "data" field.
+	Data []byte `json:"data,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the StateMachineQuery when eager-loading is set.
 	Edges        StateMachineEdges `json:"edges"`
@@ -31,9 +46,13 @@ type StateMachine struct {
 type StateMachineEdges struct {
 	// History holds the value of the history edge.
 	History []*StateTransition `json:"history,omitempty"`
+	// ProcessedEvents holds the value of the processed_events edge.
+	ProcessedEvents []*ProcessedEvent `json:"processed_events,omitempty"`
+	// Jobs holds the value of the jobs edge.
+	Jobs []*Job `json:"jobs,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [3]bool
 }
 
 // HistoryOrErr returns the History value or an error if the edge
@@ -45,15 +64,39 @@ func (e StateMachineEdges) HistoryOrErr() ([]*StateTransition, error) {
 	return nil, &NotLoadedError{edge: "history"}
 }
 
+// ProcessedEventsOrErr returns the ProcessedEvents value or an error if the edge
+// was not loaded in eager-loading.
+func (e StateMachineEdges) ProcessedEventsOrErr() ([]*ProcessedEvent, error) {
+	if e.loadedTypes[1] {
+		return e.ProcessedEvents, nil
+	}
+	return nil, &NotLoadedError{edge: "processed_events"}
+}
+
+// JobsOrErr returns the Jobs value or an error if the edge
+// was not loaded in eager-loading.
+func (e StateMachineEdges) JobsOrErr() ([]*Job, error) {
+	if e.loadedTypes[2] {
+		return e.Jobs, nil
+	}
+	return nil, &NotLoadedError{edge: "jobs"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*StateMachine) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case statemachine.FieldID:
+		case statemachine.FieldData:
+			values[i] = new([]byte)
+		case statemachine.FieldSuspended:
+			values[i] = new(sql.NullBool)
+		case statemachine.FieldID, statemachine.FieldDefinitionVersion:
 			values[i] = new(sql.NullInt64)
-		case statemachine.FieldMachineID, statemachine.FieldCurrentState:
+		case statemachine.FieldMachineID, statemachine.FieldCurrentState, statemachine.FieldDefinitionName:
 			values[i] = new(sql.NullString)
+		case statemachine.FieldCreatedAt, statemachine.FieldUpdatedAt, statemachine.FieldEnteredAt:
+			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -87,6 +130,49 @@ func (sm *StateMachine) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				sm.CurrentState = value.String
 			}
+		case statemachine.FieldDefinitionName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field definition_name", values[i])
+			} else if value.Valid {
+				sm.DefinitionName = value.String
+			}
+		case statemachine.FieldDefinitionVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field definition_version", values[i])
+			} else if value.Valid {
+				sm.DefinitionVersion = int(value.Int64)
+			}
+		case statemachine.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				sm.CreatedAt = value.Time
+			}
+		case statemachine.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				sm.UpdatedAt = value.Time
+			}
+		case statemachine.FieldEnteredAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field entered_at", values[i])
+			} else if value.Valid {
+				sm.EnteredAt = new(time.Time)
+				*sm.EnteredAt = value.Time
+			}
+		case statemachine.FieldSuspended:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field suspended", values[i])
+			} else if value.Valid {
+				sm.Suspended = value.Bool
+			}
+		case statemachine.FieldData:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field data", values[i])
+			} else if value != nil {
+				sm.Data = *value
+			}
 		default:
 			sm.selectValues.Set(columns[i], values[i])
 		}
@@ -105,6 +191,16 @@ func (sm *StateMachine) QueryHistory() *StateTransitionQuery {
 	return NewStateMachineClient(sm.config).QueryHistory(sm)
 }
 
+// QueryProcessedEvents queries the "processed_events" edge of the StateMachine entity.
+func (sm *StateMachine) QueryProcessedEvents() *ProcessedEventQuery {
+	return NewStateMachineClient(sm.config).QueryProcessedEvents(sm)
+}
+
+// QueryJobs queries the "jobs" edge of the StateMachine entity.
+func (sm *StateMachine) QueryJobs() *JobQuery {
+	return NewStateMachineClient(sm.config).QueryJobs(sm)
+}
+
 // Update returns a builder for updating this StateMachine.
 // Note that you need to call StateMachine.Unwrap() before calling this method if this StateMachine
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -133,6 +229,29 @@ func (sm *StateMachine) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("current_state=")
 	builder.WriteString(sm.CurrentState)
+	builder.WriteString(", ")
+	builder.WriteString("definition_name=")
+	builder.WriteString(sm.DefinitionName)
+	builder.WriteString(", ")
+	builder.WriteString("definition_version=")
+	builder.WriteString(fmt.Sprintf("%v", sm.DefinitionVersion))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(sm.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(sm.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := sm.EnteredAt; v != nil {
+		builder.WriteString("entered_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("suspended=")
+	builder.WriteString(fmt.Sprintf("%v", sm.Suspended))
+	builder.WriteString(", ")
+	builder.WriteString("data=")
+	builder.WriteString(fmt.Sprintf("%v", sm.Data))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -3,6 +3,8 @@
 package statemachine
 
 import (
+	"time"
+
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 )
@@ -16,8 +18,26 @@ const (
 	FieldMachineID = "machine_id"
 	// FieldCurrentState holds the string denoting the current_state field in the database.
 	FieldCurrentState = "current_state"
+	// FieldDefinitionName holds the string denoting the definition_name field in the database.
+	FieldDefinitionName = "definition_name"
+	// FieldDefinitionVersion holds the string denoting the definition_version field in the database.
+	FieldDefinitionVersion = "definition_version"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// FieldEnteredAt holds the string denoting the entered_at field in the database.
+	FieldEnteredAt = "entered_at"
+	// FieldSuspended holds the string denoting the suspended field in the database.
+	FieldSuspended = "suspended"
+	// FieldData holds the string denoting the data field in the database.
+	FieldData = "data"
 	// EdgeHistory holds the string denoting the history edge name in mutations.
 	EdgeHistory = "history"
+	// EdgeProcessedEvents holds the string denoting the processed_events edge name in mutations.
+	EdgeProcessedEvents = "processed_events"
+	// EdgeJobs holds the string denoting the jobs edge name in mutations.
+	EdgeJobs = "jobs"
 	// Table holds the table name of the statemachine in the database.
 	Table = "state_machines"
 	// HistoryTable is the table that holds the history relation/edge.
@@ -27,6 +47,20 @@ const (
 	HistoryInverseTable = "state_transitions"
 	// HistoryColumn is the table column denoting the history relation/edge.
 	HistoryColumn = "state_machine_history"
+	// ProcessedEventsTable is the table that holds the processed_events relation/edge.
+	ProcessedEventsTable = "processed_events"
+	// ProcessedEventsInverseTable is the table name for the ProcessedEvent entity.
+	// It exists in this package in order to avoid circular dependency with the "processedevent" package.
+	ProcessedEventsInverseTable = "processed_events"
+	// ProcessedEventsColumn is the table column denoting the processed_events relation/edge.
+	ProcessedEventsColumn = "state_machine_processed_events"
+	// JobsTable is the table that holds the jobs relation/edge.
+	JobsTable = "jobs"
+	// JobsInverseTable is the table name for the Job entity.
+	// It exists in this package in order to avoid circular dependency with the "job" package.
+	JobsInverseTable = "jobs"
+	// JobsColumn is the table column denoting the jobs relation/edge.
+	JobsColumn = "state_machine_jobs"
 )
 
 // Columns holds all SQL columns for statemachine fields.
@@ -34,6 +68,13 @@ var Columns = []string{
 	FieldID,
 	FieldMachineID,
 	FieldCurrentState,
+	FieldDefinitionName,
+	FieldDefinitionVersion,
+	FieldCreatedAt,
+	FieldUpdatedAt,
+	FieldEnteredAt,
+	FieldSuspended,
+	FieldData,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -51,6 +92,20 @@ var (
 	MachineIDValidator func(string) error
 	// CurrentStateValidator is a validator for the "current_state" field. It is called by the builders before save.
 	CurrentStateValidator func(string) error
+	// DefaultDefinitionName holds the default value on creation for the "definition_name" field.
+	DefaultDefinitionName string
+	// DefaultDefinitionVersion holds the default value on creation for the "definition_version" field.
+	DefaultDefinitionVersion int
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultEnteredAt holds the default value on creation for the "entered_at" field.
+	DefaultEnteredAt func() time.Time
+	// DefaultSuspended holds the default value on creation for the "suspended" field.
+	DefaultSuspended bool
 )
 
 // OrderOption defines the ordering options for the StateMachine queries.
@@ -71,6 +126,36 @@ func ByCurrentState(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCurrentState, opts...).ToFunc()
 }
 
+// ByDefinitionName orders the results by the definition_name field.
+func ByDefinitionName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefinitionName, opts...).ToFunc()
+}
+
+// ByDefinitionVersion orders the results by the definition_version field.
+func ByDefinitionVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefinitionVersion, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByEnteredAt orders the results by the entered_at field.
+func ByEnteredAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEnteredAt, opts...).ToFunc()
+}
+
+// BySuspended orders the results by the suspended field.
+func BySuspended(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSuspended, opts...).ToFunc()
+}
+
 // ByHistoryCount orders the results by history count.
 func ByHistoryCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -84,6 +169,34 @@ func ByHistory(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newHistoryStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByProcessedEventsCount orders the results by processed_events count.
+func ByProcessedEventsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newProcessedEventsStep(), opts...)
+	}
+}
+
+// ByProcessedEvents orders the results by processed_events terms.
+func ByProcessedEvents(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newProcessedEventsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByJobsCount orders the results by jobs count.
+func ByJobsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newJobsStep(), opts...)
+	}
+}
+
+// ByJobs orders the results by jobs terms.
+func ByJobs(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newJobsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newHistoryStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -91,3 +204,17 @@ func newHistoryStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, HistoryTable, HistoryColumn),
 	)
 }
+func newProcessedEventsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ProcessedEventsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ProcessedEventsTable, ProcessedEventsColumn),
+	)
+}
+func newJobsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(JobsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, JobsTable, JobsColumn),
+	)
+}
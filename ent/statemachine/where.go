@@ -3,6 +3,8 @@
 package statemachine
 
 import (
+	"time"
+
 	"github.com/shinhauhuang/go-fsm/ent/predicate"
 
 	"entgo.io/ent/dialect/sql"
@@ -64,6 +66,41 @@ func CurrentState(v string) predicate.StateMachine {
 	return predicate.StateMachine(sql.FieldEQ(FieldCurrentState, v))
 }
 
+// DefinitionName applies equality check predicate on the "definition_name" field. It's identical to DefinitionNameEQ.
+func DefinitionName(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldDefinitionName, v))
+}
+
+// DefinitionVersion applies equality check predicate on the "definition_version" field. It's identical to DefinitionVersionEQ.
+func DefinitionVersion(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldDefinitionVersion, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// EnteredAt applies equality check predicate on the "entered_at" field. It's identical to EnteredAtEQ.
+func EnteredAt(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldEnteredAt, v))
+}
+
+// Suspended applies equality check predicate on the "suspended" field. It's identical to SuspendedEQ.
+func Suspended(v bool) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldSuspended, v))
+}
+
+// Data applies equality check predicate on the "data" field. It's identical to DataEQ.
+func Data(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldData, v))
+}
+
 // MachineIDEQ applies the EQ predicate on the "machine_id" field.
 func MachineIDEQ(v string) predicate.StateMachine {
 	return predicate.StateMachine(sql.FieldEQ(FieldMachineID, v))
@@ -194,6 +231,301 @@ func CurrentStateContainsFold(v string) predicate.StateMachine {
 	return predicate.StateMachine(sql.FieldContainsFold(FieldCurrentState, v))
 }
 
+// DefinitionNameEQ applies the EQ predicate on the "definition_name" field.
+func DefinitionNameEQ(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldDefinitionName, v))
+}
+
+// DefinitionNameNEQ applies the NEQ predicate on the "definition_name" field.
+func DefinitionNameNEQ(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldDefinitionName, v))
+}
+
+// DefinitionNameIn applies the In predicate on the "definition_name" field.
+func DefinitionNameIn(vs ...string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIn(FieldDefinitionName, vs...))
+}
+
+// DefinitionNameNotIn applies the NotIn predicate on the "definition_name" field.
+func DefinitionNameNotIn(vs ...string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotIn(FieldDefinitionName, vs...))
+}
+
+// DefinitionNameGT applies the GT predicate on the "definition_name" field.
+func DefinitionNameGT(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGT(FieldDefinitionName, v))
+}
+
+// DefinitionNameGTE applies the GTE predicate on the "definition_name" field.
+func DefinitionNameGTE(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGTE(FieldDefinitionName, v))
+}
+
+// DefinitionNameLT applies the LT predicate on the "definition_name" field.
+func DefinitionNameLT(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLT(FieldDefinitionName, v))
+}
+
+// DefinitionNameLTE applies the LTE predicate on the "definition_name" field.
+func DefinitionNameLTE(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLTE(FieldDefinitionName, v))
+}
+
+// DefinitionNameContains applies the Contains predicate on the "definition_name" field.
+func DefinitionNameContains(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldContains(FieldDefinitionName, v))
+}
+
+// DefinitionNameHasPrefix applies the HasPrefix predicate on the "definition_name" field.
+func DefinitionNameHasPrefix(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldHasPrefix(FieldDefinitionName, v))
+}
+
+// DefinitionNameHasSuffix applies the HasSuffix predicate on the "definition_name" field.
+func DefinitionNameHasSuffix(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldHasSuffix(FieldDefinitionName, v))
+}
+
+// DefinitionNameEqualFold applies the EqualFold predicate on the "definition_name" field.
+func DefinitionNameEqualFold(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEqualFold(FieldDefinitionName, v))
+}
+
+// DefinitionNameContainsFold applies the ContainsFold predicate on the "definition_name" field.
+func DefinitionNameContainsFold(v string) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldContainsFold(FieldDefinitionName, v))
+}
+
+// DefinitionVersionEQ applies the EQ predicate on the "definition_version" field.
+func DefinitionVersionEQ(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionNEQ applies the NEQ predicate on the "definition_version" field.
+func DefinitionVersionNEQ(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionIn applies the In predicate on the "definition_version" field.
+func DefinitionVersionIn(vs ...int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIn(FieldDefinitionVersion, vs...))
+}
+
+// DefinitionVersionNotIn applies the NotIn predicate on the "definition_version" field.
+func DefinitionVersionNotIn(vs ...int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotIn(FieldDefinitionVersion, vs...))
+}
+
+// DefinitionVersionGT applies the GT predicate on the "definition_version" field.
+func DefinitionVersionGT(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGT(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionGTE applies the GTE predicate on the "definition_version" field.
+func DefinitionVersionGTE(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGTE(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionLT applies the LT predicate on the "definition_version" field.
+func DefinitionVersionLT(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLT(FieldDefinitionVersion, v))
+}
+
+// DefinitionVersionLTE applies the LTE predicate on the "definition_version" field.
+func DefinitionVersionLTE(v int) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLTE(FieldDefinitionVersion, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// EnteredAtEQ applies the EQ predicate on the "entered_at" field.
+func EnteredAtEQ(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldEnteredAt, v))
+}
+
+// EnteredAtNEQ applies the NEQ predicate on the "entered_at" field.
+func EnteredAtNEQ(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldEnteredAt, v))
+}
+
+// EnteredAtIn applies the In predicate on the "entered_at" field.
+func EnteredAtIn(vs ...time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIn(FieldEnteredAt, vs...))
+}
+
+// EnteredAtNotIn applies the NotIn predicate on the "entered_at" field.
+func EnteredAtNotIn(vs ...time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotIn(FieldEnteredAt, vs...))
+}
+
+// EnteredAtGT applies the GT predicate on the "entered_at" field.
+func EnteredAtGT(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGT(FieldEnteredAt, v))
+}
+
+// EnteredAtGTE applies the GTE predicate on the "entered_at" field.
+func EnteredAtGTE(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGTE(FieldEnteredAt, v))
+}
+
+// EnteredAtLT applies the LT predicate on the "entered_at" field.
+func EnteredAtLT(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLT(FieldEnteredAt, v))
+}
+
+// EnteredAtLTE applies the LTE predicate on the "entered_at" field.
+func EnteredAtLTE(v time.Time) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLTE(FieldEnteredAt, v))
+}
+
+// EnteredAtIsNil applies the IsNil predicate on the "entered_at" field.
+func EnteredAtIsNil() predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIsNull(FieldEnteredAt))
+}
+
+// EnteredAtNotNil applies the NotNil predicate on the "entered_at" field.
+func EnteredAtNotNil() predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotNull(FieldEnteredAt))
+}
+
+// SuspendedEQ applies the EQ predicate on the "suspended" field.
+func SuspendedEQ(v bool) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldSuspended, v))
+}
+
+// SuspendedNEQ applies the NEQ predicate on the "suspended" field.
+func SuspendedNEQ(v bool) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldSuspended, v))
+}
+
+// DataEQ applies the EQ predicate on the "data" field.
+func DataEQ(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldEQ(FieldData, v))
+}
+
+// DataNEQ applies the NEQ predicate on the "data" field.
+func DataNEQ(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNEQ(FieldData, v))
+}
+
+// DataIn applies the In predicate on the "data" field.
+func DataIn(vs ...[]byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIn(FieldData, vs...))
+}
+
+// DataNotIn applies the NotIn predicate on the "data" field.
+func DataNotIn(vs ...[]byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotIn(FieldData, vs...))
+}
+
+// DataGT applies the GT predicate on the "data" field.
+func DataGT(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGT(FieldData, v))
+}
+
+// DataGTE applies the GTE predicate on the "data" field.
+func DataGTE(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldGTE(FieldData, v))
+}
+
+// DataLT applies the LT predicate on the "data" field.
+func DataLT(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLT(FieldData, v))
+}
+
+// DataLTE applies the LTE predicate on the "data" field.
+func DataLTE(v []byte) predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldLTE(FieldData, v))
+}
+
+// DataIsNil applies the IsNil predicate on the "data" field.
+func DataIsNil() predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldIsNull(FieldData))
+}
+
+// DataNotNil applies the NotNil predicate on the "data" field.
+func DataNotNil() predicate.StateMachine {
+	return predicate.StateMachine(sql.FieldNotNull(FieldData))
+}
+
 // HasHistory applies the HasEdge predicate on the "history" edge.
 func HasHistory() predicate.StateMachine {
 	return predicate.StateMachine(func(s *sql.Selector) {
@@ -217,6 +549,52 @@ func HasHistoryWith(preds ...predicate.StateTransition) predicate.StateMachine {
 	})
 }
 
+// HasProcessedEvents applies the HasEdge predicate on the "processed_events" edge.
+func HasProcessedEvents() predicate.StateMachine {
+	return predicate.StateMachine(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, ProcessedEventsTable, ProcessedEventsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasProcessedEventsWith applies the HasEdge predicate on the "processed_events" edge with a given conditions (other predicates).
+func HasProcessedEventsWith(preds ...predicate.ProcessedEvent) predicate.StateMachine {
+	return predicate.StateMachine(func(s *sql.Selector) {
+		step := newProcessedEventsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasJobs applies the HasEdge predicate on the "jobs" edge.
+func HasJobs() predicate.StateMachine {
+	return predicate.StateMachine(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, JobsTable, JobsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasJobsWith applies the HasEdge predicate on the "jobs" edge with a given conditions (other predicates).
+func HasJobsWith(preds ...predicate.Job) predicate.StateMachine {
+	return predicate.StateMachine(func(s *sql.Selector) {
+		step := newJobsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.StateMachine) predicate.StateMachine {
 	return predicate.StateMachine(sql.AndPredicates(predicates...))
@@ -6,7 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/shinhauhuang/go-fsm/ent/job"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
 	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 	"github.com/shinhauhuang/go-fsm/ent/statetransition"
 
@@ -33,6 +36,96 @@ func (smc *StateMachineCreate) SetCurrentState(s string) *StateMachineCreate {
 	return smc
 }
 
+// SetDefinitionName sets the "definition_name" field.
+func (smc *StateMachineCreate) SetDefinitionName(s string) *StateMachineCreate {
+	smc.mutation.SetDefinitionName(s)
+	return smc
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (smc *StateMachineCreate) SetNillableDefinitionName(s *string) *StateMachineCreate {
+	if s != nil {
+		smc.SetDefinitionName(*s)
+	}
+	return smc
+}
+
+// SetDefinitionVersion sets the "definition_version" field.
+func (smc *StateMachineCreate) SetDefinitionVersion(i int) *StateMachineCreate {
+	smc.mutation.SetDefinitionVersion(i)
+	return smc
+}
+
+// SetNillableDefinitionVersion sets the "definition_version" field if the given value is not nil.
+func (smc *StateMachineCreate) SetNillableDefinitionVersion(i *int) *StateMachineCreate {
+	if i != nil {
+		smc.SetDefinitionVersion(*i)
+	}
+	return smc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (smc *StateMachineCreate) SetCreatedAt(t time.Time) *StateMachineCreate {
+	smc.mutation.SetCreatedAt(t)
+	return smc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (smc *StateMachineCreate) SetNillableCreatedAt(t *time.Time) *StateMachineCreate {
+	if t != nil {
+		smc.SetCreatedAt(*t)
+	}
+	return smc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (smc *StateMachineCreate) SetUpdatedAt(t time.Time) *StateMachineCreate {
+	smc.mutation.SetUpdatedAt(t)
+	return smc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (smc *StateMachineCreate) SetNillableUpdatedAt(t *time.Time) *StateMachineCreate {
+	if t != nil {
+		smc.SetUpdatedAt(*t)
+	}
+	return smc
+}
+
+// SetEnteredAt sets the "entered_at" field.
+func (smc *StateMachineCreate) SetEnteredAt(t time.Time) *StateMachineCreate {
+	smc.mutation.SetEnteredAt(t)
+	return smc
+}
+
+// SetNillableEnteredAt sets the "entered_at" field if the given value is not nil.
+func (smc *StateMachineCreate) SetNillableEnteredAt(t *time.Time) *StateMachineCreate {
+	if t != nil {
+		smc.SetEnteredAt(*t)
+	}
+	return smc
+}
+
+// SetSuspended sets the "suspended" field.
+func (smc *StateMachineCreate) SetSuspended(b bool) *StateMachineCreate {
+	smc.mutation.SetSuspended(b)
+	return smc
+}
+
+// SetNillableSuspended sets the "suspended" field if the given value is not nil.
+func (smc *StateMachineCreate) SetNillableSuspended(b *bool) *StateMachineCreate {
+	if b != nil {
+		smc.SetSuspended(*b)
+	}
+	return smc
+}
+
+// SetData sets the "data" field.
+func (smc *StateMachineCreate) SetData(b []byte) *StateMachineCreate {
+	smc.mutation.SetData(b)
+	return smc
+}
+
 // AddHistoryIDs adds the "history" edge to the StateTransition entity by IDs.
 func (smc *StateMachineCreate) AddHistoryIDs(ids ...int) *StateMachineCreate {
 	smc.mutation.AddHistoryIDs(ids...)
@@ -48,6 +141,36 @@ func (smc *StateMachineCreate) AddHistory(s ...*StateTransition) *StateMachineCr
 	return smc.AddHistoryIDs(ids...)
 }
 
+// AddProcessedEventIDs adds the "processed_events" edge to the ProcessedEvent entity by IDs.
+func (smc *StateMachineCreate) AddProcessedEventIDs(ids ...int) *StateMachineCreate {
+	smc.mutation.AddProcessedEventIDs(ids...)
+	return smc
+}
+
+// AddProcessedEvents adds the "processed_events" edges to the ProcessedEvent entity.
+func (smc *StateMachineCreate) AddProcessedEvents(p ...*ProcessedEvent) *StateMachineCreate {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return smc.AddProcessedEventIDs(ids...)
+}
+
+// AddJobIDs adds the "jobs" edge to the Job entity by IDs.
+func (smc *StateMachineCreate) AddJobIDs(ids ...int) *StateMachineCreate {
+	smc.mutation.AddJobIDs(ids...)
+	return smc
+}
+
+// AddJobs adds the "jobs" edges to the Job entity.
+func (smc *StateMachineCreate) AddJobs(j ...*Job) *StateMachineCreate {
+	ids := make([]int, len(j))
+	for i := range j {
+		ids[i] = j[i].ID
+	}
+	return smc.AddJobIDs(ids...)
+}
+
 // Mutation returns the StateMachineMutation object of the builder.
 func (smc *StateMachineCreate) Mutation() *StateMachineMutation {
 	return smc.mutation
@@ -55,6 +178,7 @@ func (smc *StateMachineCreate) Mutation() *StateMachineMutation {
 
 // Save creates the StateMachine in the database.
 func (smc *StateMachineCreate) Save(ctx context.Context) (*StateMachine, error) {
+	smc.defaults()
 	return withHooks(ctx, smc.sqlSave, smc.mutation, smc.hooks)
 }
 
@@ -80,6 +204,34 @@ func (smc *StateMachineCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (smc *StateMachineCreate) defaults() {
+	if _, ok := smc.mutation.DefinitionName(); !ok {
+		v := statemachine.DefaultDefinitionName
+		smc.mutation.SetDefinitionName(v)
+	}
+	if _, ok := smc.mutation.DefinitionVersion(); !ok {
+		v := statemachine.DefaultDefinitionVersion
+		smc.mutation.SetDefinitionVersion(v)
+	}
+	if _, ok := smc.mutation.CreatedAt(); !ok {
+		v := statemachine.DefaultCreatedAt()
+		smc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := smc.mutation.UpdatedAt(); !ok {
+		v := statemachine.DefaultUpdatedAt()
+		smc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := smc.mutation.EnteredAt(); !ok {
+		v := statemachine.DefaultEnteredAt()
+		smc.mutation.SetEnteredAt(v)
+	}
+	if _, ok := smc.mutation.Suspended(); !ok {
+		v := statemachine.DefaultSuspended
+		smc.mutation.SetSuspended(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (smc *StateMachineCreate) check() error {
 	if _, ok := smc.mutation.MachineID(); !ok {
@@ -98,6 +250,15 @@ func (smc *StateMachineCreate) check() error {
 			return &ValidationError{Name: "current_state", err: fmt.Errorf(`ent: validator failed for field "StateMachine.current_state": %w`, err)}
 		}
 	}
+	if _, ok := smc.mutation.DefinitionName(); !ok {
+		return &ValidationError{Name: "definition_name", err: errors.New(`ent: missing required field "StateMachine.definition_name"`)}
+	}
+	if _, ok := smc.mutation.DefinitionVersion(); !ok {
+		return &ValidationError{Name: "definition_version", err: errors.New(`ent: missing required field "StateMachine.definition_version"`)}
+	}
+	if _, ok := smc.mutation.Suspended(); !ok {
+		return &ValidationError{Name: "suspended", err: errors.New(`ent: missing required field "StateMachine.suspended"`)}
+	}
 	return nil
 }
 
@@ -132,6 +293,34 @@ func (smc *StateMachineCreate) createSpec() (*StateMachine, *sqlgraph.CreateSpec
 		_spec.SetField(statemachine.FieldCurrentState, field.TypeString, value)
 		_node.CurrentState = value
 	}
+	if value, ok := smc.mutation.DefinitionName(); ok {
+		_spec.SetField(statemachine.FieldDefinitionName, field.TypeString, value)
+		_node.DefinitionName = value
+	}
+	if value, ok := smc.mutation.DefinitionVersion(); ok {
+		_spec.SetField(statemachine.FieldDefinitionVersion, field.TypeInt, value)
+		_node.DefinitionVersion = value
+	}
+	if value, ok := smc.mutation.CreatedAt(); ok {
+		_spec.SetField(statemachine.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := smc.mutation.UpdatedAt(); ok {
+		_spec.SetField(statemachine.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := smc.mutation.EnteredAt(); ok {
+		_spec.SetField(statemachine.FieldEnteredAt, field.TypeTime, value)
+		_node.EnteredAt = &value
+	}
+	if value, ok := smc.mutation.Suspended(); ok {
+		_spec.SetField(statemachine.FieldSuspended, field.TypeBool, value)
+		_node.Suspended = value
+	}
+	if value, ok := smc.mutation.Data(); ok {
+		_spec.SetField(statemachine.FieldData, field.TypeBytes, value)
+		_node.Data = value
+	}
 	if nodes := smc.mutation.HistoryIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -148,6 +337,38 @@ func (smc *StateMachineCreate) createSpec() (*StateMachine, *sqlgraph.CreateSpec
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := smc.mutation.ProcessedEventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := smc.mutation.JobsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -169,6 +390,7 @@ func (smcb *StateMachineCreateBulk) Save(ctx context.Context) ([]*StateMachine,
 	for i := range smcb.builders {
 		func(i int, root context.Context) {
 			builder := smcb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*StateMachineMutation)
 				if !ok {
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/shinhauhuang/go-fsm/ent/job"
 	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
 	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 	"github.com/shinhauhuang/go-fsm/ent/statetransition"
 
@@ -21,11 +23,13 @@ import (
 // StateMachineQuery is the builder for querying StateMachine entities.
 type StateMachineQuery struct {
 	config
-	ctx         *QueryContext
-	order       []statemachine.OrderOption
-	inters      []Interceptor
-	predicates  []predicate.StateMachine
-	withHistory *StateTransitionQuery
+	ctx                 *QueryContext
+	order               []statemachine.OrderOption
+	inters              []Interceptor
+	predicates          []predicate.StateMachine
+	withHistory         *StateTransitionQuery
+	withProcessedEvents *ProcessedEventQuery
+	withJobs            *JobQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -84,6 +88,50 @@ func (smq *StateMachineQuery) QueryHistory() *StateTransitionQuery {
 	return query
 }
 
+// QueryProcessedEvents chains the current query on the "processed_events" edge.
+func (smq *StateMachineQuery) QueryProcessedEvents() *ProcessedEventQuery {
+	query := (&ProcessedEventClient{config: smq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := smq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := smq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(statemachine.Table, statemachine.FieldID, selector),
+			sqlgraph.To(processedevent.Table, processedevent.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, statemachine.ProcessedEventsTable, statemachine.ProcessedEventsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(smq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryJobs chains the current query on the "jobs" edge.
+func (smq *StateMachineQuery) QueryJobs() *JobQuery {
+	query := (&JobClient{config: smq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := smq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := smq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(statemachine.Table, statemachine.FieldID, selector),
+			sqlgraph.To(job.Table, job.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, statemachine.JobsTable, statemachine.JobsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(smq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first StateMachine entity from the query.
 // Returns a *NotFoundError when no StateMachine was found.
 func (smq *StateMachineQuery) First(ctx context.Context) (*StateMachine, error) {
@@ -271,12 +319,14 @@ func (smq *StateMachineQuery) Clone() *StateMachineQuery {
 		return nil
 	}
 	return &StateMachineQuery{
-		config:      smq.config,
-		ctx:         smq.ctx.Clone(),
-		order:       append([]statemachine.OrderOption{}, smq.order...),
-		inters:      append([]Interceptor{}, smq.inters...),
-		predicates:  append([]predicate.StateMachine{}, smq.predicates...),
-		withHistory: smq.withHistory.Clone(),
+		config:              smq.config,
+		ctx:                 smq.ctx.Clone(),
+		order:               append([]statemachine.OrderOption{}, smq.order...),
+		inters:              append([]Interceptor{}, smq.inters...),
+		predicates:          append([]predicate.StateMachine{}, smq.predicates...),
+		withHistory:         smq.withHistory.Clone(),
+		withProcessedEvents: smq.withProcessedEvents.Clone(),
+		withJobs:            smq.withJobs.Clone(),
 		// clone intermediate query.
 		sql:  smq.sql.Clone(),
 		path: smq.path,
@@ -294,6 +344,28 @@ func (smq *StateMachineQuery) WithHistory(opts ...func(*StateTransitionQuery)) *
 	return smq
 }
 
+// WithProcessedEvents tells the query-builder to eager-load the nodes that are connected to
+// the "processed_events" edge. The optional arguments are used to configure the query builder of the edge.
+func (smq *StateMachineQuery) WithProcessedEvents(opts ...func(*ProcessedEventQuery)) *StateMachineQuery {
+	query := (&ProcessedEventClient{config: smq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	smq.withProcessedEvents = query
+	return smq
+}
+
+// WithJobs tells the query-builder to eager-load the nodes that are connected to
+// the "jobs" edge. The optional arguments are used to configure the query builder of the edge.
+func (smq *StateMachineQuery) WithJobs(opts ...func(*JobQuery)) *StateMachineQuery {
+	query := (&JobClient{config: smq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	smq.withJobs = query
+	return smq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -372,8 +444,10 @@ func (smq *StateMachineQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([
 	var (
 		nodes       = []*StateMachine{}
 		_spec       = smq.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [3]bool{
 			smq.withHistory != nil,
+			smq.withProcessedEvents != nil,
+			smq.withJobs != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -401,6 +475,20 @@ func (smq *StateMachineQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([
 			return nil, err
 		}
 	}
+	if query := smq.withProcessedEvents; query != nil {
+		if err := smq.loadProcessedEvents(ctx, query, nodes,
+			func(n *StateMachine) { n.Edges.ProcessedEvents = []*ProcessedEvent{} },
+			func(n *StateMachine, e *ProcessedEvent) { n.Edges.ProcessedEvents = append(n.Edges.ProcessedEvents, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := smq.withJobs; query != nil {
+		if err := smq.loadJobs(ctx, query, nodes,
+			func(n *StateMachine) { n.Edges.Jobs = []*Job{} },
+			func(n *StateMachine, e *Job) { n.Edges.Jobs = append(n.Edges.Jobs, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -435,6 +523,68 @@ func (smq *StateMachineQuery) loadHistory(ctx context.Context, query *StateTrans
 	}
 	return nil
 }
+func (smq *StateMachineQuery) loadProcessedEvents(ctx context.Context, query *ProcessedEventQuery, nodes []*StateMachine, init func(*StateMachine), assign func(*StateMachine, *ProcessedEvent)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*StateMachine)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.ProcessedEvent(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(statemachine.ProcessedEventsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.state_machine_processed_events
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "state_machine_processed_events" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "state_machine_processed_events" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (smq *StateMachineQuery) loadJobs(ctx context.Context, query *JobQuery, nodes []*StateMachine, init func(*StateMachine), assign func(*StateMachine, *Job)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*StateMachine)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Job(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(statemachine.JobsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.state_machine_jobs
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "state_machine_jobs" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "state_machine_jobs" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (smq *StateMachineQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := smq.querySpec()
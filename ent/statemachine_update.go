@@ -6,8 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/shinhauhuang/go-fsm/ent/job"
 	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
 	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 	"github.com/shinhauhuang/go-fsm/ent/statetransition"
 
@@ -57,6 +60,93 @@ func (smu *StateMachineUpdate) SetNillableCurrentState(s *string) *StateMachineU
 	return smu
 }
 
+// SetDefinitionName sets the "definition_name" field.
+func (smu *StateMachineUpdate) SetDefinitionName(s string) *StateMachineUpdate {
+	smu.mutation.SetDefinitionName(s)
+	return smu
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (smu *StateMachineUpdate) SetNillableDefinitionName(s *string) *StateMachineUpdate {
+	if s != nil {
+		smu.SetDefinitionName(*s)
+	}
+	return smu
+}
+
+// SetDefinitionVersion sets the "definition_version" field.
+func (smu *StateMachineUpdate) SetDefinitionVersion(i int) *StateMachineUpdate {
+	smu.mutation.ResetDefinitionVersion()
+	smu.mutation.SetDefinitionVersion(i)
+	return smu
+}
+
+// SetNillableDefinitionVersion sets the "definition_version" field if the given value is not nil.
+func (smu *StateMachineUpdate) SetNillableDefinitionVersion(i *int) *StateMachineUpdate {
+	if i != nil {
+		smu.SetDefinitionVersion(*i)
+	}
+	return smu
+}
+
+// AddDefinitionVersion adds i to the "definition_version" field.
+func (smu *StateMachineUpdate) AddDefinitionVersion(i int) *StateMachineUpdate {
+	smu.mutation.AddDefinitionVersion(i)
+	return smu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (smu *StateMachineUpdate) SetUpdatedAt(t time.Time) *StateMachineUpdate {
+	smu.mutation.SetUpdatedAt(t)
+	return smu
+}
+
+// SetEnteredAt sets the "entered_at" field.
+func (smu *StateMachineUpdate) SetEnteredAt(t time.Time) *StateMachineUpdate {
+	smu.mutation.SetEnteredAt(t)
+	return smu
+}
+
+// SetNillableEnteredAt sets the "entered_at" field if the given value is not nil.
+func (smu *StateMachineUpdate) SetNillableEnteredAt(t *time.Time) *StateMachineUpdate {
+	if t != nil {
+		smu.SetEnteredAt(*t)
+	}
+	return smu
+}
+
+// ClearEnteredAt clears the value of the "entered_at" field.
+func (smu *StateMachineUpdate) ClearEnteredAt() *StateMachineUpdate {
+	smu.mutation.ClearEnteredAt()
+	return smu
+}
+
+// SetSuspended sets the "suspended" field.
+func (smu *StateMachineUpdate) SetSuspended(b bool) *StateMachineUpdate {
+	smu.mutation.SetSuspended(b)
+	return smu
+}
+
+// SetNillableSuspended sets the "suspended" field if the given value is not nil.
+func (smu *StateMachineUpdate) SetNillableSuspended(b *bool) *StateMachineUpdate {
+	if b != nil {
+		smu.SetSuspended(*b)
+	}
+	return smu
+}
+
+// SetData sets the "data" field.
+func (smu *StateMachineUpdate) SetData(b []byte) *StateMachineUpdate {
+	smu.mutation.SetData(b)
+	return smu
+}
+
+// ClearData clears the value of the "data" field.
+func (smu *StateMachineUpdate) ClearData() *StateMachineUpdate {
+	smu.mutation.ClearData()
+	return smu
+}
+
 // AddHistoryIDs adds the "history" edge to the StateTransition entity by IDs.
 func (smu *StateMachineUpdate) AddHistoryIDs(ids ...int) *StateMachineUpdate {
 	smu.mutation.AddHistoryIDs(ids...)
@@ -72,6 +162,36 @@ func (smu *StateMachineUpdate) AddHistory(s ...*StateTransition) *StateMachineUp
 	return smu.AddHistoryIDs(ids...)
 }
 
+// AddProcessedEventIDs adds the "processed_events" edge to the ProcessedEvent entity by IDs.
+func (smu *StateMachineUpdate) AddProcessedEventIDs(ids ...int) *StateMachineUpdate {
+	smu.mutation.AddProcessedEventIDs(ids...)
+	return smu
+}
+
+// AddProcessedEvents adds the "processed_events" edges to the ProcessedEvent entity.
+func (smu *StateMachineUpdate) AddProcessedEvents(p ...*ProcessedEvent) *StateMachineUpdate {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return smu.AddProcessedEventIDs(ids...)
+}
+
+// AddJobIDs adds the "jobs" edge to the Job entity by IDs.
+func (smu *StateMachineUpdate) AddJobIDs(ids ...int) *StateMachineUpdate {
+	smu.mutation.AddJobIDs(ids...)
+	return smu
+}
+
+// AddJobs adds the "jobs" edges to the Job entity.
+func (smu *StateMachineUpdate) AddJobs(j ...*Job) *StateMachineUpdate {
+	ids := make([]int, len(j))
+	for i := range j {
+		ids[i] = j[i].ID
+	}
+	return smu.AddJobIDs(ids...)
+}
+
 // Mutation returns the StateMachineMutation object of the builder.
 func (smu *StateMachineUpdate) Mutation() *StateMachineMutation {
 	return smu.mutation
@@ -98,8 +218,51 @@ func (smu *StateMachineUpdate) RemoveHistory(s ...*StateTransition) *StateMachin
 	return smu.RemoveHistoryIDs(ids...)
 }
 
+// ClearProcessedEvents clears all "processed_events" edges to the ProcessedEvent entity.
+func (smu *StateMachineUpdate) ClearProcessedEvents() *StateMachineUpdate {
+	smu.mutation.ClearProcessedEvents()
+	return smu
+}
+
+// RemoveProcessedEventIDs removes the "processed_events" edge to ProcessedEvent entities by IDs.
+func (smu *StateMachineUpdate) RemoveProcessedEventIDs(ids ...int) *StateMachineUpdate {
+	smu.mutation.RemoveProcessedEventIDs(ids...)
+	return smu
+}
+
+// RemoveProcessedEvents removes "processed_events" edges to ProcessedEvent entities.
+func (smu *StateMachineUpdate) RemoveProcessedEvents(p ...*ProcessedEvent) *StateMachineUpdate {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return smu.RemoveProcessedEventIDs(ids...)
+}
+
+// ClearJobs clears all "jobs" edges to the Job entity.
+func (smu *StateMachineUpdate) ClearJobs() *StateMachineUpdate {
+	smu.mutation.ClearJobs()
+	return smu
+}
+
+// RemoveJobIDs removes the "jobs" edge to Job entities by IDs.
+func (smu *StateMachineUpdate) RemoveJobIDs(ids ...int) *StateMachineUpdate {
+	smu.mutation.RemoveJobIDs(ids...)
+	return smu
+}
+
+// RemoveJobs removes "jobs" edges to Job entities.
+func (smu *StateMachineUpdate) RemoveJobs(j ...*Job) *StateMachineUpdate {
+	ids := make([]int, len(j))
+	for i := range j {
+		ids[i] = j[i].ID
+	}
+	return smu.RemoveJobIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (smu *StateMachineUpdate) Save(ctx context.Context) (int, error) {
+	smu.defaults()
 	return withHooks(ctx, smu.sqlSave, smu.mutation, smu.hooks)
 }
 
@@ -125,6 +288,14 @@ func (smu *StateMachineUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (smu *StateMachineUpdate) defaults() {
+	if _, ok := smu.mutation.UpdatedAt(); !ok {
+		v := statemachine.UpdateDefaultUpdatedAt()
+		smu.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (smu *StateMachineUpdate) check() error {
 	if v, ok := smu.mutation.MachineID(); ok {
@@ -158,6 +329,33 @@ func (smu *StateMachineUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := smu.mutation.CurrentState(); ok {
 		_spec.SetField(statemachine.FieldCurrentState, field.TypeString, value)
 	}
+	if value, ok := smu.mutation.DefinitionName(); ok {
+		_spec.SetField(statemachine.FieldDefinitionName, field.TypeString, value)
+	}
+	if value, ok := smu.mutation.DefinitionVersion(); ok {
+		_spec.SetField(statemachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := smu.mutation.AddedDefinitionVersion(); ok {
+		_spec.AddField(statemachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := smu.mutation.UpdatedAt(); ok {
+		_spec.SetField(statemachine.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := smu.mutation.EnteredAt(); ok {
+		_spec.SetField(statemachine.FieldEnteredAt, field.TypeTime, value)
+	}
+	if smu.mutation.EnteredAtCleared() {
+		_spec.ClearField(statemachine.FieldEnteredAt, field.TypeTime)
+	}
+	if value, ok := smu.mutation.Suspended(); ok {
+		_spec.SetField(statemachine.FieldSuspended, field.TypeBool, value)
+	}
+	if value, ok := smu.mutation.Data(); ok {
+		_spec.SetField(statemachine.FieldData, field.TypeBytes, value)
+	}
+	if smu.mutation.DataCleared() {
+		_spec.ClearField(statemachine.FieldData, field.TypeBytes)
+	}
 	if smu.mutation.HistoryCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -203,6 +401,96 @@ func (smu *StateMachineUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if smu.mutation.ProcessedEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smu.mutation.RemovedProcessedEventsIDs(); len(nodes) > 0 && !smu.mutation.ProcessedEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smu.mutation.ProcessedEventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if smu.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smu.mutation.RemovedJobsIDs(); len(nodes) > 0 && !smu.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smu.mutation.JobsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, smu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{statemachine.Label}
@@ -251,6 +539,93 @@ func (smuo *StateMachineUpdateOne) SetNillableCurrentState(s *string) *StateMach
 	return smuo
 }
 
+// SetDefinitionName sets the "definition_name" field.
+func (smuo *StateMachineUpdateOne) SetDefinitionName(s string) *StateMachineUpdateOne {
+	smuo.mutation.SetDefinitionName(s)
+	return smuo
+}
+
+// SetNillableDefinitionName sets the "definition_name" field if the given value is not nil.
+func (smuo *StateMachineUpdateOne) SetNillableDefinitionName(s *string) *StateMachineUpdateOne {
+	if s != nil {
+		smuo.SetDefinitionName(*s)
+	}
+	return smuo
+}
+
+// SetDefinitionVersion sets the "definition_version" field.
+func (smuo *StateMachineUpdateOne) SetDefinitionVersion(i int) *StateMachineUpdateOne {
+	smuo.mutation.ResetDefinitionVersion()
+	smuo.mutation.SetDefinitionVersion(i)
+	return smuo
+}
+
+// SetNillableDefinitionVersion sets the "definition_version" field if the given value is not nil.
+func (smuo *StateMachineUpdateOne) SetNillableDefinitionVersion(i *int) *StateMachineUpdateOne {
+	if i != nil {
+		smuo.SetDefinitionVersion(*i)
+	}
+	return smuo
+}
+
+// AddDefinitionVersion adds i to the "definition_version" field.
+func (smuo *StateMachineUpdateOne) AddDefinitionVersion(i int) *StateMachineUpdateOne {
+	smuo.mutation.AddDefinitionVersion(i)
+	return smuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (smuo *StateMachineUpdateOne) SetUpdatedAt(t time.Time) *StateMachineUpdateOne {
+	smuo.mutation.SetUpdatedAt(t)
+	return smuo
+}
+
+// SetEnteredAt sets the "entered_at" field.
+func (smuo *StateMachineUpdateOne) SetEnteredAt(t time.Time) *StateMachineUpdateOne {
+	smuo.mutation.SetEnteredAt(t)
+	return smuo
+}
+
+// SetNillableEnteredAt sets the "entered_at" field if the given value is not nil.
+func (smuo *StateMachineUpdateOne) SetNillableEnteredAt(t *time.Time) *StateMachineUpdateOne {
+	if t != nil {
+		smuo.SetEnteredAt(*t)
+	}
+	return smuo
+}
+
+// ClearEnteredAt clears the value of the "entered_at" field.
+func (smuo *StateMachineUpdateOne) ClearEnteredAt() *StateMachineUpdateOne {
+	smuo.mutation.ClearEnteredAt()
+	return smuo
+}
+
+// SetSuspended sets the "suspended" field.
+func (smuo *StateMachineUpdateOne) SetSuspended(b bool) *StateMachineUpdateOne {
+	smuo.mutation.SetSuspended(b)
+	return smuo
+}
+
+// SetNillableSuspended sets the "suspended" field if the given value is not nil.
+func (smuo *StateMachineUpdateOne) SetNillableSuspended(b *bool) *StateMachineUpdateOne {
+	if b != nil {
+		smuo.SetSuspended(*b)
+	}
+	return smuo
+}
+
+// SetData sets the "data" field.
+func (smuo *StateMachineUpdateOne) SetData(b []byte) *StateMachineUpdateOne {
+	smuo.mutation.SetData(b)
+	return smuo
+}
+
+// ClearData clears the value of the "data" field.
+func (smuo *StateMachineUpdateOne) ClearData() *StateMachineUpdateOne {
+	smuo.mutation.ClearData()
+	return smuo
+}
+
 // AddHistoryIDs adds the "history" edge to the StateTransition entity by IDs.
 func (smuo *StateMachineUpdateOne) AddHistoryIDs(ids ...int) *StateMachineUpdateOne {
 	smuo.mutation.AddHistoryIDs(ids...)
@@ -266,6 +641,36 @@ func (smuo *StateMachineUpdateOne) AddHistory(s ...*StateTransition) *StateMachi
 	return smuo.AddHistoryIDs(ids...)
 }
 
+// AddProcessedEventIDs adds the "processed_events" edge to the ProcessedEvent entity by IDs.
+func (smuo *StateMachineUpdateOne) AddProcessedEventIDs(ids ...int) *StateMachineUpdateOne {
+	smuo.mutation.AddProcessedEventIDs(ids...)
+	return smuo
+}
+
+// AddProcessedEvents adds the "processed_events" edges to the ProcessedEvent entity.
+func (smuo *StateMachineUpdateOne) AddProcessedEvents(p ...*ProcessedEvent) *StateMachineUpdateOne {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return smuo.AddProcessedEventIDs(ids...)
+}
+
+// AddJobIDs adds the "jobs" edge to the Job entity by IDs.
+func (smuo *StateMachineUpdateOne) AddJobIDs(ids ...int) *StateMachineUpdateOne {
+	smuo.mutation.AddJobIDs(ids...)
+	return smuo
+}
+
+// AddJobs adds the "jobs" edges to the Job entity.
+func (smuo *StateMachineUpdateOne) AddJobs(j ...*Job) *StateMachineUpdateOne {
+	ids := make([]int, len(j))
+	for i := range j {
+		ids[i] = j[i].ID
+	}
+	return smuo.AddJobIDs(ids...)
+}
+
 // Mutation returns the StateMachineMutation object of the builder.
 func (smuo *StateMachineUpdateOne) Mutation() *StateMachineMutation {
 	return smuo.mutation
@@ -292,6 +697,48 @@ func (smuo *StateMachineUpdateOne) RemoveHistory(s ...*StateTransition) *StateMa
 	return smuo.RemoveHistoryIDs(ids...)
 }
 
+// ClearProcessedEvents clears all "processed_events" edges to the ProcessedEvent entity.
+func (smuo *StateMachineUpdateOne) ClearProcessedEvents() *StateMachineUpdateOne {
+	smuo.mutation.ClearProcessedEvents()
+	return smuo
+}
+
+// RemoveProcessedEventIDs removes the "processed_events" edge to ProcessedEvent entities by IDs.
+func (smuo *StateMachineUpdateOne) RemoveProcessedEventIDs(ids ...int) *StateMachineUpdateOne {
+	smuo.mutation.RemoveProcessedEventIDs(ids...)
+	return smuo
+}
+
+// RemoveProcessedEvents removes "processed_events" edges to ProcessedEvent entities.
+func (smuo *StateMachineUpdateOne) RemoveProcessedEvents(p ...*ProcessedEvent) *StateMachineUpdateOne {
+	ids := make([]int, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return smuo.RemoveProcessedEventIDs(ids...)
+}
+
+// ClearJobs clears all "jobs" edges to the Job entity.
+func (smuo *StateMachineUpdateOne) ClearJobs() *StateMachineUpdateOne {
+	smuo.mutation.ClearJobs()
+	return smuo
+}
+
+// RemoveJobIDs removes the "jobs" edge to Job entities by IDs.
+func (smuo *StateMachineUpdateOne) RemoveJobIDs(ids ...int) *StateMachineUpdateOne {
+	smuo.mutation.RemoveJobIDs(ids...)
+	return smuo
+}
+
+// RemoveJobs removes "jobs" edges to Job entities.
+func (smuo *StateMachineUpdateOne) RemoveJobs(j ...*Job) *StateMachineUpdateOne {
+	ids := make([]int, len(j))
+	for i := range j {
+		ids[i] = j[i].ID
+	}
+	return smuo.RemoveJobIDs(ids...)
+}
+
 // Where appends a list predicates to the StateMachineUpdate builder.
 func (smuo *StateMachineUpdateOne) Where(ps ...predicate.StateMachine) *StateMachineUpdateOne {
 	smuo.mutation.Where(ps...)
@@ -307,6 +754,7 @@ func (smuo *StateMachineUpdateOne) Select(field string, fields ...string) *State
 
 // Save executes the query and returns the updated StateMachine entity.
 func (smuo *StateMachineUpdateOne) Save(ctx context.Context) (*StateMachine, error) {
+	smuo.defaults()
 	return withHooks(ctx, smuo.sqlSave, smuo.mutation, smuo.hooks)
 }
 
@@ -332,6 +780,14 @@ func (smuo *StateMachineUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (smuo *StateMachineUpdateOne) defaults() {
+	if _, ok := smuo.mutation.UpdatedAt(); !ok {
+		v := statemachine.UpdateDefaultUpdatedAt()
+		smuo.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (smuo *StateMachineUpdateOne) check() error {
 	if v, ok := smuo.mutation.MachineID(); ok {
@@ -382,6 +838,33 @@ func (smuo *StateMachineUpdateOne) sqlSave(ctx context.Context) (_node *StateMac
 	if value, ok := smuo.mutation.CurrentState(); ok {
 		_spec.SetField(statemachine.FieldCurrentState, field.TypeString, value)
 	}
+	if value, ok := smuo.mutation.DefinitionName(); ok {
+		_spec.SetField(statemachine.FieldDefinitionName, field.TypeString, value)
+	}
+	if value, ok := smuo.mutation.DefinitionVersion(); ok {
+		_spec.SetField(statemachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := smuo.mutation.AddedDefinitionVersion(); ok {
+		_spec.AddField(statemachine.FieldDefinitionVersion, field.TypeInt, value)
+	}
+	if value, ok := smuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(statemachine.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := smuo.mutation.EnteredAt(); ok {
+		_spec.SetField(statemachine.FieldEnteredAt, field.TypeTime, value)
+	}
+	if smuo.mutation.EnteredAtCleared() {
+		_spec.ClearField(statemachine.FieldEnteredAt, field.TypeTime)
+	}
+	if value, ok := smuo.mutation.Suspended(); ok {
+		_spec.SetField(statemachine.FieldSuspended, field.TypeBool, value)
+	}
+	if value, ok := smuo.mutation.Data(); ok {
+		_spec.SetField(statemachine.FieldData, field.TypeBytes, value)
+	}
+	if smuo.mutation.DataCleared() {
+		_spec.ClearField(statemachine.FieldData, field.TypeBytes)
+	}
 	if smuo.mutation.HistoryCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -427,6 +910,96 @@ func (smuo *StateMachineUpdateOne) sqlSave(ctx context.Context) (_node *StateMac
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if smuo.mutation.ProcessedEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smuo.mutation.RemovedProcessedEventsIDs(); len(nodes) > 0 && !smuo.mutation.ProcessedEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smuo.mutation.ProcessedEventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.ProcessedEventsTable,
+			Columns: []string{statemachine.ProcessedEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(processedevent.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if smuo.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smuo.mutation.RemovedJobsIDs(); len(nodes) > 0 && !smuo.mutation.JobsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := smuo.mutation.JobsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   statemachine.JobsTable,
+			Columns: []string{statemachine.JobsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(job.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &StateMachine{config: smuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -27,6 +27,16 @@ type StateTransition struct {
 	Event string `json:"event,omitempty"`
 	// Timestamp holds the value of the "timestamp" field.
 	Timestamp time.Time `json:"timestamp,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind statetransition.Kind `json:"kind,omitempty"`
+	// Reason holds the value of the "reason" field.
+	Reason string `json:"reason,omitempty"`
+	// Actor holds the value of the "actor" field.
+	Actor string `json:"actor,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// Compensation holds the value of the "compensation" field.
+	Compensation statetransition.Compensation `json:"compensation,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the StateTransitionQuery when eager-loading is set.
 	Edges                 StateTransitionEdges `json:"edges"`
@@ -61,7 +71,7 @@ func (*StateTransition) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case statetransition.FieldID:
 			values[i] = new(sql.NullInt64)
-		case statetransition.FieldFromState, statetransition.FieldToState, statetransition.FieldEvent:
+		case statetransition.FieldFromState, statetransition.FieldToState, statetransition.FieldEvent, statetransition.FieldKind, statetransition.FieldReason, statetransition.FieldActor, statetransition.FieldError, statetransition.FieldCompensation:
 			values[i] = new(sql.NullString)
 		case statetransition.FieldTimestamp:
 			values[i] = new(sql.NullTime)
@@ -112,6 +122,36 @@ func (st *StateTransition) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				st.Timestamp = value.Time
 			}
+		case statetransition.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				st.Kind = statetransition.Kind(value.String)
+			}
+		case statetransition.FieldReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field reason", values[i])
+			} else if value.Valid {
+				st.Reason = value.String
+			}
+		case statetransition.FieldActor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor", values[i])
+			} else if value.Valid {
+				st.Actor = value.String
+			}
+		case statetransition.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				st.Error = value.String
+			}
+		case statetransition.FieldCompensation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field compensation", values[i])
+			} else if value.Valid {
+				st.Compensation = statetransition.Compensation(value.String)
+			}
 		case statetransition.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field state_machine_history", value)
@@ -171,6 +211,21 @@ func (st *StateTransition) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("timestamp=")
 	builder.WriteString(st.Timestamp.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", st.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("reason=")
+	builder.WriteString(st.Reason)
+	builder.WriteString(", ")
+	builder.WriteString("actor=")
+	builder.WriteString(st.Actor)
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(st.Error)
+	builder.WriteString(", ")
+	builder.WriteString("compensation=")
+	builder.WriteString(fmt.Sprintf("%v", st.Compensation))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -3,6 +3,7 @@
 package statetransition
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -22,6 +23,16 @@ const (
 	FieldEvent = "event"
 	// FieldTimestamp holds the string denoting the timestamp field in the database.
 	FieldTimestamp = "timestamp"
+	// FieldKind holds the string denoting the kind field in the database.
+	FieldKind = "kind"
+	// FieldReason holds the string denoting the reason field in the database.
+	FieldReason = "reason"
+	// FieldActor holds the string denoting the actor field in the database.
+	FieldActor = "actor"
+	// FieldError holds the string denoting the error field in the database.
+	FieldError = "error"
+	// FieldCompensation holds the string denoting the compensation field in the database.
+	FieldCompensation = "compensation"
 	// EdgeMachine holds the string denoting the machine edge name in mutations.
 	EdgeMachine = "machine"
 	// Table holds the table name of the statetransition in the database.
@@ -42,6 +53,11 @@ var Columns = []string{
 	FieldToState,
 	FieldEvent,
 	FieldTimestamp,
+	FieldKind,
+	FieldReason,
+	FieldActor,
+	FieldError,
+	FieldCompensation,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "state_transitions"
@@ -70,6 +86,64 @@ var (
 	DefaultTimestamp func() time.Time
 )
 
+// Kind defines the type for the "kind" enum field.
+type Kind string
+
+// KindTransition is the default value of the Kind enum.
+const DefaultKind = KindTransition
+
+// Kind values.
+const (
+	KindTransition Kind = "transition"
+	KindMigration  Kind = "migration"
+	KindFailed     Kind = "failed"
+	KindOverride   Kind = "override"
+	KindSuspend    Kind = "suspend"
+	KindResume     Kind = "resume"
+	KindReset      Kind = "reset"
+)
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+// KindValidator is a validator for the "kind" field enum values. It is called by the builders before save.
+func KindValidator(k Kind) error {
+	switch k {
+	case KindTransition, KindMigration, KindFailed, KindOverride, KindSuspend, KindResume, KindReset:
+		return nil
+	default:
+		return fmt.Errorf("statetransition: invalid enum value for kind field: %q", k)
+	}
+}
+
+// Compensation defines the type for the "compensation" enum field.
+type Compensation string
+
+// CompensationNone is the default value of the Compensation enum.
+const DefaultCompensation = CompensationNone
+
+// Compensation values.
+const (
+	CompensationNone      Compensation = "none"
+	CompensationSucceeded Compensation = "succeeded"
+	CompensationFailed    Compensation = "failed"
+)
+
+func (c Compensation) String() string {
+	return string(c)
+}
+
+// CompensationValidator is a validator for the "compensation" field enum values. It is called by the builders before save.
+func CompensationValidator(c Compensation) error {
+	switch c {
+	case CompensationNone, CompensationSucceeded, CompensationFailed:
+		return nil
+	default:
+		return fmt.Errorf("statetransition: invalid enum value for compensation field: %q", c)
+	}
+}
+
 // OrderOption defines the ordering options for the StateTransition queries.
 type OrderOption func(*sql.Selector)
 
@@ -98,6 +172,31 @@ func ByTimestamp(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTimestamp, opts...).ToFunc()
 }
 
+// ByKind orders the results by the kind field.
+func ByKind(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKind, opts...).ToFunc()
+}
+
+// ByReason orders the results by the reason field.
+func ByReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldReason, opts...).ToFunc()
+}
+
+// ByActor orders the results by the actor field.
+func ByActor(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldActor, opts...).ToFunc()
+}
+
+// ByError orders the results by the error field.
+func ByError(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldError, opts...).ToFunc()
+}
+
+// ByCompensation orders the results by the compensation field.
+func ByCompensation(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCompensation, opts...).ToFunc()
+}
+
 // ByMachineField orders the results by machine field.
 func ByMachineField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -76,6 +76,21 @@ func Timestamp(v time.Time) predicate.StateTransition {
 	return predicate.StateTransition(sql.FieldEQ(FieldTimestamp, v))
 }
 
+// Reason applies equality check predicate on the "reason" field. It's identical to ReasonEQ.
+func Reason(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldReason, v))
+}
+
+// Actor applies equality check predicate on the "actor" field. It's identical to ActorEQ.
+func Actor(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldActor, v))
+}
+
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldError, v))
+}
+
 // FromStateEQ applies the EQ predicate on the "from_state" field.
 func FromStateEQ(v string) predicate.StateTransition {
 	return predicate.StateTransition(sql.FieldEQ(FieldFromState, v))
@@ -311,6 +326,271 @@ func TimestampLTE(v time.Time) predicate.StateTransition {
 	return predicate.StateTransition(sql.FieldLTE(FieldTimestamp, v))
 }
 
+// KindEQ applies the EQ predicate on the "kind" field.
+func KindEQ(v Kind) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldKind, v))
+}
+
+// KindNEQ applies the NEQ predicate on the "kind" field.
+func KindNEQ(v Kind) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNEQ(FieldKind, v))
+}
+
+// KindIn applies the In predicate on the "kind" field.
+func KindIn(vs ...Kind) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIn(FieldKind, vs...))
+}
+
+// KindNotIn applies the NotIn predicate on the "kind" field.
+func KindNotIn(vs ...Kind) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotIn(FieldKind, vs...))
+}
+
+// ReasonEQ applies the EQ predicate on the "reason" field.
+func ReasonEQ(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldReason, v))
+}
+
+// ReasonNEQ applies the NEQ predicate on the "reason" field.
+func ReasonNEQ(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNEQ(FieldReason, v))
+}
+
+// ReasonIn applies the In predicate on the "reason" field.
+func ReasonIn(vs ...string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIn(FieldReason, vs...))
+}
+
+// ReasonNotIn applies the NotIn predicate on the "reason" field.
+func ReasonNotIn(vs ...string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotIn(FieldReason, vs...))
+}
+
+// ReasonGT applies the GT predicate on the "reason" field.
+func ReasonGT(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldGT(FieldReason, v))
+}
+
+// ReasonGTE applies the GTE predicate on the "reason" field.
+func ReasonGTE(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldGTE(FieldReason, v))
+}
+
+// ReasonLT applies the LT predicate on the "reason" field.
+func ReasonLT(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldLT(FieldReason, v))
+}
+
+// ReasonLTE applies the LTE predicate on the "reason" field.
+func ReasonLTE(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldLTE(FieldReason, v))
+}
+
+// ReasonContains applies the Contains predicate on the "reason" field.
+func ReasonContains(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldContains(FieldReason, v))
+}
+
+// ReasonHasPrefix applies the HasPrefix predicate on the "reason" field.
+func ReasonHasPrefix(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldHasPrefix(FieldReason, v))
+}
+
+// ReasonHasSuffix applies the HasSuffix predicate on the "reason" field.
+func ReasonHasSuffix(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldHasSuffix(FieldReason, v))
+}
+
+// ReasonIsNil applies the IsNil predicate on the "reason" field.
+func ReasonIsNil() predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIsNull(FieldReason))
+}
+
+// ReasonNotNil applies the NotNil predicate on the "reason" field.
+func ReasonNotNil() predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotNull(FieldReason))
+}
+
+// ReasonEqualFold applies the EqualFold predicate on the "reason" field.
+func ReasonEqualFold(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEqualFold(FieldReason, v))
+}
+
+// ReasonContainsFold applies the ContainsFold predicate on the "reason" field.
+func ReasonContainsFold(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldContainsFold(FieldReason, v))
+}
+
+// ActorEQ applies the EQ predicate on the "actor" field.
+func ActorEQ(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldActor, v))
+}
+
+// ActorNEQ applies the NEQ predicate on the "actor" field.
+func ActorNEQ(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNEQ(FieldActor, v))
+}
+
+// ActorIn applies the In predicate on the "actor" field.
+func ActorIn(vs ...string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIn(FieldActor, vs...))
+}
+
+// ActorNotIn applies the NotIn predicate on the "actor" field.
+func ActorNotIn(vs ...string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotIn(FieldActor, vs...))
+}
+
+// ActorGT applies the GT predicate on the "actor" field.
+func ActorGT(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldGT(FieldActor, v))
+}
+
+// ActorGTE applies the GTE predicate on the "actor" field.
+func ActorGTE(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldGTE(FieldActor, v))
+}
+
+// ActorLT applies the LT predicate on the "actor" field.
+func ActorLT(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldLT(FieldActor, v))
+}
+
+// ActorLTE applies the LTE predicate on the "actor" field.
+func ActorLTE(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldLTE(FieldActor, v))
+}
+
+// ActorContains applies the Contains predicate on the "actor" field.
+func ActorContains(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldContains(FieldActor, v))
+}
+
+// ActorHasPrefix applies the HasPrefix predicate on the "actor" field.
+func ActorHasPrefix(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldHasPrefix(FieldActor, v))
+}
+
+// ActorHasSuffix applies the HasSuffix predicate on the "actor" field.
+func ActorHasSuffix(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldHasSuffix(FieldActor, v))
+}
+
+// ActorIsNil applies the IsNil predicate on the "actor" field.
+func ActorIsNil() predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIsNull(FieldActor))
+}
+
+// ActorNotNil applies the NotNil predicate on the "actor" field.
+func ActorNotNil() predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotNull(FieldActor))
+}
+
+// ActorEqualFold applies the EqualFold predicate on the "actor" field.
+func ActorEqualFold(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEqualFold(FieldActor, v))
+}
+
+// ActorContainsFold applies the ContainsFold predicate on the "actor" field.
+func ActorContainsFold(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldContainsFold(FieldActor, v))
+}
+
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorIsNil applies the IsNil predicate on the "error" field.
+func ErrorIsNil() predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIsNull(FieldError))
+}
+
+// ErrorNotNil applies the NotNil predicate on the "error" field.
+func ErrorNotNil() predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotNull(FieldError))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldContainsFold(FieldError, v))
+}
+
+// CompensationEQ applies the EQ predicate on the "compensation" field.
+func CompensationEQ(v Compensation) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldEQ(FieldCompensation, v))
+}
+
+// CompensationNEQ applies the NEQ predicate on the "compensation" field.
+func CompensationNEQ(v Compensation) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNEQ(FieldCompensation, v))
+}
+
+// CompensationIn applies the In predicate on the "compensation" field.
+func CompensationIn(vs ...Compensation) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldIn(FieldCompensation, vs...))
+}
+
+// CompensationNotIn applies the NotIn predicate on the "compensation" field.
+func CompensationNotIn(vs ...Compensation) predicate.StateTransition {
+	return predicate.StateTransition(sql.FieldNotIn(FieldCompensation, vs...))
+}
+
 // HasMachine applies the HasEdge predicate on the "machine" edge.
 func HasMachine() predicate.StateTransition {
 	return predicate.StateTransition(func(s *sql.Selector) {
@@ -54,6 +54,76 @@ func (stc *StateTransitionCreate) SetNillableTimestamp(t *time.Time) *StateTrans
 	return stc
 }
 
+// SetKind sets the "kind" field.
+func (stc *StateTransitionCreate) SetKind(s statetransition.Kind) *StateTransitionCreate {
+	stc.mutation.SetKind(s)
+	return stc
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (stc *StateTransitionCreate) SetNillableKind(s *statetransition.Kind) *StateTransitionCreate {
+	if s != nil {
+		stc.SetKind(*s)
+	}
+	return stc
+}
+
+// SetReason sets the "reason" field.
+func (stc *StateTransitionCreate) SetReason(s string) *StateTransitionCreate {
+	stc.mutation.SetReason(s)
+	return stc
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (stc *StateTransitionCreate) SetNillableReason(s *string) *StateTransitionCreate {
+	if s != nil {
+		stc.SetReason(*s)
+	}
+	return stc
+}
+
+// SetActor sets the "actor" field.
+func (stc *StateTransitionCreate) SetActor(s string) *StateTransitionCreate {
+	stc.mutation.SetActor(s)
+	return stc
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (stc *StateTransitionCreate) SetNillableActor(s *string) *StateTransitionCreate {
+	if s != nil {
+		stc.SetActor(*s)
+	}
+	return stc
+}
+
+// SetError sets the "error" field.
+func (stc *StateTransitionCreate) SetError(s string) *StateTransitionCreate {
+	stc.mutation.SetError(s)
+	return stc
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (stc *StateTransitionCreate) SetNillableError(s *string) *StateTransitionCreate {
+	if s != nil {
+		stc.SetError(*s)
+	}
+	return stc
+}
+
+// SetCompensation sets the "compensation" field.
+func (stc *StateTransitionCreate) SetCompensation(s statetransition.Compensation) *StateTransitionCreate {
+	stc.mutation.SetCompensation(s)
+	return stc
+}
+
+// SetNillableCompensation sets the "compensation" field if the given value is not nil.
+func (stc *StateTransitionCreate) SetNillableCompensation(s *statetransition.Compensation) *StateTransitionCreate {
+	if s != nil {
+		stc.SetCompensation(*s)
+	}
+	return stc
+}
+
 // SetMachineID sets the "machine" edge to the StateMachine entity by ID.
 func (stc *StateTransitionCreate) SetMachineID(id int) *StateTransitionCreate {
 	stc.mutation.SetMachineID(id)
@@ -112,6 +182,14 @@ func (stc *StateTransitionCreate) defaults() {
 		v := statetransition.DefaultTimestamp()
 		stc.mutation.SetTimestamp(v)
 	}
+	if _, ok := stc.mutation.Kind(); !ok {
+		v := statetransition.DefaultKind
+		stc.mutation.SetKind(v)
+	}
+	if _, ok := stc.mutation.Compensation(); !ok {
+		v := statetransition.DefaultCompensation
+		stc.mutation.SetCompensation(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -128,6 +206,22 @@ func (stc *StateTransitionCreate) check() error {
 	if _, ok := stc.mutation.Timestamp(); !ok {
 		return &ValidationError{Name: "timestamp", err: errors.New(`ent: missing required field "StateTransition.timestamp"`)}
 	}
+	if _, ok := stc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "StateTransition.kind"`)}
+	}
+	if v, ok := stc.mutation.Kind(); ok {
+		if err := statetransition.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "StateTransition.kind": %w`, err)}
+		}
+	}
+	if _, ok := stc.mutation.Compensation(); !ok {
+		return &ValidationError{Name: "compensation", err: errors.New(`ent: missing required field "StateTransition.compensation"`)}
+	}
+	if v, ok := stc.mutation.Compensation(); ok {
+		if err := statetransition.CompensationValidator(v); err != nil {
+			return &ValidationError{Name: "compensation", err: fmt.Errorf(`ent: validator failed for field "StateTransition.compensation": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -170,6 +264,26 @@ func (stc *StateTransitionCreate) createSpec() (*StateTransition, *sqlgraph.Crea
 		_spec.SetField(statetransition.FieldTimestamp, field.TypeTime, value)
 		_node.Timestamp = value
 	}
+	if value, ok := stc.mutation.Kind(); ok {
+		_spec.SetField(statetransition.FieldKind, field.TypeEnum, value)
+		_node.Kind = value
+	}
+	if value, ok := stc.mutation.Reason(); ok {
+		_spec.SetField(statetransition.FieldReason, field.TypeString, value)
+		_node.Reason = value
+	}
+	if value, ok := stc.mutation.Actor(); ok {
+		_spec.SetField(statetransition.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := stc.mutation.Error(); ok {
+		_spec.SetField(statetransition.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := stc.mutation.Compensation(); ok {
+		_spec.SetField(statetransition.FieldCompensation, field.TypeEnum, value)
+		_node.Compensation = value
+	}
 	if nodes := stc.mutation.MachineIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
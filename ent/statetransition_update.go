@@ -86,6 +86,94 @@ func (stu *StateTransitionUpdate) SetNillableTimestamp(t *time.Time) *StateTrans
 	return stu
 }
 
+// SetKind sets the "kind" field.
+func (stu *StateTransitionUpdate) SetKind(s statetransition.Kind) *StateTransitionUpdate {
+	stu.mutation.SetKind(s)
+	return stu
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (stu *StateTransitionUpdate) SetNillableKind(s *statetransition.Kind) *StateTransitionUpdate {
+	if s != nil {
+		stu.SetKind(*s)
+	}
+	return stu
+}
+
+// SetReason sets the "reason" field.
+func (stu *StateTransitionUpdate) SetReason(s string) *StateTransitionUpdate {
+	stu.mutation.SetReason(s)
+	return stu
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (stu *StateTransitionUpdate) SetNillableReason(s *string) *StateTransitionUpdate {
+	if s != nil {
+		stu.SetReason(*s)
+	}
+	return stu
+}
+
+// ClearReason clears the value of the "reason" field.
+func (stu *StateTransitionUpdate) ClearReason() *StateTransitionUpdate {
+	stu.mutation.ClearReason()
+	return stu
+}
+
+// SetActor sets the "actor" field.
+func (stu *StateTransitionUpdate) SetActor(s string) *StateTransitionUpdate {
+	stu.mutation.SetActor(s)
+	return stu
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (stu *StateTransitionUpdate) SetNillableActor(s *string) *StateTransitionUpdate {
+	if s != nil {
+		stu.SetActor(*s)
+	}
+	return stu
+}
+
+// ClearActor clears the value of the "actor" field.
+func (stu *StateTransitionUpdate) ClearActor() *StateTransitionUpdate {
+	stu.mutation.ClearActor()
+	return stu
+}
+
+// SetError sets the "error" field.
+func (stu *StateTransitionUpdate) SetError(s string) *StateTransitionUpdate {
+	stu.mutation.SetError(s)
+	return stu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (stu *StateTransitionUpdate) SetNillableError(s *string) *StateTransitionUpdate {
+	if s != nil {
+		stu.SetError(*s)
+	}
+	return stu
+}
+
+// ClearError clears the value of the "error" field.
+func (stu *StateTransitionUpdate) ClearError() *StateTransitionUpdate {
+	stu.mutation.ClearError()
+	return stu
+}
+
+// SetCompensation sets the "compensation" field.
+func (stu *StateTransitionUpdate) SetCompensation(s statetransition.Compensation) *StateTransitionUpdate {
+	stu.mutation.SetCompensation(s)
+	return stu
+}
+
+// SetNillableCompensation sets the "compensation" field if the given value is not nil.
+func (stu *StateTransitionUpdate) SetNillableCompensation(s *statetransition.Compensation) *StateTransitionUpdate {
+	if s != nil {
+		stu.SetCompensation(*s)
+	}
+	return stu
+}
+
 // SetMachineID sets the "machine" edge to the StateMachine entity by ID.
 func (stu *StateTransitionUpdate) SetMachineID(id int) *StateTransitionUpdate {
 	stu.mutation.SetMachineID(id)
@@ -143,7 +231,25 @@ func (stu *StateTransitionUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// check runs all checks and user-defined validators on the builder.
+func (stu *StateTransitionUpdate) check() error {
+	if v, ok := stu.mutation.Kind(); ok {
+		if err := statetransition.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "StateTransition.kind": %w`, err)}
+		}
+	}
+	if v, ok := stu.mutation.Compensation(); ok {
+		if err := statetransition.CompensationValidator(v); err != nil {
+			return &ValidationError{Name: "compensation", err: fmt.Errorf(`ent: validator failed for field "StateTransition.compensation": %w`, err)}
+		}
+	}
+	return nil
+}
+
 func (stu *StateTransitionUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := stu.check(); err != nil {
+		return n, err
+	}
 	_spec := sqlgraph.NewUpdateSpec(statetransition.Table, statetransition.Columns, sqlgraph.NewFieldSpec(statetransition.FieldID, field.TypeInt))
 	if ps := stu.mutation.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
@@ -164,6 +270,30 @@ func (stu *StateTransitionUpdate) sqlSave(ctx context.Context) (n int, err error
 	if value, ok := stu.mutation.Timestamp(); ok {
 		_spec.SetField(statetransition.FieldTimestamp, field.TypeTime, value)
 	}
+	if value, ok := stu.mutation.Kind(); ok {
+		_spec.SetField(statetransition.FieldKind, field.TypeEnum, value)
+	}
+	if value, ok := stu.mutation.Reason(); ok {
+		_spec.SetField(statetransition.FieldReason, field.TypeString, value)
+	}
+	if stu.mutation.ReasonCleared() {
+		_spec.ClearField(statetransition.FieldReason, field.TypeString)
+	}
+	if value, ok := stu.mutation.Actor(); ok {
+		_spec.SetField(statetransition.FieldActor, field.TypeString, value)
+	}
+	if stu.mutation.ActorCleared() {
+		_spec.ClearField(statetransition.FieldActor, field.TypeString)
+	}
+	if value, ok := stu.mutation.Error(); ok {
+		_spec.SetField(statetransition.FieldError, field.TypeString, value)
+	}
+	if stu.mutation.ErrorCleared() {
+		_spec.ClearField(statetransition.FieldError, field.TypeString)
+	}
+	if value, ok := stu.mutation.Compensation(); ok {
+		_spec.SetField(statetransition.FieldCompensation, field.TypeEnum, value)
+	}
 	if stu.mutation.MachineCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -269,6 +399,94 @@ func (stuo *StateTransitionUpdateOne) SetNillableTimestamp(t *time.Time) *StateT
 	return stuo
 }
 
+// SetKind sets the "kind" field.
+func (stuo *StateTransitionUpdateOne) SetKind(s statetransition.Kind) *StateTransitionUpdateOne {
+	stuo.mutation.SetKind(s)
+	return stuo
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (stuo *StateTransitionUpdateOne) SetNillableKind(s *statetransition.Kind) *StateTransitionUpdateOne {
+	if s != nil {
+		stuo.SetKind(*s)
+	}
+	return stuo
+}
+
+// SetReason sets the "reason" field.
+func (stuo *StateTransitionUpdateOne) SetReason(s string) *StateTransitionUpdateOne {
+	stuo.mutation.SetReason(s)
+	return stuo
+}
+
+// SetNillableReason sets the "reason" field if the given value is not nil.
+func (stuo *StateTransitionUpdateOne) SetNillableReason(s *string) *StateTransitionUpdateOne {
+	if s != nil {
+		stuo.SetReason(*s)
+	}
+	return stuo
+}
+
+// ClearReason clears the value of the "reason" field.
+func (stuo *StateTransitionUpdateOne) ClearReason() *StateTransitionUpdateOne {
+	stuo.mutation.ClearReason()
+	return stuo
+}
+
+// SetActor sets the "actor" field.
+func (stuo *StateTransitionUpdateOne) SetActor(s string) *StateTransitionUpdateOne {
+	stuo.mutation.SetActor(s)
+	return stuo
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (stuo *StateTransitionUpdateOne) SetNillableActor(s *string) *StateTransitionUpdateOne {
+	if s != nil {
+		stuo.SetActor(*s)
+	}
+	return stuo
+}
+
+// ClearActor clears the value of the "actor" field.
+func (stuo *StateTransitionUpdateOne) ClearActor() *StateTransitionUpdateOne {
+	stuo.mutation.ClearActor()
+	return stuo
+}
+
+// SetError sets the "error" field.
+func (stuo *StateTransitionUpdateOne) SetError(s string) *StateTransitionUpdateOne {
+	stuo.mutation.SetError(s)
+	return stuo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (stuo *StateTransitionUpdateOne) SetNillableError(s *string) *StateTransitionUpdateOne {
+	if s != nil {
+		stuo.SetError(*s)
+	}
+	return stuo
+}
+
+// ClearError clears the value of the "error" field.
+func (stuo *StateTransitionUpdateOne) ClearError() *StateTransitionUpdateOne {
+	stuo.mutation.ClearError()
+	return stuo
+}
+
+// SetCompensation sets the "compensation" field.
+func (stuo *StateTransitionUpdateOne) SetCompensation(s statetransition.Compensation) *StateTransitionUpdateOne {
+	stuo.mutation.SetCompensation(s)
+	return stuo
+}
+
+// SetNillableCompensation sets the "compensation" field if the given value is not nil.
+func (stuo *StateTransitionUpdateOne) SetNillableCompensation(s *statetransition.Compensation) *StateTransitionUpdateOne {
+	if s != nil {
+		stuo.SetCompensation(*s)
+	}
+	return stuo
+}
+
 // SetMachineID sets the "machine" edge to the StateMachine entity by ID.
 func (stuo *StateTransitionUpdateOne) SetMachineID(id int) *StateTransitionUpdateOne {
 	stuo.mutation.SetMachineID(id)
@@ -339,7 +557,25 @@ func (stuo *StateTransitionUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// check runs all checks and user-defined validators on the builder.
+func (stuo *StateTransitionUpdateOne) check() error {
+	if v, ok := stuo.mutation.Kind(); ok {
+		if err := statetransition.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "StateTransition.kind": %w`, err)}
+		}
+	}
+	if v, ok := stuo.mutation.Compensation(); ok {
+		if err := statetransition.CompensationValidator(v); err != nil {
+			return &ValidationError{Name: "compensation", err: fmt.Errorf(`ent: validator failed for field "StateTransition.compensation": %w`, err)}
+		}
+	}
+	return nil
+}
+
 func (stuo *StateTransitionUpdateOne) sqlSave(ctx context.Context) (_node *StateTransition, err error) {
+	if err := stuo.check(); err != nil {
+		return _node, err
+	}
 	_spec := sqlgraph.NewUpdateSpec(statetransition.Table, statetransition.Columns, sqlgraph.NewFieldSpec(statetransition.FieldID, field.TypeInt))
 	id, ok := stuo.mutation.ID()
 	if !ok {
@@ -377,6 +613,30 @@ func (stuo *StateTransitionUpdateOne) sqlSave(ctx context.Context) (_node *State
 	if value, ok := stuo.mutation.Timestamp(); ok {
 		_spec.SetField(statetransition.FieldTimestamp, field.TypeTime, value)
 	}
+	if value, ok := stuo.mutation.Kind(); ok {
+		_spec.SetField(statetransition.FieldKind, field.TypeEnum, value)
+	}
+	if value, ok := stuo.mutation.Reason(); ok {
+		_spec.SetField(statetransition.FieldReason, field.TypeString, value)
+	}
+	if stuo.mutation.ReasonCleared() {
+		_spec.ClearField(statetransition.FieldReason, field.TypeString)
+	}
+	if value, ok := stuo.mutation.Actor(); ok {
+		_spec.SetField(statetransition.FieldActor, field.TypeString, value)
+	}
+	if stuo.mutation.ActorCleared() {
+		_spec.ClearField(statetransition.FieldActor, field.TypeString)
+	}
+	if value, ok := stuo.mutation.Error(); ok {
+		_spec.SetField(statetransition.FieldError, field.TypeString, value)
+	}
+	if stuo.mutation.ErrorCleared() {
+		_spec.ClearField(statetransition.FieldError, field.TypeString)
+	}
+	if value, ok := stuo.mutation.Compensation(); ok {
+		_spec.SetField(statetransition.FieldCompensation, field.TypeEnum, value)
+	}
 	if stuo.mutation.MachineCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
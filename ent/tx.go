@@ -12,6 +12,22 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// ArchivedMachine is the client for interacting with the ArchivedMachine builders.
+	ArchivedMachine *ArchivedMachineClient
+	// ArchivedTransition is the client for interacting with the ArchivedTransition builders.
+	ArchivedTransition *ArchivedTransitionClient
+	// DeadLetter is the client for interacting with the DeadLetter builders.
+	DeadLetter *DeadLetterClient
+	// Job is the client for interacting with the Job builders.
+	Job *JobClient
+	// Lease is the client for interacting with the Lease builders.
+	Lease *LeaseClient
+	// OutboxMessage is the client for interacting with the OutboxMessage builders.
+	OutboxMessage *OutboxMessageClient
+	// ProcessedEvent is the client for interacting with the ProcessedEvent builders.
+	ProcessedEvent *ProcessedEventClient
+	// Schedule is the client for interacting with the Schedule builders.
+	Schedule *ScheduleClient
 	// StateMachine is the client for interacting with the StateMachine builders.
 	StateMachine *StateMachineClient
 	// StateTransition is the client for interacting with the StateTransition builders.
@@ -147,6 +163,14 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.ArchivedMachine = NewArchivedMachineClient(tx.config)
+	tx.ArchivedTransition = NewArchivedTransitionClient(tx.config)
+	tx.DeadLetter = NewDeadLetterClient(tx.config)
+	tx.Job = NewJobClient(tx.config)
+	tx.Lease = NewLeaseClient(tx.config)
+	tx.OutboxMessage = NewOutboxMessageClient(tx.config)
+	tx.ProcessedEvent = NewProcessedEventClient(tx.config)
+	tx.Schedule = NewScheduleClient(tx.config)
 	tx.StateMachine = NewStateMachineClient(tx.config)
 	tx.StateTransition = NewStateTransitionClient(tx.config)
 }
@@ -158,7 +182,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: StateMachine.QueryXXX(), the query will be executed
+// applies a query, for example: ArchivedMachine.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -0,0 +1,165 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrMailboxFull is returned for an event that did not fit in the mailbox of an actor.
+	ErrMailboxFull = errors.New("mailbox full")
+	// ErrActorStopped is returned for an event sent to an actor after Stop.
+	ErrActorStopped = errors.New("actor stopped")
+)
+
+// OverflowPolicy decides what Send does when the mailbox of an actor is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Send wait for room in the mailbox or for its context to be done.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowReject fails the new event with ErrMailboxFull.
+	OverflowReject
+	// OverflowDropOldest fails the oldest queued event with ErrMailboxFull to make room.
+	OverflowDropOldest
+)
+
+// envelope is an event queued in the mailbox of an actor.
+type envelope struct {
+	ctx    context.Context
+	event  Event
+	args   []interface{}
+	result chan error
+}
+
+// Actor applies the events sent to a machine one at a time in its own goroutine, so senders
+// never wait for the machine's lock or for slow actions.
+type Actor struct {
+	fsm     *FSM
+	size    int
+	policy  OverflowPolicy
+	mu      sync.RWMutex // Held for writing to close the mailbox, for reading to send to it
+	stopped bool
+	mailbox chan *envelope
+	done    chan struct{}
+
+	stopOnce sync.Once
+	stopping chan struct{} // Closed by Stop to release senders waiting for room
+}
+
+// ActorOption configures an Actor.
+type ActorOption func(a *Actor)
+
+// WithMailboxSize sets the number of events an actor queues. The default is 64; a negative size
+// is treated as 0, and OverflowDropOldest queues at least one event.
+func WithMailboxSize(size int) ActorOption {
+	return func(a *Actor) {
+		a.size = size
+	}
+}
+
+// WithOverflowPolicy sets what Send does when the mailbox is full. The default is OverflowBlock.
+func WithOverflowPolicy(policy OverflowPolicy) ActorOption {
+	return func(a *Actor) {
+		a.policy = policy
+	}
+}
+
+// NewActor starts an actor for f. Events should then be sent to the actor rather than applied
+// with f.Transition, which would still wait for the event being processed.
+func NewActor(f *FSM, opts ...ActorOption) *Actor {
+	a := &Actor{fsm: f, size: 64, done: make(chan struct{}), stopping: make(chan struct{})}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.size = max(a.size, 0)
+	if a.policy == OverflowDropOldest {
+		a.size = max(a.size, 1) // Dropping needs a queued event to drop
+	}
+	a.mailbox = make(chan *envelope, a.size)
+	go a.run()
+	return a
+}
+
+// FSM returns the machine of the actor.
+func (a *Actor) FSM() *FSM {
+	return a.fsm
+}
+
+// run applies queued events until the mailbox is closed and drained.
+func (a *Actor) run() {
+	defer close(a.done)
+	for env := range a.mailbox {
+		if err := env.ctx.Err(); err != nil {
+			env.result <- err
+			continue
+		}
+		env.result <- a.fsm.Transition(env.ctx, env.event, env.args...)
+	}
+}
+
+// Send queues an event and returns a channel receiving the result of its Transition.
+// ctx is used by the transition and, with OverflowBlock, bounds the wait for room in the mailbox.
+// Events whose context is done before they are processed fail with the context's error.
+func (a *Actor) Send(ctx context.Context, event Event, args ...interface{}) <-chan error {
+	env := &envelope{ctx: ctx, event: event, args: args, result: make(chan error, 1)}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.stopped {
+		env.result <- ErrActorStopped
+		return env.result
+	}
+
+	switch a.policy {
+	case OverflowReject:
+		select {
+		case a.mailbox <- env:
+		default:
+			env.result <- ErrMailboxFull
+		}
+	case OverflowDropOldest:
+		for sent := false; !sent; {
+			select {
+			case a.mailbox <- env:
+				sent = true
+			default:
+				select {
+				case oldest := <-a.mailbox:
+					oldest.result <- ErrMailboxFull
+				default:
+				}
+			}
+		}
+	default:
+		select {
+		case a.mailbox <- env:
+		case <-ctx.Done():
+			env.result <- ctx.Err()
+		case <-a.stopping:
+			env.result <- ErrActorStopped
+		}
+	}
+	return env.result
+}
+
+// Stop stops accepting events and waits until the queued ones have been processed or ctx is done.
+// Events sent after Stop, and events still waiting for room in the mailbox, fail with
+// ErrActorStopped.
+func (a *Actor) Stop(ctx context.Context) error {
+	a.stopOnce.Do(func() { close(a.stopping) })
+	a.mu.Lock()
+	if !a.stopped {
+		a.stopped = true
+		close(a.mailbox)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
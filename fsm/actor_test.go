@@ -0,0 +1,234 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingMachine returns a machine whose entry into StateRunning waits for release.
+func blockingMachine(t *testing.T, release <-chan struct{}) *FSM {
+	t.Helper()
+	f, err := NewFSM(context.Background(), nil, "", StateIdle, defineTestTransitions())
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+		<-release
+		return nil
+	})
+	return f
+}
+
+// waitResult returns the result received on ch, failing the test after a second.
+func waitResult(t *testing.T, ch <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for result")
+		return nil
+	}
+}
+
+func TestActor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Events are applied in order", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		a := NewActor(f)
+
+		start := a.Send(ctx, EventStart)
+		pause := a.Send(ctx, EventPause)
+		invalid := a.Send(ctx, EventStart)
+		if err := waitResult(t, start); err != nil {
+			t.Errorf("Expected start to succeed, got %v", err)
+		}
+		if err := waitResult(t, pause); err != nil {
+			t.Errorf("Expected pause to succeed, got %v", err)
+		}
+		if err := waitResult(t, invalid); !errors.Is(err, ErrInvalidEvent) {
+			t.Errorf("Expected ErrInvalidEvent, got %v", err)
+		}
+		if err := a.Stop(ctx); err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+		if a.FSM().CurrentState() != StatePaused {
+			t.Errorf("Expected state %s, got %s", StatePaused, a.FSM().CurrentState())
+		}
+	})
+
+	t.Run("Reject overflow", func(t *testing.T) {
+		release := make(chan struct{})
+		a := NewActor(blockingMachine(t, release), WithMailboxSize(1), WithOverflowPolicy(OverflowReject))
+
+		first := a.Send(ctx, EventStart)
+		waitForEmptyMailbox(t, a)
+		queued := a.Send(ctx, EventPause)
+		if err := waitResult(t, a.Send(ctx, EventStop)); !errors.Is(err, ErrMailboxFull) {
+			t.Errorf("Expected ErrMailboxFull, got %v", err)
+		}
+
+		close(release)
+		if err := waitResult(t, first); err != nil {
+			t.Errorf("Expected first event to succeed, got %v", err)
+		}
+		if err := waitResult(t, queued); err != nil {
+			t.Errorf("Expected queued event to succeed, got %v", err)
+		}
+		a.Stop(ctx)
+	})
+
+	t.Run("Drop oldest overflow", func(t *testing.T) {
+		release := make(chan struct{})
+		a := NewActor(blockingMachine(t, release), WithMailboxSize(1), WithOverflowPolicy(OverflowDropOldest))
+
+		first := a.Send(ctx, EventStart)
+		waitForEmptyMailbox(t, a)
+		dropped := a.Send(ctx, EventStop)
+		kept := a.Send(ctx, EventPause)
+		if err := waitResult(t, dropped); !errors.Is(err, ErrMailboxFull) {
+			t.Errorf("Expected ErrMailboxFull for the oldest event, got %v", err)
+		}
+
+		close(release)
+		waitResult(t, first)
+		if err := waitResult(t, kept); err != nil {
+			t.Errorf("Expected newest event to succeed, got %v", err)
+		}
+		a.Stop(ctx)
+	})
+
+	t.Run("Block overflow honours the context", func(t *testing.T) {
+		release := make(chan struct{})
+		a := NewActor(blockingMachine(t, release), WithMailboxSize(1))
+
+		first := a.Send(ctx, EventStart)
+		waitForEmptyMailbox(t, a)
+		a.Send(ctx, EventPause)
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if err := waitResult(t, a.Send(timeout, EventStop)); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+		close(release)
+		waitResult(t, first)
+		a.Stop(ctx)
+	})
+
+	t.Run("Stop drains the mailbox", func(t *testing.T) {
+		release := make(chan struct{})
+		a := NewActor(blockingMachine(t, release))
+
+		first := a.Send(ctx, EventStart)
+		queued := a.Send(ctx, EventPause)
+
+		stopped := make(chan error)
+		go func() { stopped <- a.Stop(ctx) }()
+		// Wait until the mailbox no longer accepts events
+		for {
+			a.mu.RLock()
+			stopping := a.stopped
+			a.mu.RUnlock()
+			if stopping {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		if err := waitResult(t, a.Send(ctx, EventStop)); !errors.Is(err, ErrActorStopped) {
+			t.Errorf("Expected ErrActorStopped, got %v", err)
+		}
+
+		close(release)
+		if err := waitResult(t, stopped); err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+		if err := waitResult(t, first); err != nil {
+			t.Errorf("Expected first event to succeed, got %v", err)
+		}
+		if err := waitResult(t, queued); err != nil {
+			t.Errorf("Expected queued event to be processed, got %v", err)
+		}
+	})
+
+	t.Run("Stop releases blocked senders", func(t *testing.T) {
+		release := make(chan struct{})
+		a := NewActor(blockingMachine(t, release), WithMailboxSize(1))
+
+		first := a.Send(ctx, EventStart)
+		waitForEmptyMailbox(t, a)
+		a.Send(ctx, EventPause)
+		blocked := make(chan (<-chan error))
+		go func() { blocked <- a.Send(ctx, EventStop) }()
+
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if err := a.Stop(timeout); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected Stop to honour its deadline, got %v", err)
+		}
+		if err := waitResult(t, <-blocked); !errors.Is(err, ErrActorStopped) {
+			t.Errorf("Expected ErrActorStopped for the blocked sender, got %v", err)
+		}
+		close(release)
+		waitResult(t, first)
+		if err := a.Stop(ctx); err != nil {
+			t.Errorf("Stop failed: %v", err)
+		}
+	})
+
+	t.Run("Drop oldest overflow without a mailbox", func(t *testing.T) {
+		release := make(chan struct{})
+		a := NewActor(blockingMachine(t, release), WithMailboxSize(0), WithOverflowPolicy(OverflowDropOldest))
+
+		first := a.Send(ctx, EventStart)
+		waitForEmptyMailbox(t, a)
+		sent := make(chan [2]<-chan error)
+		go func() { sent <- [2]<-chan error{a.Send(ctx, EventStop), a.Send(ctx, EventPause)} }()
+		var results [2]<-chan error
+		select {
+		case results = <-sent:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected Send not to wait while the actor is busy")
+		}
+		if err := waitResult(t, results[0]); !errors.Is(err, ErrMailboxFull) {
+			t.Errorf("Expected ErrMailboxFull for the oldest event, got %v", err)
+		}
+
+		close(release)
+		waitResult(t, first)
+		if err := waitResult(t, results[1]); err != nil {
+			t.Errorf("Expected newest event to succeed, got %v", err)
+		}
+		a.Stop(ctx)
+	})
+
+	t.Run("Negative mailbox size", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		a := NewActor(f, WithMailboxSize(-1))
+		if err := waitResult(t, a.Send(ctx, EventStart)); err != nil {
+			t.Errorf("Expected event to succeed, got %v", err)
+		}
+		a.Stop(ctx)
+	})
+}
+
+// waitForEmptyMailbox waits until the actor has taken every queued event.
+func waitForEmptyMailbox(t *testing.T, a *Actor) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for len(a.mailbox) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the actor")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,239 @@
+package fsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/job"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+// jobLease is how long a claimed job is reserved for the worker running it. A job still running
+// when its lease expires, for example because its worker crashed, is run again.
+const jobLease = 5 * time.Minute
+
+// Job is a run of an asynchronous action.
+type Job struct {
+	ID        int
+	MachineID string
+	Name      string
+	State     State  // State whose entry enqueued the job
+	Payload   []byte // Arguments of the transition, encoded as a JSON array
+	Attempt   int    // 1 for the first run
+}
+
+// AsyncAction is an action run in the background by the job worker of a Manager.
+type AsyncAction func(ctx context.Context, job *Job) error
+
+// asyncAction is an async action registered with a Manager.
+type asyncAction struct {
+	action         AsyncAction
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	failureEvent   Event
+}
+
+// AsyncOption configures an async action at registration time.
+type AsyncOption func(a *asyncAction)
+
+// WithMaxAttempts sets the number of runs after which a failing job is given up. The default is 5.
+func WithMaxAttempts(n int) AsyncOption {
+	return func(a *asyncAction) {
+		a.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry of a failing job, doubled for every further
+// retry up to limit. The default is one second, up to five minutes.
+func WithBackoff(initial, limit time.Duration) AsyncOption {
+	return func(a *asyncAction) {
+		a.initialBackoff = initial
+		a.maxBackoff = limit
+	}
+}
+
+// WithFailureEvent sets an event fired into the machine when a job is given up.
+// The transition receives the *Job and the error of the last attempt as arguments.
+func WithFailureEvent(event Event) AsyncOption {
+	return func(a *asyncAction) {
+		a.failureEvent = event
+	}
+}
+
+// backoff returns the delay before retrying a job that failed the given number of times.
+func (a *asyncAction) backoff(attempts int) time.Duration {
+	d := a.initialBackoff
+	for i := 1; i < attempts && d < a.maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, a.maxBackoff)
+}
+
+// RegisterAsync registers an async action under the given name, replacing any previous action
+// with the same name. Machines enqueue it with OnEntryAsync.
+func (m *Manager) RegisterAsync(name string, action AsyncAction, opts ...AsyncOption) error {
+	if name == "" || action == nil {
+		return errors.New("async actions require a name and an action")
+	}
+	a := &asyncAction{
+		action:         action,
+		maxAttempts:    5,
+		initialBackoff: time.Second,
+		maxBackoff:     5 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.asyncActions[name] = a
+	return nil
+}
+
+// OnEntryAsync registers an entry action that enqueues a job running the async action with the
+// given name, as registered with Manager.RegisterAsync. The job is written in the transaction of
+// the transition, so it exists only if the transition commits; failures of the async action do
+// not fail the transition. Only persistent machines can enqueue jobs.
+func (f *FSM) OnEntryAsync(state State, name string, opts ...HookOption) *Handle {
+	machineID := f.machineID
+	return f.OnEntry(state, func(ctx context.Context, args ...interface{}) error {
+		tx := ent.TxFromContext(ctx)
+		if tx == nil {
+			return fmt.Errorf("async action %s requires a persistent machine", name)
+		}
+		payload, err := json.Marshal(args)
+		if err != nil {
+			return fmt.Errorf("failed to encode arguments of async action %s: %w", name, err)
+		}
+		sm, err := tx.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query state machine for async action %s: %w", name, err)
+		}
+		err = tx.Job.Create().
+			SetName(name).
+			SetState(string(state)).
+			SetPayload(payload).
+			SetMachine(sm).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue async action %s: %w", name, err)
+		}
+		return nil
+	}, opts...)
+}
+
+// ProcessJobs runs the jobs that are due once and returns the number run. Several workers may
+// process the same database; each job is claimed by one of them. Only the jobs of the async
+// actions registered with m are run, so workers with different registrations can share a database.
+func (m *Manager) ProcessJobs(ctx context.Context) (int, error) {
+	m.mu.RLock()
+	actions := make(map[string]*asyncAction, len(m.asyncActions))
+	names := make([]string, 0, len(m.asyncActions))
+	for name, a := range m.asyncActions {
+		actions[name] = a
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	due, err := m.client.Job.Query().
+		Where(job.StatusIn(job.StatusPending, job.StatusRunning), job.NextRunAtLTE(now), job.NameIn(names...)).
+		WithMachine().
+		Order(ent.Asc(job.FieldID)).
+		Limit(100).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query jobs: %w", err)
+	}
+
+	processed := 0
+	for _, j := range due {
+		claimed, err := m.client.Job.Update().
+			Where(job.ID(j.ID), job.NextRunAt(j.NextRunAt), job.StatusIn(job.StatusPending, job.StatusRunning)).
+			SetStatus(job.StatusRunning).
+			SetNextRunAt(now.Add(jobLease)).
+			Save(ctx)
+		if err != nil {
+			return processed, fmt.Errorf("failed to claim job %d: %w", j.ID, err)
+		}
+		if claimed == 0 {
+			continue // Claimed by another worker
+		}
+		if err := m.runJob(ctx, j, actions[j.Name]); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// RunJobs processes jobs every interval until ctx is done or processing fails.
+func (m *Manager) RunJobs(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.ProcessJobs(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob runs a claimed job with its async action and records its outcome.
+func (m *Manager) runJob(ctx context.Context, j *ent.Job, a *asyncAction) error {
+	run := &Job{
+		ID:        j.ID,
+		MachineID: j.Edges.Machine.MachineID,
+		Name:      j.Name,
+		State:     State(j.State),
+		Payload:   j.Payload,
+		Attempt:   j.Attempts + 1,
+	}
+	err := a.action(ctx, run)
+
+	update := m.client.Job.UpdateOneID(j.ID).SetAttempts(run.Attempt)
+	switch {
+	case err == nil:
+		update.SetStatus(job.StatusSucceeded)
+	case run.Attempt < a.maxAttempts:
+		update.SetStatus(job.StatusPending).
+			SetNextRunAt(time.Now().Add(a.backoff(run.Attempt))).
+			SetLastError(err.Error())
+	default:
+		if a.failureEvent != "" {
+			if ferr := m.fireFailureEvent(ctx, run, a.failureEvent, err); ferr != nil {
+				err = errors.Join(err, ferr)
+			}
+		}
+		update.SetStatus(job.StatusFailed).SetLastError(err.Error())
+	}
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record outcome of job %d: %w", j.ID, err)
+	}
+	return nil
+}
+
+// fireFailureEvent applies the failure event of a job that was given up.
+func (m *Manager) fireFailureEvent(ctx context.Context, run *Job, event Event, cause error) error {
+	f, err := m.LoadFSM(ctx, run.MachineID)
+	if err != nil {
+		return fmt.Errorf("failure event %s: %w", event, err)
+	}
+	if err := f.Transition(ctx, event, run, cause); err != nil {
+		return fmt.Errorf("failure event %s: %w", event, err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package fsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/job"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+func TestAsyncActions(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	const (
+		StatePaid   State = "paid"
+		StateFailed State = "failed"
+		EventPay    Event = "pay"
+		EventFail   Event = "fail"
+	)
+	def := &Definition{
+		Name:    "async",
+		Initial: StateIdle,
+		Transitions: []Transition{
+			{From: StateIdle, Event: EventPay, To: StatePaid},
+			{From: StatePaid, Event: EventFail, To: StateFailed},
+		},
+		Setup: func(f *FSM) error {
+			f.OnEntryAsync(StatePaid, "notify")
+			return nil
+		},
+	}
+
+	m := NewManager(client)
+	if err := m.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	var runs []*Job
+	failures := 0
+	m.RegisterAsync("notify", func(ctx context.Context, j *Job) error {
+		runs = append(runs, j)
+		if failures > 0 {
+			failures--
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	}, WithMaxAttempts(3), WithBackoff(0, 0), WithFailureEvent(EventFail))
+
+	jobOf := func(t *testing.T, machineID string) *ent.Job {
+		t.Helper()
+		j, err := client.Job.Query().Where(job.HasMachineWith(statemachine.MachineID(machineID))).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query job: %v", err)
+		}
+		return j
+	}
+
+	t.Run("Jobs are enqueued with the transition and retried", func(t *testing.T) {
+		runs, failures = nil, 1
+		f, err := m.NewFSM(ctx, "async", "async_machine_1")
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventPay, "order-1"); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		var args []string
+		if err := json.Unmarshal(jobOf(t, "async_machine_1").Payload, &args); err != nil || !reflect.DeepEqual(args, []string{"order-1"}) {
+			t.Errorf("Expected payload [order-1], got %v, %v", args, err)
+		}
+
+		if n, err := m.ProcessJobs(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected 1 job processed, got %d, %v", n, err)
+		}
+		if j := jobOf(t, "async_machine_1"); j.Status != job.StatusPending || j.Attempts != 1 {
+			t.Errorf("Expected job to be pending retry after 1 attempt, got %s after %d", j.Status, j.Attempts)
+		}
+
+		if n, err := m.ProcessJobs(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected 1 job processed, got %d, %v", n, err)
+		}
+		if j := jobOf(t, "async_machine_1"); j.Status != job.StatusSucceeded || j.Attempts != 2 {
+			t.Errorf("Expected job to succeed on attempt 2, got %s after %d", j.Status, j.Attempts)
+		}
+		if len(runs) != 2 || runs[1].Attempt != 2 || runs[1].MachineID != "async_machine_1" || runs[1].State != StatePaid {
+			t.Errorf("Unexpected runs %+v", runs)
+		}
+		if n, _ := m.ProcessJobs(ctx); n != 0 {
+			t.Errorf("Expected no jobs left, got %d", n)
+		}
+	})
+
+	t.Run("Given up jobs fire the failure event", func(t *testing.T) {
+		runs, failures = nil, 3
+		f, err := m.NewFSM(ctx, "async", "async_machine_2")
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventPay); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			if _, err := m.ProcessJobs(ctx); err != nil {
+				t.Fatalf("ProcessJobs failed: %v", err)
+			}
+		}
+
+		if j := jobOf(t, "async_machine_2"); j.Status != job.StatusFailed || j.Attempts != 3 {
+			t.Errorf("Expected job to fail after 3 attempts, got %s after %d", j.Status, j.Attempts)
+		}
+		sm, err := client.StateMachine.Query().Where(statemachine.MachineID("async_machine_2")).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query state machine: %v", err)
+		}
+		if State(sm.CurrentState) != StateFailed {
+			t.Errorf("Expected state %s, got %s", StateFailed, sm.CurrentState)
+		}
+	})
+
+	t.Run("Failed transitions do not enqueue jobs", func(t *testing.T) {
+		f, err := m.NewFSM(ctx, "async", "async_machine_3")
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StatePaid, func(ctx context.Context, args ...interface{}) error {
+			return errors.New("entry action error")
+		})
+		if err := f.Transition(ctx, EventPay); err == nil {
+			t.Fatalf("Expected error from entry action, got nil")
+		}
+		n, err := client.Job.Query().Where(job.HasMachineWith(statemachine.MachineID("async_machine_3"))).Count(ctx)
+		if err != nil || n != 0 {
+			t.Errorf("Expected no job, got %d, %v", n, err)
+		}
+	})
+
+	t.Run("Unregistered actions are left to other workers", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "async_machine_4", StateIdle, def.Transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntryAsync(StatePaid, "unknown")
+		if err := f.Transition(ctx, EventPay); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if n, err := m.ProcessJobs(ctx); err != nil || n != 0 {
+			t.Fatalf("Expected no job processed, got %d, %v", n, err)
+		}
+		if j := jobOf(t, "async_machine_4"); j.Status != job.StatusPending || j.Attempts != 0 {
+			t.Errorf("Expected job to stay pending without attempts, got %s after %d", j.Status, j.Attempts)
+		}
+
+		other := NewManager(client)
+		other.RegisterAsync("unknown", func(ctx context.Context, j *Job) error { return nil })
+		if n, err := other.ProcessJobs(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected 1 job processed by the worker registering it, got %d, %v", n, err)
+		}
+		if j := jobOf(t, "async_machine_4"); j.Status != job.StatusSucceeded {
+			t.Errorf("Expected job to succeed, got %s", j.Status)
+		}
+	})
+
+	t.Run("Memory machines cannot enqueue jobs", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, def.Transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntryAsync(StatePaid, "notify")
+		if err := f.Transition(ctx, EventPay); err == nil {
+			t.Errorf("Expected error for machine without persistence, got nil")
+		}
+	})
+
+	t.Run("RunJobs stops with the context", func(t *testing.T) {
+		runCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if err := m.RunJobs(runCtx, 5*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestAsyncBackoff(t *testing.T) {
+	a := &asyncAction{initialBackoff: time.Second, maxBackoff: 10 * time.Second}
+	expected := map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 5: 10 * time.Second}
+	for attempts, d := range expected {
+		if got := a.backoff(attempts); got != d {
+			t.Errorf("backoff(%d) = %v, expected %v", attempts, got, d)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+// BulkSelector selects persisted machines. Zero fields match every machine.
+type BulkSelector struct {
+	Definition    string
+	States        []State
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
+	UpdatedBefore time.Time // Machines whose row has not changed since
+}
+
+// predicates returns the conditions of the selector.
+func (s BulkSelector) predicates() []predicate.StateMachine {
+	var ps []predicate.StateMachine
+	if s.Definition != "" {
+		ps = append(ps, statemachine.DefinitionName(s.Definition))
+	}
+	if len(s.States) > 0 {
+		states := make([]string, len(s.States))
+		for i, state := range s.States {
+			states[i] = string(state)
+		}
+		ps = append(ps, statemachine.CurrentStateIn(states...))
+	}
+	if !s.CreatedBefore.IsZero() {
+		ps = append(ps, statemachine.CreatedAtLT(s.CreatedBefore))
+	}
+	if !s.CreatedAfter.IsZero() {
+		ps = append(ps, statemachine.CreatedAtGT(s.CreatedAfter))
+	}
+	if !s.UpdatedBefore.IsZero() {
+		ps = append(ps, statemachine.UpdatedAtLT(s.UpdatedBefore))
+	}
+	return ps
+}
+
+// BulkOptions configures BulkTransition.
+type BulkOptions struct {
+	Concurrency int  // Machines transitioned at once; defaults to 1
+	BatchSize   int  // Machines read per query; defaults to 100
+	DryRun      bool // Evaluate guards with DryRun instead of applying the event
+}
+
+// BulkResult is the outcome of a bulk transition for one machine.
+type BulkResult struct {
+	MachineID string
+	From      State
+	To        State // Target state, also reported for dry runs and failures once it is known
+	Err       error
+}
+
+// BulkReport lists the outcome of a bulk transition per machine, in the order of the selection.
+type BulkReport struct {
+	Results   []BulkResult
+	Succeeded int
+	Failed    int
+}
+
+// BulkTransition applies event to every machine matching sel, loading each through the manager so
+// that its guards, actions and middlewares apply. Machines are read in batches ordered by row and
+// transitioned concurrently within a batch. Failures are reported per machine and do not stop
+// the run; BulkTransition returns an error only when the selection fails or ctx is done, along
+// with the results so far.
+func (m *Manager) BulkTransition(ctx context.Context, sel BulkSelector, event Event, opts BulkOptions, args ...interface{}) (*BulkReport, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+
+	report := &BulkReport{}
+	lastID := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		batch, err := m.client.StateMachine.Query().
+			Where(sel.predicates()...).
+			Where(statemachine.IDGT(lastID)).
+			Order(ent.Asc(statemachine.FieldID)).
+			Limit(opts.BatchSize).
+			All(ctx)
+		if err != nil {
+			return report, fmt.Errorf("failed to select machines: %w", err)
+		}
+		if len(batch) == 0 {
+			return report, nil
+		}
+		lastID = batch[len(batch)-1].ID
+
+		results := make([]BulkResult, len(batch))
+		sem := make(chan struct{}, opts.Concurrency)
+		var wg sync.WaitGroup
+		for i, sm := range batch {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() { <-sem; wg.Done() }()
+				results[i] = m.bulkTransition(ctx, sm, event, opts.DryRun, args)
+			}()
+		}
+		wg.Wait()
+
+		for _, r := range results {
+			if r.Err != nil {
+				report.Failed++
+			} else {
+				report.Succeeded++
+			}
+		}
+		report.Results = append(report.Results, results...)
+	}
+}
+
+// bulkTransition applies or dry-runs event on one machine.
+func (m *Manager) bulkTransition(ctx context.Context, sm *ent.StateMachine, event Event, dryRun bool, args []interface{}) BulkResult {
+	r := BulkResult{MachineID: sm.MachineID, From: State(sm.CurrentState)}
+	f, err := m.LoadFSM(ctx, sm.MachineID)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	r.From = f.CurrentState()
+
+	if dryRun {
+		r.To, r.Err = f.DryRun(ctx, event, args...)
+		return r
+	}
+	f.mu.RLock()
+	r.To, _ = f.target(event)
+	f.mu.RUnlock()
+	r.Err = f.Transition(ctx, event, args...)
+	return r
+}
@@ -0,0 +1,128 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+func TestBulkTransition(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	def := &Definition{
+		Name:        "bulk",
+		Initial:     StateIdle,
+		Transitions: defineTestTransitions(),
+		Setup: func(f *FSM) error {
+			return f.AddCheck(StatePaused, EventStop, "not held", func(ctx context.Context, args ...interface{}) error {
+				if f.Definition().Name == "bulk" && len(args) > 0 && args[0] == "hold" {
+					return errors.New("held")
+				}
+				return nil
+			})
+		},
+	}
+	m := NewManager(client)
+	if err := m.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	// Five paused machines, one running and one in another definition
+	for i := 1; i <= 6; i++ {
+		f, err := m.NewFSM(ctx, "bulk", fmt.Sprintf("bulk_machine_%d", i))
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Transition(ctx, EventStart)
+		if i <= 5 {
+			f.Transition(ctx, EventPause)
+		}
+	}
+	other, err := NewFSM(ctx, client, "bulk_other", StatePaused, defineTestTransitions())
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	_ = other
+	paused := BulkSelector{Definition: "bulk", States: []State{StatePaused}}
+
+	countIn := func(t *testing.T, state State) int {
+		t.Helper()
+		n, err := client.StateMachine.Query().Where(statemachine.DefinitionName("bulk"), statemachine.CurrentState(string(state))).Count(ctx)
+		if err != nil {
+			t.Fatalf("Failed to count machines: %v", err)
+		}
+		return n
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		report, err := m.BulkTransition(ctx, paused, EventStop, BulkOptions{DryRun: true, BatchSize: 2})
+		if err != nil {
+			t.Fatalf("BulkTransition failed: %v", err)
+		}
+		if report.Succeeded != 5 || report.Failed != 0 || len(report.Results) != 5 {
+			t.Errorf("Unexpected report %+v", report)
+		}
+		if r := report.Results[0]; r.MachineID != "bulk_machine_1" || r.From != StatePaused || r.To != StateStopped {
+			t.Errorf("Unexpected result %+v", r)
+		}
+		if n := countIn(t, StatePaused); n != 5 {
+			t.Errorf("Expected dry run to leave 5 machines paused, got %d", n)
+		}
+	})
+
+	t.Run("Guards are respected", func(t *testing.T) {
+		report, err := m.BulkTransition(ctx, paused, EventStop, BulkOptions{DryRun: true}, "hold")
+		if err != nil {
+			t.Fatalf("BulkTransition failed: %v", err)
+		}
+		if report.Failed != 5 || !errors.Is(report.Results[0].Err, ErrTransitionDenied) {
+			t.Errorf("Expected every machine to be denied, got %+v", report)
+		}
+	})
+
+	t.Run("Age", func(t *testing.T) {
+		report, err := m.BulkTransition(ctx, BulkSelector{Definition: "bulk", CreatedBefore: time.Now().Add(-time.Hour)}, EventStop, BulkOptions{DryRun: true})
+		if err != nil || len(report.Results) != 0 {
+			t.Errorf("Expected no machine older than an hour, got %+v, %v", report, err)
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		report, err := m.BulkTransition(ctx, BulkSelector{Definition: "bulk"}, EventStop, BulkOptions{BatchSize: 2, Concurrency: 2})
+		if err != nil {
+			t.Fatalf("BulkTransition failed: %v", err)
+		}
+		if report.Succeeded != 6 || report.Failed != 0 {
+			t.Errorf("Unexpected report %+v", report)
+		}
+		if n := countIn(t, StateStopped); n != 6 {
+			t.Errorf("Expected 6 stopped machines, got %d", n)
+		}
+		sm, _ := client.StateMachine.Query().Where(statemachine.MachineID("bulk_other")).Only(ctx)
+		if State(sm.CurrentState) != StatePaused {
+			t.Errorf("Expected machines of other definitions to be left alone")
+		}
+
+		report, err = m.BulkTransition(ctx, BulkSelector{Definition: "bulk"}, EventStop, BulkOptions{})
+		if err != nil {
+			t.Fatalf("BulkTransition failed: %v", err)
+		}
+		if report.Failed != 6 || !errors.Is(report.Results[5].Err, ErrInvalidTransition) {
+			t.Errorf("Expected every stopped machine to fail, got %+v", report.Results)
+		}
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := m.BulkTransition(cancelled, paused, EventStop, BulkOptions{}); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
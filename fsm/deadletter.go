@@ -0,0 +1,170 @@
+package fsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/deadletter"
+)
+
+// retryingDeadLetter is the context key marking the retry of a dead letter, which the
+// DeadLetters middleware must not record again.
+type retryingDeadLetter struct{}
+
+// DeadLetters records every event whose dispatch fails in the dead_letters table of client,
+// with its arguments, machine ID, failing phase and error, then returns the error unchanged.
+// Arguments are stored as JSON, so a retried event receives them as decoded JSON values.
+func DeadLetters(client *ent.Client) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) error {
+			err := next(ctx, req)
+			if err == nil || ctx.Value(retryingDeadLetter{}) != nil {
+				return err
+			}
+			if rerr := recordDeadLetter(context.WithoutCancel(ctx), client, req, err); rerr != nil {
+				return errors.Join(err, rerr)
+			}
+			return err
+		}
+	}
+}
+
+// recordDeadLetter stores a failed event.
+func recordDeadLetter(ctx context.Context, client *ent.Client, req Request, cause error) error {
+	args, err := json.Marshal(req.Args)
+	if err != nil {
+		return fmt.Errorf("failed to encode arguments of dead letter: %w", err)
+	}
+	key, _ := IdempotencyKeyFromContext(ctx)
+	err = client.DeadLetter.Create().
+		SetMachineID(req.Machine.machineID).
+		SetEvent(string(req.Event)).
+		SetArgs(args).
+		SetIdempotencyKey(key).
+		SetPhase(string(failedPhase(cause))).
+		SetError(cause.Error()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record dead letter: %w", err)
+	}
+	return nil
+}
+
+// failedPhase returns the phase in which err occurred, or "" if it did not come from a transition.
+func failedPhase(err error) Phase {
+	var terr *TransitionError
+	if errors.As(err, &terr) {
+		return terr.Phase
+	}
+	return ""
+}
+
+// DeadLetterFilter selects dead letters. Zero fields match everything.
+type DeadLetterFilter struct {
+	MachineID string
+	Event     Event
+	Phase     Phase
+	Status    deadletter.Status
+	Limit     int
+}
+
+// ListDeadLetters returns the dead letters matching filter, oldest first.
+func (m *Manager) ListDeadLetters(ctx context.Context, filter DeadLetterFilter) ([]*ent.DeadLetter, error) {
+	query := m.client.DeadLetter.Query().Order(ent.Asc(deadletter.FieldID))
+	if filter.MachineID != "" {
+		query = query.Where(deadletter.MachineID(filter.MachineID))
+	}
+	if filter.Event != "" {
+		query = query.Where(deadletter.Event(string(filter.Event)))
+	}
+	if filter.Phase != "" {
+		query = query.Where(deadletter.Phase(string(filter.Phase)))
+	}
+	if filter.Status != "" {
+		query = query.Where(deadletter.StatusEQ(filter.Status))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	letters, err := query.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	return letters, nil
+}
+
+// GetDeadLetter returns the dead letter with the given ID.
+func (m *Manager) GetDeadLetter(ctx context.Context, id int) (*ent.DeadLetter, error) {
+	dl, err := m.client.DeadLetter.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letter %d: %w", id, err)
+	}
+	return dl, nil
+}
+
+// RetryDeadLetters dispatches the pending dead letters with the given IDs again, in ID order, to
+// machines loaded through the manager. Dead letters that succeed are marked resolved; the others
+// stay pending with their attempt count, phase and error updated. It returns the number resolved.
+func (m *Manager) RetryDeadLetters(ctx context.Context, ids ...int) (int, error) {
+	letters, err := m.client.DeadLetter.Query().
+		Where(deadletter.IDIn(ids...), deadletter.StatusEQ(deadletter.StatusPending)).
+		Order(ent.Asc(deadletter.FieldID)).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+
+	resolved := 0
+	var errs []error
+	for _, dl := range letters {
+		update := dl.Update()
+		if rerr := m.retryDeadLetter(ctx, dl); rerr != nil {
+			errs = append(errs, fmt.Errorf("dead letter %d: %w", dl.ID, rerr))
+			update.AddAttempts(1).SetPhase(string(failedPhase(rerr))).SetError(rerr.Error())
+		} else {
+			update.SetStatus(deadletter.StatusResolved)
+			resolved++
+		}
+		if err := update.Exec(ctx); err != nil {
+			return resolved, errors.Join(append(errs, fmt.Errorf("failed to update dead letter %d: %w", dl.ID, err))...)
+		}
+	}
+	return resolved, errors.Join(errs...)
+}
+
+// retryDeadLetter dispatches a dead letter with its recorded arguments and idempotency key.
+func (m *Manager) retryDeadLetter(ctx context.Context, dl *ent.DeadLetter) error {
+	var args []interface{}
+	if len(dl.Args) > 0 {
+		if err := json.Unmarshal(dl.Args, &args); err != nil {
+			return fmt.Errorf("failed to decode arguments: %w", err)
+		}
+	}
+	f, err := m.LoadFSM(ctx, dl.MachineID)
+	if err != nil {
+		return err
+	}
+
+	ctx = context.WithValue(ctx, retryingDeadLetter{}, dl.ID)
+	if dl.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, dl.IdempotencyKey)
+	}
+	return f.Transition(ctx, Event(dl.Event), args...)
+}
+
+// DiscardDeadLetters marks the pending dead letters with the given IDs as discarded and returns
+// the number discarded.
+func (m *Manager) DiscardDeadLetters(ctx context.Context, ids ...int) (int, error) {
+	n, err := m.client.DeadLetter.Update().
+		Where(deadletter.IDIn(ids...), deadletter.StatusEQ(deadletter.StatusPending)).
+		SetStatus(deadletter.StatusDiscarded).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to discard dead letters: %w", err)
+	}
+	return n, nil
+}
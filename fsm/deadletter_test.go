@@ -0,0 +1,116 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent/deadletter"
+)
+
+func TestDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	open := false
+	var received []interface{}
+	def := &Definition{
+		Name:        "deadletters",
+		Initial:     StateIdle,
+		Transitions: defineTestTransitions(),
+		Setup: func(f *FSM) error {
+			f.AddCheck(StateIdle, EventStart, "open", func(ctx context.Context, args ...interface{}) error {
+				if !open {
+					return errors.New("closed")
+				}
+				return nil
+			})
+			f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+				received = args
+				return nil
+			})
+			return nil
+		},
+	}
+	m := NewManager(client)
+	m.Use(DeadLetters(client))
+	if err := m.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	f, err := m.NewFSM(ctx, "deadletters", "dead_letter_machine")
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	if err := f.Transition(ctx, EventStart, "order-1"); !errors.Is(err, ErrTransitionDenied) {
+		t.Fatalf("Expected ErrTransitionDenied, got %v", err)
+	}
+	if err := f.Transition(ctx, EventStop); !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("Expected ErrInvalidEvent, got %v", err)
+	}
+
+	letters, err := m.ListDeadLetters(ctx, DeadLetterFilter{MachineID: "dead_letter_machine"})
+	if err != nil {
+		t.Fatalf("ListDeadLetters failed: %v", err)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("Expected 2 dead letters, got %d", len(letters))
+	}
+	denied := letters[0]
+	if denied.Event != string(EventStart) || denied.Phase != string(PhaseGuard) || string(denied.Args) != `["order-1"]` || denied.Attempts != 1 {
+		t.Errorf("Unexpected dead letter %+v", denied)
+	}
+
+	t.Run("Filters", func(t *testing.T) {
+		letters, err := m.ListDeadLetters(ctx, DeadLetterFilter{Phase: PhaseValidate})
+		if err != nil || len(letters) != 1 || letters[0].Event != string(EventStop) {
+			t.Errorf("Expected the invalid event only, got %v, %v", letters, err)
+		}
+		got, err := m.GetDeadLetter(ctx, denied.ID)
+		if err != nil || got.Error != denied.Error {
+			t.Errorf("GetDeadLetter returned %v, %v", got, err)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		resolved, err := m.RetryDeadLetters(ctx, denied.ID)
+		if resolved != 0 || !errors.Is(err, ErrTransitionDenied) {
+			t.Fatalf("Expected retry to be denied, got %d, %v", resolved, err)
+		}
+		dl, _ := m.GetDeadLetter(ctx, denied.ID)
+		if dl.Attempts != 2 || dl.Status != deadletter.StatusPending {
+			t.Errorf("Expected pending dead letter after 2 attempts, got %s after %d", dl.Status, dl.Attempts)
+		}
+
+		open = true
+		resolved, err = m.RetryDeadLetters(ctx, denied.ID)
+		if err != nil || resolved != 1 {
+			t.Fatalf("Expected dead letter to be resolved, got %d, %v", resolved, err)
+		}
+		if dl, _ := m.GetDeadLetter(ctx, denied.ID); dl.Status != deadletter.StatusResolved {
+			t.Errorf("Expected resolved dead letter, got %s", dl.Status)
+		}
+		if !reflect.DeepEqual(received, []interface{}{"order-1"}) {
+			t.Errorf("Expected recorded arguments, got %v", received)
+		}
+		if n, _ := client.DeadLetter.Query().Count(ctx); n != 2 {
+			t.Errorf("Expected retries not to record new dead letters, got %d", n)
+		}
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		pending, err := m.ListDeadLetters(ctx, DeadLetterFilter{Status: deadletter.StatusPending})
+		if err != nil || len(pending) != 1 {
+			t.Fatalf("Expected 1 pending dead letter, got %v, %v", pending, err)
+		}
+		n, err := m.DiscardDeadLetters(ctx, pending[0].ID, denied.ID)
+		if err != nil || n != 1 {
+			t.Fatalf("Expected 1 dead letter discarded, got %d, %v", n, err)
+		}
+		if n, _ := m.RetryDeadLetters(ctx, pending[0].ID); n != 0 {
+			t.Errorf("Expected discarded dead letters not to be retried")
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package fsm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Definition describes the structure of a state machine independently of any running instance.
+// It can be built in code or decoded from an interchange format such as SCXML.
+type Definition struct {
+	Name        string       // Optional name identifying the workflow
+	Version     int          // Version of the workflow, recorded on persisted machines
+	Initial     State        // State a new machine starts in
+	States      []State      // Declared states in declaration order; derived from Transitions when empty
+	Final       []State      // States in which the machine is considered complete
+	Transitions []Transition // Transition rules
+
+	// Setup, if set, is called on every FSM built from the definition to register its
+	// actions, guards and listeners.
+	Setup func(f *FSM) error
+	// Middlewares wrap every event dispatched to machines built from the definition.
+	Middlewares []Middleware
+	// SLAs limit how long machines may stay in a state, see Manager.ListSLABreaches.
+	SLAs []SLA
+}
+
+// AllStates returns every state of the definition in a stable order.
+// Declared states come first, followed by any state only referenced by Initial, Final or a transition.
+func (d *Definition) AllStates() []State {
+	seen := make(map[State]bool)
+	var states []State
+	add := func(s State) {
+		if s != "" && !seen[s] {
+			seen[s] = true
+			states = append(states, s)
+		}
+	}
+
+	for _, s := range d.States {
+		add(s)
+	}
+	add(d.Initial)
+	for _, t := range d.Transitions {
+		add(t.From)
+		add(t.To)
+	}
+	for _, s := range d.Final {
+		add(s)
+	}
+	return states
+}
+
+// HasState reports whether the state is part of the definition.
+func (d *Definition) HasState(state State) bool {
+	for _, s := range d.AllStates() {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether the state is declared as final.
+func (d *Definition) IsFinal(state State) bool {
+	for _, s := range d.Final {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the definition for structural errors such as duplicate transitions
+// or transitions referencing undeclared states.
+func (d *Definition) Validate() error {
+	declared := make(map[State]bool)
+	for _, s := range d.States {
+		if s == "" {
+			return errors.New("state names must not be empty")
+		}
+		if declared[s] {
+			return fmt.Errorf("duplicate state %s", s)
+		}
+		declared[s] = true
+	}
+	checkDeclared := func(s State) error {
+		if len(d.States) > 0 && !declared[s] {
+			return fmt.Errorf("state %s is not declared", s)
+		}
+		return nil
+	}
+
+	if d.Initial != "" {
+		if err := checkDeclared(d.Initial); err != nil {
+			return fmt.Errorf("initial %w", err)
+		}
+	}
+	for _, s := range d.Final {
+		if err := checkDeclared(s); err != nil {
+			return fmt.Errorf("final %w", err)
+		}
+	}
+
+	seen := make(map[State]map[Event]bool)
+	for _, t := range d.Transitions {
+		if _, ok := seen[t.From]; !ok {
+			seen[t.From] = make(map[Event]bool)
+		}
+		if seen[t.From][t.Event] {
+			return fmt.Errorf("duplicate transition defined from state %s for event %s", t.From, t.Event)
+		}
+		seen[t.From][t.Event] = true
+
+		if err := checkDeclared(t.From); err != nil {
+			return fmt.Errorf("transition source %w", err)
+		}
+		if err := checkDeclared(t.To); err != nil {
+			return fmt.Errorf("transition target %w", err)
+		}
+	}
+
+	limited := make(map[State]bool)
+	for _, sla := range d.SLAs {
+		switch {
+		case !d.HasState(sla.State):
+			return fmt.Errorf("SLA state %s is not part of the definition", sla.State)
+		case limited[sla.State]:
+			return fmt.Errorf("duplicate SLA for state %s", sla.State)
+		case sla.Within <= 0:
+			return fmt.Errorf("SLA for state %s must have a positive duration", sla.State)
+		case sla.Event != "" && !seen[sla.State][sla.Event]:
+			return fmt.Errorf("SLA event %s is not defined from state %s", sla.Event, sla.State)
+		}
+		limited[sla.State] = true
+	}
+	return nil
+}
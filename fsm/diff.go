@@ -0,0 +1,164 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+// TargetChange describes a transition whose target state differs between two definitions.
+type TargetChange struct {
+	From  State
+	Event Event
+	OldTo State
+	NewTo State
+}
+
+// DefinitionDiff is the structural difference between two definitions.
+type DefinitionDiff struct {
+	AddedStates        []State
+	RemovedStates      []State
+	AddedTransitions   []Transition
+	RemovedTransitions []Transition
+	ChangedTargets     []TargetChange
+	// DeadStates are states kept by the new definition that can no longer be left:
+	// they are not final and have no outgoing transitions, although they had some before.
+	DeadStates []State
+}
+
+// Diff compares two definitions and reports what next adds, removes and changes relative to old.
+func Diff(old, next *Definition) *DefinitionDiff {
+	d := &DefinitionDiff{}
+
+	oldStates := stateSet(old.AllStates())
+	newStates := stateSet(next.AllStates())
+	for _, s := range next.AllStates() {
+		if !oldStates[s] {
+			d.AddedStates = append(d.AddedStates, s)
+		}
+	}
+	for _, s := range old.AllStates() {
+		if !newStates[s] {
+			d.RemovedStates = append(d.RemovedStates, s)
+		}
+	}
+
+	oldTargets := transitionTargets(old)
+	newTargets := transitionTargets(next)
+	for _, t := range next.Transitions {
+		oldTo, ok := oldTargets[t.From][t.Event]
+		switch {
+		case !ok:
+			d.AddedTransitions = append(d.AddedTransitions, t)
+		case oldTo != t.To:
+			d.ChangedTargets = append(d.ChangedTargets, TargetChange{From: t.From, Event: t.Event, OldTo: oldTo, NewTo: t.To})
+		}
+	}
+	for _, t := range old.Transitions {
+		if _, ok := newTargets[t.From][t.Event]; !ok {
+			d.RemovedTransitions = append(d.RemovedTransitions, t)
+		}
+	}
+
+	for _, s := range next.AllStates() {
+		if oldStates[s] && isDead(next, newTargets, s) && !isDead(old, oldTargets, s) {
+			d.DeadStates = append(d.DeadStates, s)
+		}
+	}
+	return d
+}
+
+// Empty reports whether the two definitions are structurally identical.
+func (d *DefinitionDiff) Empty() bool {
+	return len(d.AddedStates) == 0 && len(d.RemovedStates) == 0 &&
+		len(d.AddedTransitions) == 0 && len(d.RemovedTransitions) == 0 &&
+		len(d.ChangedTargets) == 0 && len(d.DeadStates) == 0
+}
+
+// stateSet converts a list of states into a lookup set.
+func stateSet(states []State) map[State]bool {
+	set := make(map[State]bool, len(states))
+	for _, s := range states {
+		set[s] = true
+	}
+	return set
+}
+
+// transitionTargets indexes the transitions of a definition by source state and event.
+func transitionTargets(def *Definition) map[State]map[Event]State {
+	targets := make(map[State]map[Event]State)
+	for _, t := range def.Transitions {
+		if _, ok := targets[t.From]; !ok {
+			targets[t.From] = make(map[Event]State)
+		}
+		targets[t.From][t.Event] = t.To
+	}
+	return targets
+}
+
+// isDead reports whether a machine in the state could never leave it.
+func isDead(def *Definition, targets map[State]map[Event]State, s State) bool {
+	return !def.IsFinal(s) && len(targets[s]) == 0
+}
+
+// Impact counts live machines affected by a definition change.
+type Impact struct {
+	Machines int           // Total number of affected machines
+	ByState  map[State]int // Affected machines per removed or dead state
+}
+
+// Impact queries the machines recorded under the old definition and counts those that sit
+// in a state the new definition removes or turns into a dead end.
+func (d *DefinitionDiff) Impact(ctx context.Context, client *ent.Client, old *Definition) (*Impact, error) {
+	affected := make([]string, 0, len(d.RemovedStates)+len(d.DeadStates))
+	for _, s := range d.RemovedStates {
+		affected = append(affected, string(s))
+	}
+	for _, s := range d.DeadStates {
+		affected = append(affected, string(s))
+	}
+
+	impact := &Impact{ByState: make(map[State]int)}
+	if len(affected) == 0 {
+		return impact, nil
+	}
+
+	query := client.StateMachine.Query().
+		Where(
+			statemachine.DefinitionName(old.Name),
+			statemachine.CurrentStateIn(affected...),
+		)
+	if old.Name != "" {
+		query = query.Where(statemachine.DefinitionVersion(old.Version))
+	}
+
+	var rows []struct {
+		CurrentState string `json:"current_state"`
+		Count        int    `json:"count"`
+	}
+	err := query.GroupBy(statemachine.FieldCurrentState).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count affected machines: %w", err)
+	}
+
+	for _, row := range rows {
+		impact.ByState[State(row.CurrentState)] = row.Count
+		impact.Machines += row.Count
+	}
+	return impact, nil
+}
+
+// States returns the affected states in a stable order.
+func (i *Impact) States() []State {
+	states := make([]State, 0, len(i.ByState))
+	for s := range i.ByState {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(a, b int) bool { return states[a] < states[b] })
+	return states
+}
@@ -0,0 +1,99 @@
+package fsm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	v1, v2 := defineVersionedDefinitions()
+
+	t.Run("Identical definitions", func(t *testing.T) {
+		if d := Diff(v1, v1); !d.Empty() {
+			t.Errorf("Expected empty diff, got %+v", d)
+		}
+	})
+
+	t.Run("Removed states and transitions", func(t *testing.T) {
+		d := Diff(v1, v2)
+		if !reflect.DeepEqual(d.RemovedStates, []State{StatePaused}) {
+			t.Errorf("Expected removed states [%s], got %v", StatePaused, d.RemovedStates)
+		}
+		expectedRemoved := []Transition{
+			{From: StateRunning, Event: EventPause, To: StatePaused},
+			{From: StatePaused, Event: EventResume, To: StateRunning},
+			{From: StatePaused, Event: EventStop, To: StateStopped},
+		}
+		if !reflect.DeepEqual(d.RemovedTransitions, expectedRemoved) {
+			t.Errorf("Expected removed transitions %v, got %v", expectedRemoved, d.RemovedTransitions)
+		}
+		if len(d.AddedStates) != 0 || len(d.AddedTransitions) != 0 || len(d.ChangedTargets) != 0 {
+			t.Errorf("Expected no additions or changes, got %+v", d)
+		}
+	})
+
+	t.Run("Added states, changed targets and dead states", func(t *testing.T) {
+		next := &Definition{
+			Name:    "worker",
+			Version: 2,
+			Initial: StateIdle,
+			Transitions: []Transition{
+				{From: StateIdle, Event: EventStart, To: StateRunning},
+				{From: StateRunning, Event: EventPause, To: "suspended"},
+				{From: StateRunning, Event: EventStop, To: StateStopped},
+				{From: "suspended", Event: EventResume, To: StateRunning},
+			},
+		}
+
+		d := Diff(v1, next)
+		if !reflect.DeepEqual(d.AddedStates, []State{"suspended"}) {
+			t.Errorf("Expected added states [suspended], got %v", d.AddedStates)
+		}
+		expectedChanged := []TargetChange{{From: StateRunning, Event: EventPause, OldTo: StatePaused, NewTo: "suspended"}}
+		if !reflect.DeepEqual(d.ChangedTargets, expectedChanged) {
+			t.Errorf("Expected changed targets %v, got %v", expectedChanged, d.ChangedTargets)
+		}
+
+		// StatePaused is still referenced nowhere, so it is removed rather than dead.
+		next.States = append(next.AllStates(), StatePaused)
+		d = Diff(v1, next)
+		if !reflect.DeepEqual(d.DeadStates, []State{StatePaused}) {
+			t.Errorf("Expected dead states [%s], got %v", StatePaused, d.DeadStates)
+		}
+	})
+}
+
+func TestDiffImpact(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+	v1, v2 := defineVersionedDefinitions()
+
+	for _, machineID := range []string{"impact_machine_1", "impact_machine_2", "impact_machine_3"} {
+		f, err := NewFSMFromDefinition(ctx, client, machineID, v1)
+		if err != nil {
+			t.Fatalf("NewFSMFromDefinition failed: %v", err)
+		}
+		if machineID == "impact_machine_3" {
+			continue // Stays idle
+		}
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventPause); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+	}
+
+	impact, err := Diff(v1, v2).Impact(ctx, client, v1)
+	if err != nil {
+		t.Fatalf("Impact failed: %v", err)
+	}
+	if impact.Machines != 2 || impact.ByState[StatePaused] != 2 {
+		t.Errorf("Expected 2 machines in %s, got %+v", StatePaused, impact)
+	}
+	if !reflect.DeepEqual(impact.States(), []State{StatePaused}) {
+		t.Errorf("Expected affected states [%s], got %v", StatePaused, impact.States())
+	}
+}
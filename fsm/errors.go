@@ -0,0 +1,64 @@
+package fsm
+
+import "fmt"
+
+// Phases in which a transition can fail, reported by TransitionError together with PhaseExit
+// and PhaseEntry.
+const (
+	// PhaseValidate is the lookup of the transition triggered by the event.
+	PhaseValidate Phase = "validate"
+	// PhaseGuard is the evaluation of the guards of the transition.
+	PhaseGuard Phase = "guard"
+	// PhaseCallback is the execution of the transition callbacks.
+	PhaseCallback Phase = "callback"
+	// PhasePersist is the commit of the new state and its history record.
+	PhasePersist Phase = "persist"
+)
+
+// TransitionError is returned by Transition when an event cannot be applied.
+// Err is the underlying cause: ErrInvalidTransition or ErrInvalidEvent for PhaseValidate,
+// a *GuardError for PhaseGuard, or the error returned by an action or the database.
+type TransitionError struct {
+	MachineID string
+	From      State
+	Event     Event
+	To        State // Empty for PhaseValidate
+	Phase     Phase
+	Err       error
+	// Compensation holds the errors returned by compensation actions run after the failure.
+	Compensation error
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	if e.Compensation != nil {
+		return fmt.Sprintf("%s; compensation failed: %v", e.message(), e.Compensation)
+	}
+	return e.message()
+}
+
+// message describes the failure without the compensation errors.
+func (e *TransitionError) message() string {
+	switch e.Phase {
+	case PhaseExit:
+		return fmt.Sprintf("exit action failed for state %s: %v", e.From, e.Err)
+	case PhaseCallback:
+		return fmt.Sprintf("transition callback failed for event %s from state %s: %v", e.Event, e.From, e.Err)
+	case PhaseEntry:
+		return fmt.Sprintf("entry action failed for state %s: %v", e.To, e.Err)
+	case PhasePersist:
+		return fmt.Sprintf("failed to persist state and history: %v", e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+// Unwrap returns the underlying cause.
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// transitionError wraps err for a transition from the current state. f.mu must be held.
+func (f *FSM) transitionError(phase Phase, event Event, to State, err error) *TransitionError {
+	return &TransitionError{MachineID: f.machineID, From: f.currentState, Event: event, To: to, Phase: phase, Err: err}
+}
@@ -0,0 +1,103 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTransitionError(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	errAction := errors.New("action error")
+	failing := func(ctx context.Context, args ...interface{}) error { return errAction }
+
+	tests := []struct {
+		name     string
+		setup    func(f *FSM)
+		event    Event
+		phase    Phase
+		to       State
+		sentinel error
+		message  string
+	}{
+		{
+			name:     "Validate",
+			event:    EventStop,
+			phase:    PhaseValidate,
+			sentinel: ErrInvalidEvent,
+			message:  "invalid event: no transition defined for event stop from state idle",
+		},
+		{
+			name: "Guard",
+			setup: func(f *FSM) {
+				f.AddCheck(StateIdle, EventStart, "credit", func(ctx context.Context, args ...interface{}) error { return errAction })
+			},
+			event:    EventStart,
+			phase:    PhaseGuard,
+			to:       StateRunning,
+			sentinel: ErrTransitionDenied,
+			message:  "transition denied by guard: event start from state idle: credit: action error",
+		},
+		{
+			name:     "Exit",
+			setup:    func(f *FSM) { f.OnExit(StateIdle, failing) },
+			event:    EventStart,
+			phase:    PhaseExit,
+			to:       StateRunning,
+			sentinel: errAction,
+			message:  "exit action failed for state idle: action error",
+		},
+		{
+			name:     "Callback",
+			setup:    func(f *FSM) { f.OnTransition(StateIdle, EventStart, failing) },
+			event:    EventStart,
+			phase:    PhaseCallback,
+			to:       StateRunning,
+			sentinel: errAction,
+			message:  "transition callback failed for event start from state idle: action error",
+		},
+		{
+			name:     "Entry",
+			setup:    func(f *FSM) { f.OnEntry(StateRunning, failing) },
+			event:    EventStart,
+			phase:    PhaseEntry,
+			to:       StateRunning,
+			sentinel: errAction,
+			message:  "entry action failed for state running: action error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineID := "errors_machine_" + tt.name
+			f, err := NewFSM(ctx, client, machineID, StateIdle, defineTestTransitions())
+			if err != nil {
+				t.Fatalf("NewFSM failed: %v", err)
+			}
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			err = f.Transition(ctx, tt.event)
+			var terr *TransitionError
+			if !errors.As(err, &terr) {
+				t.Fatalf("Expected *TransitionError, got %T: %v", err, err)
+			}
+			if terr.Phase != tt.phase || terr.From != StateIdle || terr.Event != tt.event || terr.To != tt.to {
+				t.Errorf("Unexpected error fields: %+v", terr)
+			}
+			if terr.MachineID != machineID {
+				t.Errorf("Expected machine ID %s, got %q", machineID, terr.MachineID)
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("Expected error to match %v, got %v", tt.sentinel, err)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("Expected error message '%s', got '%s'", tt.message, err.Error())
+			}
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"sync" // Import the sync package for mutex
+	"time"
 
 	"github.com/shinhauhuang/go-fsm/ent"              // Import the generated Ent client
 	"github.com/shinhauhuang/go-fsm/ent/statemachine" // Import statemachine query
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
 )
 
 var (
@@ -17,6 +19,8 @@ var (
 	ErrInvalidTransition = errors.New("invalid transition")
 	// ErrInvalidEvent is returned for an invalid event.
 	ErrInvalidEvent = errors.New("invalid event")
+	// ErrIncompatibleDefinition is returned when a persisted machine does not match the definition used to load it.
+	ErrIncompatibleDefinition = errors.New("incompatible definition")
 )
 
 // State represents a state in the FSM.
@@ -45,29 +49,38 @@ type FSM struct {
 	mu                  sync.RWMutex // Mutex to ensure thread safety
 	client              *ent.Client  // Ent client for persistence
 	machineID           string       // Unique ID for this FSM instance
+	definition          *Definition  // Static structure the FSM was built from
 	currentState        State
+	enteredAt           time.Time // When the current state was entered
+	suspended           bool      // Last known suspension of the machine, see Suspend
 	transitions         map[State]map[Event]State
-	entryActions        map[State]Action
-	exitActions         map[State]Action
-	guards              map[State]map[Event]Guard
-	transitionCallbacks map[State]map[Event]Action
+	entryActions        map[State][]*hook
+	exitActions         map[State][]*hook
+	anyEntryActions     []*hook
+	anyExitActions      []*hook
+	guards              map[State]map[Event][]namedCheck
+	transitionCallbacks map[State]map[Event][]*hook
+	nextHookID          uint64
+	listeners           listenerSet  // Listeners notified by this instance only
+	middlewares         []Middleware // Middlewares added with Use
+	outerMiddlewares    []Middleware // Middlewares of the Manager that built the FSM
 }
 
 // NewFSM creates a new FSM with an initial state, a list of transitions, and an Ent client for persistence.
 // It will try to load the state from the database if a machineID is provided.
 func NewFSM(ctx context.Context, client *ent.Client, machineID string, initialState State, transitions []Transition) (*FSM, error) {
-	fsm := &FSM{
-		client:              client,
-		machineID:           machineID,
-		currentState:        initialState,
-		transitions:         make(map[State]map[Event]State),
-		entryActions:        make(map[State]Action),
-		exitActions:         make(map[State]Action),
-		guards:              make(map[State]map[Event]Guard),
-		transitionCallbacks: make(map[State]map[Event]Action),
+	return NewFSMFromDefinition(ctx, client, machineID, &Definition{Initial: initialState, Transitions: transitions})
+}
+
+// NewFSMFromDefinition creates a new FSM from a Definition and an Ent client for persistence.
+// Like NewFSM, it will try to load the state from the database if a machineID is provided.
+func NewFSMFromDefinition(ctx context.Context, client *ent.Client, machineID string, def *Definition) (*FSM, error) {
+	if err := def.Validate(); err != nil {
+		return nil, err
 	}
 
-	if err := initFSMTransitions(fsm, transitions); err != nil {
+	fsm := newFSM(client, machineID, def.Initial, def)
+	if err := initFSMTransitions(fsm, def.Transitions); err != nil {
 		return nil, err
 	}
 
@@ -79,7 +92,9 @@ func NewFSM(ctx context.Context, client *ent.Client, machineID string, initialSt
 				// If not found, create a new entry
 				_, err := client.StateMachine.Create().
 					SetMachineID(machineID).
-					SetCurrentState(string(initialState)).
+					SetCurrentState(string(def.Initial)).
+					SetDefinitionName(def.Name).
+					SetDefinitionVersion(def.Version).
 					Save(ctx)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create new state machine entry: %w", err)
@@ -88,13 +103,53 @@ func NewFSM(ctx context.Context, client *ent.Client, machineID string, initialSt
 				return nil, fmt.Errorf("failed to query state machine: %w", err)
 			}
 		} else {
+			if err := checkCompatible(sm, def); err != nil {
+				return nil, err
+			}
+			if err := adopt(ctx, client, sm, def); err != nil {
+				return nil, err
+			}
 			fsm.currentState = State(sm.CurrentState)
+			if fsm.enteredAt, err = stateEnteredAt(ctx, client, sm); err != nil {
+				return nil, err
+			}
+			fsm.suspended = sm.Suspended
 		}
 	}
 
+	if err := fsm.setup(); err != nil {
+		return nil, err
+	}
 	return fsm, nil
 }
 
+// newFSM allocates an FSM with empty hook registries.
+func newFSM(client *ent.Client, machineID string, state State, def *Definition) *FSM {
+	return &FSM{
+		client:              client,
+		machineID:           machineID,
+		definition:          def,
+		currentState:        state,
+		enteredAt:           time.Now(),
+		transitions:         make(map[State]map[Event]State),
+		entryActions:        make(map[State][]*hook),
+		exitActions:         make(map[State][]*hook),
+		guards:              make(map[State]map[Event][]namedCheck),
+		transitionCallbacks: make(map[State]map[Event][]*hook),
+	}
+}
+
+// setup runs the definition's Setup function, if any, on a newly built FSM.
+func (f *FSM) setup() error {
+	if f.definition.Setup == nil {
+		return nil
+	}
+	if err := f.definition.Setup(f); err != nil {
+		return fmt.Errorf("definition setup failed: %w", err)
+	}
+	return nil
+}
+
 // initFSMTransitions initializes the FSM's transitions map and performs duplicate transition checks.
 func initFSMTransitions(fsm *FSM, transitions []Transition) error {
 	for _, t := range transitions {
@@ -112,6 +167,12 @@ func initFSMTransitions(fsm *FSM, transitions []Transition) error {
 // LoadFSM loads an existing FSM from the database.
 // It requires the machineID and the set of transitions that define the FSM's behavior.
 func LoadFSM(ctx context.Context, client *ent.Client, machineID string, transitions []Transition) (*FSM, error) {
+	return LoadFSMFromDefinition(ctx, client, machineID, &Definition{Transitions: transitions})
+}
+
+// LoadFSMFromDefinition loads an existing FSM from the database using a Definition.
+// It returns an error if the machine does not exist.
+func LoadFSMFromDefinition(ctx context.Context, client *ent.Client, machineID string, def *Definition) (*FSM, error) {
 	if client == nil || machineID == "" {
 		return nil, errors.New("client and machineID are required to load an FSM")
 	}
@@ -121,21 +182,28 @@ func LoadFSM(ctx context.Context, client *ent.Client, machineID string, transiti
 		return nil, fmt.Errorf("failed to query state machine with ID %s: %w", machineID, err)
 	}
 
-	fsm := &FSM{
-		client:              client,
-		machineID:           machineID,
-		currentState:        State(sm.CurrentState), // Load current state from DB
-		transitions:         make(map[State]map[Event]State),
-		entryActions:        make(map[State]Action),
-		exitActions:         make(map[State]Action),
-		guards:              make(map[State]map[Event]Guard),
-		transitionCallbacks: make(map[State]map[Event]Action),
+	if err := def.Validate(); err != nil {
+		return nil, fmt.Errorf("%w during FSM loading", err)
+	}
+	if err := checkCompatible(sm, def); err != nil {
+		return nil, err
+	}
+	if err := adopt(ctx, client, sm, def); err != nil {
+		return nil, err
 	}
 
-	if err := initFSMTransitions(fsm, transitions); err != nil {
+	fsm := newFSM(client, machineID, State(sm.CurrentState), def) // Load current state from DB
+	if fsm.enteredAt, err = stateEnteredAt(ctx, client, sm); err != nil {
+		return nil, err
+	}
+	fsm.suspended = sm.Suspended
+	if err := initFSMTransitions(fsm, def.Transitions); err != nil {
 		return nil, fmt.Errorf("%w during FSM loading", err)
 	}
 
+	if err := fsm.setup(); err != nil {
+		return nil, err
+	}
 	return fsm, nil
 }
 
@@ -146,92 +214,282 @@ func (f *FSM) CurrentState() State {
 	return f.currentState
 }
 
+// Definition returns the definition the FSM was built from.
+// The returned value must not be modified.
+func (f *FSM) Definition() *Definition {
+	return f.definition
+}
+
+// IsFinal reports whether the FSM is in one of its definition's final states.
+func (f *FSM) IsFinal() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.definition.IsFinal(f.currentState)
+}
+
 // Transition attempts to transition the FSM to a new state based on an event.
+// The event passes through the middlewares of the manager, of the definition and those added
+// with Use, in that order, before it is applied.
+//
+// For persistent machines, exit actions, transition callbacks, entry actions and the state update
+// share one database transaction, available to actions through ent.TxFromContext. It commits only
+// when every step succeeds. If ctx already carries a transaction, the transition joins it and
+// leaves the commit to the caller; the caller must reload the machine if it rolls back.
+//
+// See WithIdempotencyKey to apply redelivered events only once, and Suspend to stop a machine
+// from accepting events.
 func (f *FSM) Transition(ctx context.Context, event Event, args ...interface{}) error {
+	f.mu.RLock()
+	middlewares := make([]Middleware, 0, len(f.outerMiddlewares)+len(f.definition.Middlewares)+len(f.middlewares))
+	middlewares = append(middlewares, f.outerMiddlewares...)
+	middlewares = append(middlewares, f.definition.Middlewares...)
+	middlewares = append(middlewares, f.middlewares...)
+	f.mu.RUnlock()
+
+	if len(middlewares) == 0 {
+		return f.dispatch(ctx, event, args...)
+	}
+	return Chain(middlewares...)(dispatchHandler)(ctx, Request{Machine: f, Event: event, Args: args})
+}
+
+// dispatch applies an event to the FSM, bypassing the middleware chain.
+func (f *FSM) dispatch(ctx context.Context, event Event, args ...interface{}) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	nextStates, ok := f.transitions[f.currentState]
-	if !ok {
-		return fmt.Errorf("%w: no transitions defined from state %s", ErrInvalidTransition, f.currentState)
-	}
+	start := time.Now()
+	from := f.currentState
+	defer func() {
+		// Never leave the machine in a half-applied state if an action panics
+		if r := recover(); r != nil {
+			f.currentState = from
+			panic(r)
+		}
+	}()
 
-	nextState, ok := nextStates[event]
-	if !ok {
-		return fmt.Errorf("%w: no transition defined for event %s from state %s", ErrInvalidEvent, event, f.currentState)
+	to, err := f.transition(ctx, event, args...)
+	if err != nil {
+		f.notify(ctx, Notification{Phase: PhaseFailed, Event: event, From: from, To: to, Args: args, Duration: time.Since(start), Err: err})
 	}
+	return err
+}
 
-	// Check guard if registered
-	if guardsForState, ok := f.guards[f.currentState]; ok {
-		if guard, ok := guardsForState[event]; ok {
-			if !guard(ctx, args...) {
-				return ErrTransitionDenied
+// transition performs a transition with f.mu held. It returns the target state once it is known.
+func (f *FSM) transition(ctx context.Context, event Event, args ...interface{}) (State, error) {
+	// Open the transaction shared by checks, actions and persistence, and lock the machine in it
+	// so that concurrent transitions of the same machine in other instances wait for this one.
+	// It is rolled back unless committed.
+	var tx *ent.Tx
+	var err error
+	owned := false
+	baseCtx := ctx
+	if f.client != nil && f.machineID != "" {
+		if tx = ent.TxFromContext(ctx); tx == nil {
+			if tx, err = f.client.Tx(ctx); err != nil {
+				return "", f.transitionError(PhasePersist, event, "", fmt.Errorf("failed to start transaction: %w", err))
 			}
+			owned = true
+			ctx = ent.NewTxContext(ctx, tx)
+		}
+	}
+	finished := false
+	defer func() {
+		if owned && !finished {
+			tx.Rollback()
+		}
+	}()
+	if tx != nil {
+		if err := f.lockMachine(ctx, tx); err != nil {
+			return "", f.transitionError(PhasePersist, event, "", err)
 		}
 	}
 
-	// Execute exit action of the current state
-	if exitAction, ok := f.exitActions[f.currentState]; ok {
-		if err := exitAction(ctx, args...); err != nil {
-			return fmt.Errorf("exit action failed for state %s: %w", f.currentState, err)
+	// Return the outcome of the first call for a duplicate idempotency key
+	processed, err := f.processedEvent(ctx)
+	if err != nil {
+		return "", f.transitionError(PhaseValidate, event, "", err)
+	}
+	if processed != nil {
+		if Event(processed.Event) != event {
+			return "", f.transitionError(PhaseValidate, event, "",
+				fmt.Errorf("%w: key %s was applied to event %s", ErrIdempotencyKeyReused, processed.Key, processed.Event))
 		}
+		return State(processed.ToState), nil
 	}
 
-	// Execute transition callback if registered
-	if callbacksForState, ok := f.transitionCallbacks[f.currentState]; ok {
-		if callback, ok := callbacksForState[event]; ok {
-			if err := callback(ctx, args...); err != nil {
-				return fmt.Errorf("transition callback failed for event %s from state %s: %w", event, f.currentState, err)
-			}
+	// Checked in the database before any guard or action runs, with the machine locked, so that a
+	// suspension by another process applies at once
+	if err := f.checkSuspended(ctx); err != nil {
+		if errors.Is(err, ErrSuspended) {
+			f.suspended = true
 		}
+		return "", f.transitionError(PhaseValidate, event, "", err)
+	}
+	f.suspended = false // Clear a suspension lifted by another process
+
+	nextState, err := f.target(event)
+	if err != nil {
+		return "", f.transitionError(PhaseValidate, event, "", err)
+	}
+
+	n := Notification{Event: event, From: f.currentState, To: nextState, Args: args}
+	report := func(phase Phase, started time.Time) {
+		n.Phase = phase
+		n.Duration = time.Since(started)
+		f.notify(ctx, n)
+	}
+
+	// Check guards if registered
+	started := time.Now()
+	report(PhaseBeforeGuard, started)
+	if err := f.checkGuards(ctx, f.currentState, event, nextState, args); err != nil {
+		terr := f.transitionError(PhaseGuard, event, nextState, err)
+		n.Err = terr
+		report(PhaseGuardRejected, started)
+		return nextState, terr
+	}
+
+	// abort rolls back the transaction, compensates the actions that completed and records the
+	// failed attempt. The in-memory state must already be reverted.
+	var done []*hook
+	abort := func(phase Phase, err error) (State, error) {
+		terr := f.transitionError(phase, event, nextState, err)
+		if owned {
+			tx.Rollback()
+			finished = true
+		}
+		compensated, cerr := compensate(baseCtx, done, args)
+		terr.Compensation = cerr
+		if rerr := f.recordFailure(baseCtx, tx, owned, terr, compensated); rerr != nil {
+			terr.Err = errors.Join(terr.Err, rerr)
+		}
+		return nextState, terr
+	}
+
+	// Execute exit actions of the current state
+	started = time.Now()
+	if err := runHooks(ctx, orderedHooks(f.exitActions[f.currentState], f.anyExitActions), args, &done); err != nil {
+		return abort(PhaseExit, err)
+	}
+	report(PhaseExit, started)
+
+	// Execute transition callbacks if registered
+	started = time.Now()
+	if err := runHooks(ctx, f.transitionCallbacks[f.currentState][event], args, &done); err != nil {
+		return abort(PhaseCallback, err)
 	}
 
 	previousState := f.currentState
 	f.currentState = nextState
+	report(PhaseTransition, started)
+
+	// Execute entry actions of the new state
+	started = time.Now()
+	if err := runHooks(ctx, orderedHooks(f.entryActions[f.currentState], f.anyEntryActions), args, &done); err != nil {
+		// Revert state if an entry action fails
+		f.currentState = previousState
+		return abort(PhaseEntry, err)
+	}
+	report(PhaseEntry, started)
 
-	// Execute entry action of the new state
-	if entryAction, ok := f.entryActions[f.currentState]; ok {
-		if err := entryAction(ctx, args...); err != nil {
-			// Revert state if entry action fails
-			f.currentState = previousState
-			return fmt.Errorf("entry action failed for state %s: %w", f.currentState, err)
-		}
+	// A self-transition stays in its state
+	enteredAt := f.enteredAt
+	if nextState != previousState {
+		enteredAt = time.Now()
 	}
 
 	// Persist the new state and the transition history to the database
-	if f.client != nil && f.machineID != "" {
-		if err := f.persistStateAndHistory(ctx, previousState, nextState, event); err != nil {
+	if tx != nil {
+		started = time.Now()
+		err := f.persistStateAndHistory(ctx, tx, previousState, nextState, event, enteredAt)
+		if err == nil && owned {
+			finished = true
+			if err = tx.Commit(); err != nil {
+				err = fmt.Errorf("failed to commit transaction: %w", err)
+			}
+		}
+		if err != nil {
 			f.currentState = previousState // Revert state
-			return fmt.Errorf("failed to persist state and history: %w", err)
+			return abort(PhasePersist, err)
+		}
+		if owned {
+			report(PhasePersisted, started)
 		}
 	}
 
-	return nil
+	f.enteredAt = enteredAt
+	return nextState, nil
 }
 
-// persistStateAndHistory persists the new state and the transition history to the database.
-func (f *FSM) persistStateAndHistory(ctx context.Context, previousState, nextState State, event Event) error {
-	tx, err := f.client.Tx(ctx)
+// lockMachine locks the row of the machine until tx ends by writing to it, as SELECT ... FOR
+// UPDATE is not available on SQLite. It sets updated_at, which a successful transition writes
+// anyway and which is rolled back with tx otherwise.
+func (f *FSM) lockMachine(ctx context.Context, tx *ent.Tx) error {
+	n, err := tx.StateMachine.Update().
+		Where(statemachine.MachineID(f.machineID)).
+		SetUpdatedAt(time.Now()).
+		Save(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
+		return fmt.Errorf("failed to lock machine %s: %w", f.machineID, err)
+	}
+	if n == 0 {
+		_, err = tx.StateMachine.Query().Where(statemachine.MachineID(f.machineID)).Only(ctx)
+		return fmt.Errorf("failed to lock machine %s: %w", f.machineID, err)
 	}
+	return nil
+}
 
-	// Defer a rollback function that will be called if the transaction fails.
-	// If commit is successful, this deferred function will do nothing.
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r) // Re-throw panic after rollback
-		} else if err != nil {
-			tx.Rollback()
-		}
-	}()
+// recordFailure records a failed attempt in the history of the machine. The record is written
+// with the client once the transaction of the attempt has been rolled back, or within the
+// caller's transaction when the attempt joined one.
+func (f *FSM) recordFailure(ctx context.Context, tx *ent.Tx, owned bool, terr *TransitionError, compensated bool) error {
+	if tx == nil {
+		return nil
+	}
+	client := f.client
+	if !owned {
+		client = tx.Client()
+	}
+
+	compensation := statetransition.CompensationNone
+	if terr.Compensation != nil {
+		compensation = statetransition.CompensationFailed
+	} else if compensated {
+		compensation = statetransition.CompensationSucceeded
+	}
+
+	sm, err := client.StateMachine.Query().Where(statemachine.MachineID(f.machineID)).Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record failed attempt: %w", err)
+	}
+	err = client.StateTransition.Create().
+		SetFromState(string(terr.From)).
+		SetToState(string(terr.To)).
+		SetEvent(string(terr.Event)).
+		SetKind(statetransition.KindFailed).
+		SetError(terr.Error()).
+		SetCompensation(compensation).
+		SetMachine(sm).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record failed attempt: %w", err)
+	}
+	return nil
+}
 
+// persistStateAndHistory writes the new state, entered at enteredAt, and the transition history
+// within tx.
+func (f *FSM) persistStateAndHistory(ctx context.Context, tx *ent.Tx, previousState, nextState State, event Event, enteredAt time.Time) error {
 	// Get the StateMachine node
 	sm, err := tx.StateMachine.Query().Where(statemachine.MachineID(f.machineID)).Only(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to query state machine for update: %w", err)
 	}
+	if sm.Suspended {
+		// Suspended by another process while the actions ran
+		f.suspended = true
+		return fmt.Errorf("%w: machine %s", ErrSuspended, f.machineID)
+	}
 
 	// Create the history record
 	_, err = tx.StateTransition.Create().
@@ -243,64 +501,102 @@ func (f *FSM) persistStateAndHistory(ctx context.Context, previousState, nextSta
 	if err != nil {
 		return fmt.Errorf("failed to create transition history: %w", err)
 	}
+	if err := recordProcessedEvent(ctx, tx, sm, event, nextState); err != nil {
+		return err
+	}
 
-	// Update the current state of the machine
-	_, err = sm.Update().SetCurrentState(string(f.currentState)).Save(ctx)
+	// Update the current state of the machine. The entry time is written on self-transitions too,
+	// which records it for machines created before it was.
+	_, err = sm.Update().
+		SetCurrentState(string(f.currentState)).
+		SetEnteredAt(enteredAt).
+		Save(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to persist state: %w", err)
 	}
-
-	return tx.Commit()
+	return nil
 }
 
 // OnTransition registers a callback function to be executed when a specific transition occurs.
-func (f *FSM) OnTransition(from State, event Event, callback Action) error {
+// Several callbacks may be registered for the same transition; see WithPriority for their order.
+// The returned handle unregisters the callback.
+func (f *FSM) OnTransition(from State, event Event, callback Action, opts ...HookOption) (*Handle, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	if _, ok := f.transitions[from]; !ok {
-		return fmt.Errorf("%w: no transitions defined from state %s", ErrInvalidTransition, from)
+		return nil, fmt.Errorf("%w: no transitions defined from state %s", ErrInvalidTransition, from)
 	}
 	if _, ok := f.transitions[from][event]; !ok {
-		return fmt.Errorf("%w: no transition defined for event %s from state %s", ErrInvalidEvent, event, from)
+		return nil, fmt.Errorf("%w: no transition defined for event %s from state %s", ErrInvalidEvent, event, from)
 	}
 
 	if _, ok := f.transitionCallbacks[from]; !ok {
-		f.transitionCallbacks[from] = make(map[Event]Action)
+		f.transitionCallbacks[from] = make(map[Event][]*hook)
 	}
-	f.transitionCallbacks[from][event] = callback
-	return nil
+	h := f.newHook(callback, opts)
+	f.transitionCallbacks[from][event] = orderedHooks(f.transitionCallbacks[from][event], []*hook{h})
+	return &Handle{remove: func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.transitionCallbacks[from][event] = removeHook(f.transitionCallbacks[from][event], h.id)
+	}}, nil
 }
 
 // OnEntry registers an action to be executed when entering a state.
-func (f *FSM) OnEntry(state State, action Action) {
+// Several actions may be registered for the same state; see WithPriority for their order.
+// The returned handle unregisters the action.
+func (f *FSM) OnEntry(state State, action Action, opts ...HookOption) *Handle {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	f.entryActions[state] = action
+	h := f.newHook(action, opts)
+	f.entryActions[state] = orderedHooks(f.entryActions[state], []*hook{h})
+	return &Handle{remove: func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.entryActions[state] = removeHook(f.entryActions[state], h.id)
+	}}
 }
 
 // OnExit registers an action to be executed when exiting a state.
-func (f *FSM) OnExit(state State, action Action) {
+// Several actions may be registered for the same state; see WithPriority for their order.
+// The returned handle unregisters the action.
+func (f *FSM) OnExit(state State, action Action, opts ...HookOption) *Handle {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	f.exitActions[state] = action
+	h := f.newHook(action, opts)
+	f.exitActions[state] = orderedHooks(f.exitActions[state], []*hook{h})
+	return &Handle{remove: func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.exitActions[state] = removeHook(f.exitActions[state], h.id)
+	}}
 }
 
-// AddGuard registers a guard function for a specific transition.
-func (f *FSM) AddGuard(from State, event Event, guard Guard) error {
+// OnAnyEntry registers an action to be executed when entering any state.
+// It is ordered together with the entry actions of the state being entered.
+func (f *FSM) OnAnyEntry(action Action, opts ...HookOption) *Handle {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	h := f.newHook(action, opts)
+	f.anyEntryActions = orderedHooks(f.anyEntryActions, []*hook{h})
+	return &Handle{remove: func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.anyEntryActions = removeHook(f.anyEntryActions, h.id)
+	}}
+}
 
-	if _, ok := f.transitions[from]; !ok {
-		return fmt.Errorf("%w: no transitions defined from state %s", ErrInvalidTransition, from)
-	}
-	if _, ok := f.transitions[from][event]; !ok {
-		return fmt.Errorf("%w: no transition defined for event %s from state %s", ErrInvalidEvent, event, from)
-	}
-
-	if _, ok := f.guards[from]; !ok {
-		f.guards[from] = make(map[Event]Guard)
-	}
-	f.guards[from][event] = guard
-	return nil
+// OnAnyExit registers an action to be executed when exiting any state.
+// It is ordered together with the exit actions of the state being exited.
+func (f *FSM) OnAnyExit(action Action, opts ...HookOption) *Handle {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h := f.newHook(action, opts)
+	f.anyExitActions = orderedHooks(f.anyExitActions, []*hook{h})
+	return &Handle{remove: func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.anyExitActions = removeHook(f.anyExitActions, h.id)
+	}}
 }
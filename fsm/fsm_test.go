@@ -36,6 +36,13 @@ func setupTestClient(t *testing.T) *ent.Client {
 	return client
 }
 
+// setupFileTestClient opens a client on a database file of its own. Unlike the shared in-memory
+// database, writers wait for each other instead of failing, as concurrent processes would.
+func setupFileTestClient(t *testing.T) *ent.Client {
+	dsn := fmt.Sprintf("file:%s/fsm.db?_fk=1&_busy_timeout=5000", t.TempDir())
+	return enttest.Open(t, "sqlite3", dsn)
+}
+
 // defineTestTransitions defines a common set of transitions for testing.
 func defineTestTransitions() []Transition {
 	return []Transition{
@@ -504,7 +511,7 @@ func TestOnTransition(t *testing.T) {
 
 	t.Run("Register and execute OnTransition callback", func(t *testing.T) {
 		callbackCalled := false
-		err := f.OnTransition(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) error {
+		_, err := f.OnTransition(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) error {
 			callbackCalled = true
 			return nil
 		})
@@ -519,14 +526,14 @@ func TestOnTransition(t *testing.T) {
 	})
 
 	t.Run("OnTransition with invalid 'from' state", func(t *testing.T) {
-		err := f.OnTransition("NonExistentState", EventStart, func(ctx context.Context, args ...interface{}) error { return nil })
+		_, err := f.OnTransition("NonExistentState", EventStart, func(ctx context.Context, args ...interface{}) error { return nil })
 		if !errors.Is(err, ErrInvalidTransition) {
 			t.Errorf("Expected ErrInvalidTransition, got %v", err)
 		}
 	})
 
 	t.Run("OnTransition with invalid event for 'from' state", func(t *testing.T) {
-		err := f.OnTransition(StateIdle, "NonExistentEvent", func(ctx context.Context, args ...interface{}) error { return nil })
+		_, err := f.OnTransition(StateIdle, "NonExistentEvent", func(ctx context.Context, args ...interface{}) error { return nil })
 		if !errors.Is(err, ErrInvalidEvent) {
 			t.Errorf("Expected ErrInvalidEvent, got %v", err)
 		}
@@ -0,0 +1,163 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errGuardReturnedFalse is the reason reported for a boolean Guard that denied a transition.
+var errGuardReturnedFalse = errors.New("guard returned false")
+
+// Check is a guard that explains a denial: it returns nil to allow the transition,
+// or an error describing why the transition is not allowed.
+type Check func(ctx context.Context, args ...interface{}) error
+
+// Check adapts a boolean guard to a Check.
+func (g Guard) Check() Check {
+	return func(ctx context.Context, args ...interface{}) error {
+		if !g(ctx, args...) {
+			return errGuardReturnedFalse
+		}
+		return nil
+	}
+}
+
+// All passes when every check passes. Every check is evaluated and all reasons are reported.
+func All(checks ...Check) Check {
+	return func(ctx context.Context, args ...interface{}) error {
+		var errs []error
+		for _, c := range checks {
+			if err := c(ctx, args...); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Any passes when at least one check passes. If none does, all reasons are reported.
+func Any(checks ...Check) Check {
+	return func(ctx context.Context, args ...interface{}) error {
+		var errs []error
+		for _, c := range checks {
+			err := c(ctx, args...)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+		if len(errs) == 0 {
+			return errors.New("no checks to satisfy")
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Not passes when check fails, and reports reason when check passes.
+func Not(check Check, reason string) Check {
+	return func(ctx context.Context, args ...interface{}) error {
+		if check(ctx, args...) == nil {
+			return errors.New(reason)
+		}
+		return nil
+	}
+}
+
+// namedCheck is a check registered for a transition.
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// GuardFailure is a single guard that denied a transition.
+type GuardFailure struct {
+	Name string // Name given to AddCheck, or "guard N" for guards added with AddGuard
+	Err  error  // Reason given by the guard
+}
+
+// GuardError is returned when one or more guards deny a transition.
+// It matches ErrTransitionDenied with errors.Is.
+type GuardError struct {
+	MachineID string
+	From      State
+	Event     Event
+	To        State
+	Failures  []GuardFailure
+}
+
+// Error implements the error interface.
+func (e *GuardError) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		reasons[i] = fmt.Sprintf("%s: %v", f.Name, f.Err)
+	}
+	return fmt.Sprintf("%v: event %s from state %s: %s", ErrTransitionDenied, e.Event, e.From, strings.Join(reasons, "; "))
+}
+
+// Is reports whether target is ErrTransitionDenied.
+func (e *GuardError) Is(target error) bool {
+	return target == ErrTransitionDenied
+}
+
+// Unwrap returns the reasons given by the failing guards.
+func (e *GuardError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// AddGuard registers a guard function for a specific transition.
+// Several guards may be registered for the same transition; all of them must allow it.
+func (f *FSM) AddGuard(from State, event Event, guard Guard) error {
+	return f.addCheck(from, event, "", guard.Check())
+}
+
+// AddCheck registers a named check for a specific transition. When checks deny the transition,
+// Transition returns a *GuardError listing the name and reason of each failing check.
+func (f *FSM) AddCheck(from State, event Event, name string, check Check) error {
+	if name == "" {
+		return errors.New("check name must not be empty")
+	}
+	return f.addCheck(from, event, name, check)
+}
+
+// addCheck registers a check, naming it after its position when no name is given.
+func (f *FSM) addCheck(from State, event Event, name string, check Check) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.transitions[from]; !ok {
+		return fmt.Errorf("%w: no transitions defined from state %s", ErrInvalidTransition, from)
+	}
+	if _, ok := f.transitions[from][event]; !ok {
+		return fmt.Errorf("%w: no transition defined for event %s from state %s", ErrInvalidEvent, event, from)
+	}
+
+	if _, ok := f.guards[from]; !ok {
+		f.guards[from] = make(map[Event][]namedCheck)
+	}
+	if name == "" {
+		name = fmt.Sprintf("guard %d", len(f.guards[from][event])+1)
+	}
+	f.guards[from][event] = append(f.guards[from][event], namedCheck{name: name, check: check})
+	return nil
+}
+
+// checkGuards evaluates every guard of a transition and returns a *GuardError if any denies it.
+// f.mu must be held.
+func (f *FSM) checkGuards(ctx context.Context, from State, event Event, to State, args []interface{}) error {
+	var failures []GuardFailure
+	for _, g := range f.guards[from][event] {
+		if err := g.check(ctx, args...); err != nil {
+			failures = append(failures, GuardFailure{Name: g.name, Err: err})
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return &GuardError{MachineID: f.machineID, From: from, Event: event, To: to, Failures: failures}
+}
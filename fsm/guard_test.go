@@ -0,0 +1,120 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// constCheck returns a check that always returns err.
+func constCheck(err error) Check {
+	return func(ctx context.Context, args ...interface{}) error {
+		return err
+	}
+}
+
+func TestCheckComposition(t *testing.T) {
+	ctx := context.Background()
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	if err := All(constCheck(nil), constCheck(nil))(ctx); err != nil {
+		t.Errorf("Expected All to pass, got %v", err)
+	}
+	err := All(constCheck(errA), constCheck(nil), constCheck(errB))(ctx)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Expected All to report both reasons, got %v", err)
+	}
+
+	if err := Any(constCheck(errA), constCheck(nil))(ctx); err != nil {
+		t.Errorf("Expected Any to pass, got %v", err)
+	}
+	err = Any(constCheck(errA), constCheck(errB))(ctx)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Expected Any to report both reasons, got %v", err)
+	}
+	if err := Any()(ctx); err == nil {
+		t.Errorf("Expected Any without checks to fail, got nil")
+	}
+
+	if err := Not(constCheck(errA), "negated")(ctx); err != nil {
+		t.Errorf("Expected Not of a failing check to pass, got %v", err)
+	}
+	if err := Not(constCheck(nil), "negated")(ctx); err == nil || err.Error() != "negated" {
+		t.Errorf("Expected Not to report its reason, got %v", err)
+	}
+}
+
+func TestGuardError(t *testing.T) {
+	ctx := context.Background()
+	transitions := defineTestTransitions()
+
+	t.Run("All failing guards are reported", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		errNoCredit := errors.New("no credit")
+		calls := 0
+		f.AddGuard(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) bool {
+			calls++
+			return false
+		})
+		if err := f.AddCheck(StateIdle, EventStart, "credit", constCheck(errNoCredit)); err != nil {
+			t.Fatalf("AddCheck failed: %v", err)
+		}
+		f.AddCheck(StateIdle, EventStart, "passing", constCheck(nil))
+
+		err = f.Transition(ctx, EventStart)
+		if !errors.Is(err, ErrTransitionDenied) {
+			t.Fatalf("Expected ErrTransitionDenied, got %v", err)
+		}
+		if !errors.Is(err, errNoCredit) {
+			t.Errorf("Expected error to wrap the check reason, got %v", err)
+		}
+		var denied *GuardError
+		if !errors.As(err, &denied) {
+			t.Fatalf("Expected *GuardError, got %T", err)
+		}
+		if len(denied.Failures) != 2 || denied.Failures[0].Name != "guard 1" || denied.Failures[1].Name != "credit" {
+			t.Errorf("Unexpected failures: %+v", denied.Failures)
+		}
+		if denied.From != StateIdle || denied.Event != EventStart || denied.To != StateRunning {
+			t.Errorf("Unexpected transition in error: %+v", denied)
+		}
+		if calls != 1 {
+			t.Errorf("Expected boolean guard to run once, ran %d times", calls)
+		}
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state %s, got %s", StateIdle, f.CurrentState())
+		}
+	})
+
+	t.Run("Guards no longer overwrite each other", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		f.AddGuard(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) bool { return false })
+		f.AddGuard(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) bool { return true })
+		if err := f.Transition(ctx, EventStart); !errors.Is(err, ErrTransitionDenied) {
+			t.Errorf("Expected ErrTransitionDenied, got %v", err)
+		}
+	})
+
+	t.Run("Registration errors", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		if err := f.AddCheck(StateIdle, EventStart, "", constCheck(nil)); err == nil {
+			t.Errorf("Expected error for empty check name, got nil")
+		}
+		if err := f.AddCheck(StateIdle, EventStop, "stop", constCheck(nil)); !errors.Is(err, ErrInvalidEvent) {
+			t.Errorf("Expected ErrInvalidEvent, got %v", err)
+		}
+	})
+}
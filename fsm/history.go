@@ -0,0 +1,177 @@
+package fsm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// HistoryFilter selects and pages history entries. Zero fields match every entry.
+type HistoryFilter struct {
+	MachineID string
+	Since     time.Time // Entries recorded at or after Since
+	Until     time.Time // Entries recorded before Until
+	Event     Event
+	From      State
+	To        State
+	Actor     string
+	Kind      statetransition.Kind
+
+	Descending bool   // Newest entries first
+	Limit      int    // Entries per page; defaults to 100
+	Cursor     string // Next cursor of the previous page, empty for the first page
+}
+
+// HistoryPage is one page of history entries.
+type HistoryPage struct {
+	Entries []*ent.StateTransition
+	// Next is the cursor of the following page, or empty on the last page.
+	Next string
+}
+
+// predicates returns the conditions of the filter, excluding paging.
+func (f HistoryFilter) predicates() []predicate.StateTransition {
+	var ps []predicate.StateTransition
+	if f.MachineID != "" {
+		ps = append(ps, statetransition.HasMachineWith(statemachine.MachineID(f.MachineID)))
+	}
+	if !f.Since.IsZero() {
+		ps = append(ps, statetransition.TimestampGTE(f.Since))
+	}
+	if !f.Until.IsZero() {
+		ps = append(ps, statetransition.TimestampLT(f.Until))
+	}
+	if f.Event != "" {
+		ps = append(ps, statetransition.Event(string(f.Event)))
+	}
+	if f.From != "" {
+		ps = append(ps, statetransition.FromState(string(f.From)))
+	}
+	if f.To != "" {
+		ps = append(ps, statetransition.ToState(string(f.To)))
+	}
+	if f.Actor != "" {
+		ps = append(ps, statetransition.Actor(f.Actor))
+	}
+	if f.Kind != "" {
+		ps = append(ps, statetransition.KindEQ(f.Kind))
+	}
+	return ps
+}
+
+// History returns a page of the history entries selected by filter. Entries are ordered by the
+// order in which they were recorded, which is stable across pages even when timestamps are equal.
+// Pass the Next cursor of a page in filter.Cursor to read the following page.
+func History(ctx context.Context, client *ent.Client, filter HistoryFilter) (*HistoryPage, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	ps := filter.predicates()
+	if filter.Cursor != "" {
+		id, err := decodeHistoryCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Descending {
+			ps = append(ps, statetransition.IDLT(id))
+		} else {
+			ps = append(ps, statetransition.IDGT(id))
+		}
+	}
+
+	order := ent.Asc(statetransition.FieldID)
+	if filter.Descending {
+		order = ent.Desc(statetransition.FieldID)
+	}
+	// Read one more entry to know whether another page follows
+	entries, err := client.StateTransition.Query().
+		Where(ps...).
+		Order(order).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+
+	page := &HistoryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.Next = encodeHistoryCursor(entries[limit-1].ID)
+	}
+	return page, nil
+}
+
+// HistoryEntries iterates over every history entry selected by filter, reading it page by page,
+// so that long histories are not loaded at once. Iteration stops at the first error, which is
+// yielded with a nil entry.
+func HistoryEntries(ctx context.Context, client *ent.Client, filter HistoryFilter) iter.Seq2[*ent.StateTransition, error] {
+	return func(yield func(*ent.StateTransition, error) bool) {
+		for {
+			page, err := History(ctx, client, filter)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, entry := range page.Entries {
+				if !yield(entry, nil) {
+					return
+				}
+			}
+			if page.Next == "" {
+				return
+			}
+			filter.Cursor = page.Next
+		}
+	}
+}
+
+// errNotPersisted is returned when reading the history of a machine without a client or ID.
+var errNotPersisted = errors.New("history is only recorded for persisted machines")
+
+// History returns a page of the history of the machine. See History.
+func (f *FSM) History(ctx context.Context, filter HistoryFilter) (*HistoryPage, error) {
+	if f.client == nil || f.machineID == "" {
+		return nil, errNotPersisted
+	}
+	filter.MachineID = f.machineID
+	return History(ctx, f.client, filter)
+}
+
+// HistoryEntries iterates over the history of the machine. See HistoryEntries.
+func (f *FSM) HistoryEntries(ctx context.Context, filter HistoryFilter) iter.Seq2[*ent.StateTransition, error] {
+	if f.client == nil || f.machineID == "" {
+		return func(yield func(*ent.StateTransition, error) bool) {
+			yield(nil, errNotPersisted)
+		}
+	}
+	filter.MachineID = f.machineID
+	return HistoryEntries(ctx, f.client, filter)
+}
+
+// encodeHistoryCursor returns an opaque cursor positioned after the entry with the given ID.
+func encodeHistoryCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+// decodeHistoryCursor returns the entry ID of a cursor.
+func decodeHistoryCursor(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		var id int
+		if id, err = strconv.Atoi(string(b)); err == nil {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid history cursor %q", cursor)
+}
@@ -0,0 +1,156 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	f, err := NewFSM(ctx, client, "history_machine", StateIdle, defineTestTransitions())
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	other, err := NewFSM(ctx, client, "history_other", StateIdle, defineTestTransitions())
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	// start, then five pause/resume cycles, then an override
+	events := []Event{EventStart}
+	for i := 0; i < 5; i++ {
+		events = append(events, EventPause, EventResume)
+	}
+	for _, event := range events {
+		if err := f.Transition(ctx, event); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+	}
+	if err := other.Transition(ctx, EventStart); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if err := f.ForceState(ctx, Override{To: StateStopped, Reason: "done", Actor: "alice"}); err != nil {
+		t.Fatalf("ForceState failed: %v", err)
+	}
+
+	t.Run("Pages", func(t *testing.T) {
+		var got []string
+		filter := HistoryFilter{MachineID: "history_machine", Limit: 4}
+		pages := 0
+		for {
+			page, err := History(ctx, client, filter)
+			if err != nil {
+				t.Fatalf("History failed: %v", err)
+			}
+			pages++
+			for _, e := range page.Entries {
+				got = append(got, e.Event)
+			}
+			if page.Next == "" {
+				break
+			}
+			filter.Cursor = page.Next
+		}
+		if pages != 3 || len(got) != 12 {
+			t.Fatalf("Expected 12 entries in 3 pages, got %d in %d", len(got), pages)
+		}
+		for i, event := range events {
+			if got[i] != string(event) {
+				t.Errorf("Expected entry %d to be %s, got %s", i, event, got[i])
+			}
+		}
+		if got[11] != "" {
+			t.Errorf("Expected the override last, got %s", got[11])
+		}
+	})
+
+	t.Run("Descending", func(t *testing.T) {
+		page, err := f.History(ctx, HistoryFilter{Descending: true, Limit: 2})
+		if err != nil {
+			t.Fatalf("History failed: %v", err)
+		}
+		if len(page.Entries) != 2 || page.Entries[0].Kind != statetransition.KindOverride || page.Entries[1].Event != string(EventResume) {
+			t.Errorf("Expected newest entries first, got %+v", page.Entries)
+		}
+		next, err := f.History(ctx, HistoryFilter{Descending: true, Limit: 2, Cursor: page.Next})
+		if err != nil {
+			t.Fatalf("History failed: %v", err)
+		}
+		if next.Entries[0].ID >= page.Entries[1].ID {
+			t.Errorf("Expected the next page to continue backwards")
+		}
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filter   HistoryFilter
+			expected int
+		}{
+			{"event", HistoryFilter{MachineID: "history_machine", Event: EventPause}, 5},
+			{"from", HistoryFilter{MachineID: "history_machine", From: StatePaused}, 5},
+			{"to", HistoryFilter{To: StateRunning}, 7},
+			{"actor", HistoryFilter{Actor: "alice"}, 1},
+			{"kind", HistoryFilter{Kind: statetransition.KindOverride}, 1},
+			{"since", HistoryFilter{MachineID: "history_machine", Since: time.Now().Add(time.Hour)}, 0},
+			{"until", HistoryFilter{MachineID: "history_machine", Until: time.Now().Add(time.Hour)}, 12},
+		}
+		for _, tt := range tests {
+			page, err := History(ctx, client, tt.filter)
+			if err != nil {
+				t.Fatalf("%s: History failed: %v", tt.name, err)
+			}
+			if len(page.Entries) != tt.expected {
+				t.Errorf("%s: expected %d entries, got %d", tt.name, tt.expected, len(page.Entries))
+			}
+		}
+	})
+
+	t.Run("Iterator", func(t *testing.T) {
+		n := 0
+		for e, err := range f.HistoryEntries(ctx, HistoryFilter{Limit: 5}) {
+			if err != nil {
+				t.Fatalf("HistoryEntries failed: %v", err)
+			}
+			if n < len(events) && e.Event != string(events[n]) {
+				t.Errorf("Expected entry %d to be %s, got %s", n, events[n], e.Event)
+			}
+			n++
+		}
+		if n != 12 {
+			t.Errorf("Expected 12 entries, got %d", n)
+		}
+
+		// Stopping early
+		n = 0
+		for range HistoryEntries(ctx, client, HistoryFilter{MachineID: "history_machine", Limit: 5}) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		if n != 3 {
+			t.Errorf("Expected to stop after 3 entries, got %d", n)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := History(ctx, client, HistoryFilter{Cursor: "not a cursor"}); err == nil {
+			t.Errorf("Expected error for invalid cursor, got nil")
+		}
+		for _, err := range HistoryEntries(ctx, client, HistoryFilter{Cursor: "not a cursor"}) {
+			if err == nil {
+				t.Errorf("Expected iterator to yield the error")
+			}
+		}
+		memory, _ := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if _, err := memory.History(ctx, HistoryFilter{}); err == nil {
+			t.Errorf("Expected error for machine without persistence, got nil")
+		}
+	})
+}
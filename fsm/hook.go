@@ -0,0 +1,99 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// hook is an action registered with OnEntry, OnExit, OnTransition, OnAnyEntry or OnAnyExit.
+type hook struct {
+	id           uint64 // Registration order within the FSM
+	priority     int
+	action       Action
+	compensation Action // Undoes action when a later step of the transition fails
+}
+
+// HookOption configures an action at registration time.
+type HookOption func(h *hook)
+
+// WithPriority sets the priority of an action. Actions with a higher priority run first;
+// actions with equal priority run in registration order. The default priority is 0.
+func WithPriority(priority int) HookOption {
+	return func(h *hook) {
+		h.priority = priority
+	}
+}
+
+// WithCompensation sets an action undoing the effects of the registered action. When a later
+// action, or the persistence of the new state, fails, the compensations of the actions that
+// completed run in reverse order. The compensation receives the arguments of the transition.
+func WithCompensation(compensation Action) HookOption {
+	return func(h *hook) {
+		h.compensation = compensation
+	}
+}
+
+// newHook creates a hook with the next registration ID. f.mu must be held.
+func (f *FSM) newHook(action Action, opts []HookOption) *hook {
+	f.nextHookID++
+	h := &hook{id: f.nextHookID, action: action}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// removeHook returns hooks without the hook with the given ID.
+func removeHook(hooks []*hook, id uint64) []*hook {
+	for i, h := range hooks {
+		if h.id == id {
+			return append(hooks[:i:i], hooks[i+1:]...)
+		}
+	}
+	return hooks
+}
+
+// orderedHooks merges hook lists into execution order.
+func orderedHooks(lists ...[]*hook) []*hook {
+	var hooks []*hook
+	for _, l := range lists {
+		hooks = append(hooks, l...)
+	}
+	sort.SliceStable(hooks, func(i, j int) bool {
+		if hooks[i].priority != hooks[j].priority {
+			return hooks[i].priority > hooks[j].priority
+		}
+		return hooks[i].id < hooks[j].id
+	})
+	return hooks
+}
+
+// runHooks runs the hooks in order and stops at the first error.
+// Hooks that complete are appended to done.
+func runHooks(ctx context.Context, hooks []*hook, args []interface{}, done *[]*hook) error {
+	for _, h := range hooks {
+		if err := h.action(ctx, args...); err != nil {
+			return err
+		}
+		*done = append(*done, h)
+	}
+	return nil
+}
+
+// compensate runs the compensations of the completed hooks in reverse order. It reports whether
+// any compensation ran and returns the errors of those that failed.
+func compensate(ctx context.Context, done []*hook, args []interface{}) (bool, error) {
+	ran := false
+	var errs []error
+	for i := len(done) - 1; i >= 0; i-- {
+		if done[i].compensation == nil {
+			continue
+		}
+		ran = true
+		if err := done[i].compensation(ctx, args...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return ran, errors.Join(errs...)
+}
@@ -0,0 +1,246 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// recordAction returns an action appending name to calls.
+func recordAction(name string, calls *[]string) Action {
+	return func(ctx context.Context, args ...interface{}) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+	transitions := defineTestTransitions()
+
+	t.Run("Multiple actions run in registration order", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		f.OnExit(StateIdle, recordAction("exit 1", &calls))
+		f.OnExit(StateIdle, recordAction("exit 2", &calls))
+		f.OnTransition(StateIdle, EventStart, recordAction("callback 1", &calls))
+		f.OnTransition(StateIdle, EventStart, recordAction("callback 2", &calls))
+		f.OnEntry(StateRunning, recordAction("entry 1", &calls))
+		f.OnEntry(StateRunning, recordAction("entry 2", &calls))
+
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		expected := []string{"exit 1", "exit 2", "callback 1", "callback 2", "entry 1", "entry 2"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected calls %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("Priority", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		f.OnEntry(StateRunning, recordAction("default", &calls))
+		f.OnEntry(StateRunning, recordAction("low", &calls), WithPriority(-1))
+		f.OnEntry(StateRunning, recordAction("high", &calls), WithPriority(10))
+		f.OnAnyEntry(recordAction("any high", &calls), WithPriority(10))
+
+		f.Transition(ctx, EventStart)
+		expected := []string{"high", "any high", "default", "low"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected calls %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("Unregister", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		entry := f.OnEntry(StateRunning, recordAction("entry", &calls))
+		exit := f.OnExit(StateIdle, recordAction("exit", &calls))
+		callback, err := f.OnTransition(StateIdle, EventStart, recordAction("callback", &calls))
+		if err != nil {
+			t.Fatalf("OnTransition registration failed: %v", err)
+		}
+		anyEntry := f.OnAnyEntry(recordAction("any entry", &calls))
+		anyExit := f.OnAnyExit(recordAction("any exit", &calls))
+		f.OnEntry(StateRunning, recordAction("kept", &calls))
+
+		for _, h := range []*Handle{entry, exit, callback, anyEntry, anyExit} {
+			h.Remove()
+		}
+
+		f.Transition(ctx, EventStart)
+		if !reflect.DeepEqual(calls, []string{"kept"}) {
+			t.Errorf("Expected only the kept action to run, got %v", calls)
+		}
+	})
+
+	t.Run("Any-state hooks", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		f.OnAnyExit(recordAction("any exit", &calls))
+		f.OnAnyEntry(recordAction("any entry", &calls))
+
+		f.Transition(ctx, EventStart)
+		f.Transition(ctx, EventPause)
+		expected := []string{"any exit", "any entry", "any exit", "any entry"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected calls %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("Failing action stops the remaining ones", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			return errors.New("entry action error")
+		})
+		f.OnEntry(StateRunning, recordAction("skipped", &calls))
+
+		if err := f.Transition(ctx, EventStart); err == nil {
+			t.Fatalf("Expected error from entry action, got nil")
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected remaining actions to be skipped, got %v", calls)
+		}
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state %s, got %s", StateIdle, f.CurrentState())
+		}
+	})
+}
+
+func TestCompensation(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	lastRecord := func(t *testing.T, machineID string) *ent.StateTransition {
+		t.Helper()
+		record, err := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID(machineID))).
+			Order(ent.Desc(statetransition.FieldID)).
+			First(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query history: %v", err)
+		}
+		return record
+	}
+	failing := func(ctx context.Context, args ...interface{}) error {
+		return errors.New("entry action error")
+	}
+
+	t.Run("Completed actions are compensated in reverse order", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "compensation_machine_1", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		f.OnExit(StateIdle, recordAction("exit", &calls), WithCompensation(recordAction("undo exit", &calls)))
+		f.OnTransition(StateIdle, EventStart, recordAction("callback", &calls), WithCompensation(recordAction("undo callback", &calls)))
+		f.OnEntry(StateRunning, recordAction("entry", &calls), WithCompensation(recordAction("undo entry", &calls)))
+		f.OnEntry(StateRunning, failing, WithCompensation(recordAction("undo failing", &calls)))
+
+		err = f.Transition(ctx, EventStart)
+		var terr *TransitionError
+		if !errors.As(err, &terr) || terr.Phase != PhaseEntry || terr.Compensation != nil {
+			t.Fatalf("Expected entry error without compensation errors, got %v", err)
+		}
+		expected := []string{"exit", "callback", "entry", "undo entry", "undo callback", "undo exit"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected calls %v, got %v", expected, calls)
+		}
+
+		record := lastRecord(t, "compensation_machine_1")
+		if record.Kind != statetransition.KindFailed || record.Compensation != statetransition.CompensationSucceeded {
+			t.Errorf("Expected failed attempt with succeeded compensation, got %s/%s", record.Kind, record.Compensation)
+		}
+		if record.FromState != string(StateIdle) || record.ToState != string(StateRunning) || record.Error != err.Error() {
+			t.Errorf("Unexpected failed attempt record: %+v", record)
+		}
+	})
+
+	t.Run("Persistence failure is compensated", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "compensation_machine_2", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var calls []string
+		f.OnEntry(StateRunning, recordAction("entry", &calls), WithCompensation(recordAction("undo entry", &calls)))
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			// Removing the machine row makes the state update fail
+			_, err := ent.TxFromContext(ctx).StateMachine.Delete().Where(statemachine.MachineID("compensation_machine_2")).Exec(ctx)
+			return err
+		})
+
+		err = f.Transition(ctx, EventStart)
+		var terr *TransitionError
+		if !errors.As(err, &terr) || terr.Phase != PhasePersist {
+			t.Fatalf("Expected persist error, got %v", err)
+		}
+		if !reflect.DeepEqual(calls, []string{"entry", "undo entry"}) {
+			t.Errorf("Expected entry action to be compensated, got %v", calls)
+		}
+	})
+
+	t.Run("Failing compensation", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "compensation_machine_3", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		errUndo := errors.New("undo error")
+		f.OnExit(StateIdle, recordAction("exit", new([]string)), WithCompensation(func(ctx context.Context, args ...interface{}) error {
+			return errUndo
+		}))
+		f.OnEntry(StateRunning, failing)
+
+		err = f.Transition(ctx, EventStart)
+		var terr *TransitionError
+		if !errors.As(err, &terr) || !errors.Is(terr.Compensation, errUndo) {
+			t.Fatalf("Expected compensation error, got %v", err)
+		}
+		if record := lastRecord(t, "compensation_machine_3"); record.Compensation != statetransition.CompensationFailed {
+			t.Errorf("Expected failed compensation, got %s", record.Compensation)
+		}
+	})
+
+	t.Run("Failures without compensations", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "compensation_machine_4", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, failing)
+
+		f.Transition(ctx, EventStart)
+		if record := lastRecord(t, "compensation_machine_4"); record.Kind != statetransition.KindFailed || record.Compensation != statetransition.CompensationNone {
+			t.Errorf("Expected failed attempt without compensation, got %s/%s", record.Kind, record.Compensation)
+		}
+	})
+}
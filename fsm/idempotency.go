@@ -0,0 +1,90 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key already applied to a machine is
+// sent with a different event.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused for a different event")
+
+// idempotencyKey is the context key of the idempotency key of a Transition call.
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context making Transition apply its event at most once per machine
+// for the given key. A duplicate returns nil without evaluating guards or running actions, as the
+// first successful call did. Keys are recorded with the history of persistent machines only, and
+// only for transitions that succeed, so failed events can be redelivered with the same key. A
+// duplicate sent while the first call is running waits for it, as the machine is locked.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key set with WithIdempotencyKey.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKey{}).(string)
+	return key, ok && key != ""
+}
+
+// processedEvent returns the record of the idempotency key of ctx, or nil if there is none or the
+// key has not been applied yet.
+func (f *FSM) processedEvent(ctx context.Context) (*ent.ProcessedEvent, error) {
+	key, ok := IdempotencyKeyFromContext(ctx)
+	if !ok || f.client == nil || f.machineID == "" {
+		return nil, nil
+	}
+	client := f.client
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		client = tx.Client()
+	}
+
+	processed, err := client.ProcessedEvent.Query().
+		Where(
+			processedevent.Key(key),
+			processedevent.HasMachineWith(statemachine.MachineID(f.machineID)),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idempotency key %s: %w", key, err)
+	}
+	return processed, nil
+}
+
+// recordProcessedEvent records the idempotency key of ctx, if any, within tx.
+func recordProcessedEvent(ctx context.Context, tx *ent.Tx, sm *ent.StateMachine, event Event, to State) error {
+	key, ok := IdempotencyKeyFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	err := tx.ProcessedEvent.Create().
+		SetKey(key).
+		SetEvent(string(event)).
+		SetToState(string(to)).
+		SetMachine(sm).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record idempotency key %s: %w", key, err)
+	}
+	return nil
+}
+
+// PruneIdempotencyKeys deletes the idempotency keys recorded before the given time and returns the
+// number deleted. Events redelivered with a pruned key are applied again, so the retention period
+// must exceed the time within which duplicates can arrive.
+func PruneIdempotencyKeys(ctx context.Context, client *ent.Client, before time.Time) (int, error) {
+	n, err := client.ProcessedEvent.Delete().Where(processedevent.ProcessedAtLT(before)).Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune idempotency keys: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,181 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	transitions := []Transition{
+		{From: StateIdle, Event: EventStart, To: StateRunning},
+		{From: StateRunning, Event: EventStop, To: StateIdle},
+	}
+	history := func(t *testing.T, machineID string) int {
+		t.Helper()
+		n, err := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID(machineID)), statetransition.KindEQ(statetransition.KindTransition)).
+			Count(ctx)
+		if err != nil {
+			t.Fatalf("Failed to count history: %v", err)
+		}
+		return n
+	}
+
+	t.Run("Duplicates are applied once", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "idempotent_machine_1", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		entries := 0
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			entries++
+			return nil
+		})
+
+		keyed := WithIdempotencyKey(ctx, "message-1")
+		if err := f.Transition(keyed, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventStop); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		// Redelivery of the first message
+		if err := f.Transition(keyed, EventStart); err != nil {
+			t.Fatalf("Expected duplicate to succeed, got %v", err)
+		}
+		if entries != 1 {
+			t.Errorf("Expected entry action to run once, ran %d times", entries)
+		}
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state %s, got %s", StateIdle, f.CurrentState())
+		}
+		if n := history(t, "idempotent_machine_1"); n != 2 {
+			t.Errorf("Expected 2 history records, got %d", n)
+		}
+
+		err = f.Transition(keyed, EventStop)
+		if !errors.Is(err, ErrIdempotencyKeyReused) {
+			t.Errorf("Expected ErrIdempotencyKeyReused, got %v", err)
+		}
+	})
+
+	t.Run("Keys are scoped to the machine", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "idempotent_machine_2", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := f.Transition(WithIdempotencyKey(ctx, "message-1"), EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if f.CurrentState() != StateRunning {
+			t.Errorf("Expected state %s, got %s", StateRunning, f.CurrentState())
+		}
+	})
+
+	t.Run("Failed attempts can be redelivered", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "idempotent_machine_3", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		fail := true
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			if fail {
+				return errors.New("downstream unavailable")
+			}
+			return nil
+		})
+
+		keyed := WithIdempotencyKey(ctx, "message-2")
+		if err := f.Transition(keyed, EventStart); err == nil {
+			t.Fatalf("Expected error from entry action, got nil")
+		}
+		fail = false
+		if err := f.Transition(keyed, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if f.CurrentState() != StateRunning {
+			t.Errorf("Expected state %s, got %s", StateRunning, f.CurrentState())
+		}
+	})
+
+	t.Run("Pruning", func(t *testing.T) {
+		n, err := PruneIdempotencyKeys(ctx, client, time.Now().Add(-time.Hour))
+		if err != nil || n != 0 {
+			t.Fatalf("Expected no keys to be pruned, got %d, %v", n, err)
+		}
+		n, err = PruneIdempotencyKeys(ctx, client, time.Now().Add(time.Second))
+		if err != nil || n != 3 {
+			t.Fatalf("Expected 3 keys to be pruned, got %d, %v", n, err)
+		}
+		if left, _ := client.ProcessedEvent.Query().Where(processedevent.Key("message-1")).Count(ctx); left != 0 {
+			t.Errorf("Expected keys to be deleted, %d left", left)
+		}
+	})
+}
+
+func TestConcurrentIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	client := setupFileTestClient(t)
+	defer client.Close()
+
+	if _, err := NewFSM(ctx, client, "idempotent_concurrent", StateIdle, defineTestTransitions()); err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+
+	// Two instances of the machine, as in two consumers receiving the same delivery
+	var runs atomic.Int32
+	entered := make(chan struct{}, 2)
+	release := make(chan struct{})
+	instance := func() *FSM {
+		f, err := LoadFSM(ctx, client, "idempotent_concurrent", defineTestTransitions())
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			runs.Add(1)
+			entered <- struct{}{}
+			<-release
+			return nil
+		})
+		return f
+	}
+	a, b := instance(), instance()
+
+	keyed := WithIdempotencyKey(ctx, "delivery-1")
+	results := make(chan error, 2)
+	go func() { results <- a.Transition(keyed, EventStart) }()
+	<-entered
+	go func() { results <- b.Transition(keyed, EventStart) }()
+	select {
+	case <-entered:
+		t.Errorf("Expected the duplicate delivery to wait for the first one")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Expected both deliveries to succeed, got %v", err)
+		}
+	}
+	if n := runs.Load(); n != 1 {
+		t.Errorf("Expected the entry action to run once, got %d", n)
+	}
+	history, err := client.StateTransition.Query().
+		Where(statetransition.HasMachineWith(statemachine.MachineID("idempotent_concurrent"))).
+		All(ctx)
+	if err != nil || len(history) != 1 || history[0].Kind != statetransition.KindTransition {
+		t.Errorf("Expected a single transition in history, got %+v, %v", history, err)
+	}
+}
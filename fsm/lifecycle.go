@@ -0,0 +1,307 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/job"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// ErrNotCompleted is returned when archiving a machine that is not in a final state.
+var ErrNotCompleted = errors.New("machine not in a final state")
+
+// Reset moves the machine back to the initial state of its definition and clears its data,
+// without running any action. A reset entry with the reason and actor is recorded in the history,
+// which is otherwise kept.
+func (f *FSM) Reset(ctx context.Context, reason, actor string) error {
+	if reason == "" || actor == "" {
+		return errors.New("resetting a machine requires a reason and an actor")
+	}
+	if f.definition.Initial == "" {
+		return fmt.Errorf("cannot reset machine %s: its definition has no initial state", f.machineID)
+	}
+	return f.force(ctx, Override{To: f.definition.Initial, Reason: reason, Actor: actor}, statetransition.KindReset)
+}
+
+// DeleteMachine deletes a persisted machine together with its history, idempotency keys and
+// jobs. Instances of the machine that are still in use must not be used afterwards.
+func DeleteMachine(ctx context.Context, client *ent.Client, machineID string) (err error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sm, err := tx.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query state machine with ID %s: %w", machineID, err)
+	}
+	if err = deleteMachine(ctx, tx, sm); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// deleteMachine deletes a machine and the rows that belong to it within tx. The rows are deleted
+// explicitly, as databases created before deletes cascaded keep their old foreign keys.
+func deleteMachine(ctx context.Context, tx *ent.Tx, sm *ent.StateMachine) error {
+	if _, err := tx.StateTransition.Delete().Where(statetransition.HasMachineWith(statemachine.ID(sm.ID))).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete history of machine %s: %w", sm.MachineID, err)
+	}
+	if _, err := tx.ProcessedEvent.Delete().Where(processedevent.HasMachineWith(statemachine.ID(sm.ID))).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete idempotency keys of machine %s: %w", sm.MachineID, err)
+	}
+	if _, err := tx.Job.Delete().Where(job.HasMachineWith(statemachine.ID(sm.ID))).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete jobs of machine %s: %w", sm.MachineID, err)
+	}
+	if err := tx.StateMachine.DeleteOne(sm).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete machine %s: %w", sm.MachineID, err)
+	}
+	return nil
+}
+
+// Archive moves a machine in a final state of its registered definition, with its history, to
+// the archived_machines and archived_transitions tables. It returns an error matching
+// ErrNotCompleted for a machine that is not in a final state.
+func (m *Manager) Archive(ctx context.Context, machineID string) (err error) {
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sm, err := tx.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query state machine with ID %s: %w", machineID, err)
+	}
+	def, ok := m.Definition(sm.DefinitionName)
+	if !ok {
+		return fmt.Errorf("%w: machine %s uses definition %q, which is not registered",
+			ErrIncompatibleDefinition, machineID, sm.DefinitionName)
+	}
+	if !def.IsFinal(State(sm.CurrentState)) {
+		return fmt.Errorf("%w: machine %s is in state %s", ErrNotCompleted, machineID, sm.CurrentState)
+	}
+
+	if err = archive(ctx, tx, sm); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// archive copies a machine and its history to the archive tables and deletes it within tx.
+func archive(ctx context.Context, tx *ent.Tx, sm *ent.StateMachine) error {
+	history, err := tx.StateTransition.Query().
+		Where(statetransition.HasMachineWith(statemachine.ID(sm.ID))).
+		Order(ent.Asc(statetransition.FieldID)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query history of machine %s: %w", sm.MachineID, err)
+	}
+
+	archived, err := tx.ArchivedMachine.Create().
+		SetMachineID(sm.MachineID).
+		SetCurrentState(sm.CurrentState).
+		SetDefinitionName(sm.DefinitionName).
+		SetDefinitionVersion(sm.DefinitionVersion).
+		SetData(sm.Data).
+		SetSuspended(sm.Suspended).
+		SetCreatedAt(sm.CreatedAt).
+		SetUpdatedAt(sm.UpdatedAt).
+		SetNillableEnteredAt(sm.EnteredAt).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to archive machine %s: %w", sm.MachineID, err)
+	}
+
+	builders := make([]*ent.ArchivedTransitionCreate, len(history))
+	for i, h := range history {
+		builders[i] = tx.ArchivedTransition.Create().
+			SetFromState(h.FromState).
+			SetToState(h.ToState).
+			SetEvent(h.Event).
+			SetTimestamp(h.Timestamp).
+			SetKind(string(h.Kind)).
+			SetReason(h.Reason).
+			SetActor(h.Actor).
+			SetError(h.Error).
+			SetCompensation(string(h.Compensation)).
+			SetMachine(archived)
+	}
+	if len(builders) > 0 {
+		if err := tx.ArchivedTransition.CreateBulk(builders...).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to archive history of machine %s: %w", sm.MachineID, err)
+		}
+	}
+	return deleteMachine(ctx, tx, sm)
+}
+
+// RetentionPolicy selects the machines removed by ApplyRetention.
+type RetentionPolicy struct {
+	// CompletedFor is how long a machine must have stayed unchanged in a final state of its
+	// registered definition before it is archived. Zero leaves completed machines alone.
+	CompletedFor time.Duration
+	// Delete deletes completed machines instead of archiving them.
+	Delete bool
+	// PurgeArchivedAfter is how long archived machines are kept. Zero keeps them forever.
+	PurgeArchivedAfter time.Duration
+	// BatchSize is the number of machines read per query; defaults to 100.
+	BatchSize int
+}
+
+// RetentionResult counts the machines removed by ApplyRetention.
+type RetentionResult struct {
+	Archived int
+	Deleted  int
+	Purged   int // Archived machines deleted
+}
+
+// ApplyRetention archives or deletes the completed machines of the registered definitions and
+// purges old archives, as selected by p. Each machine is handled in its own transaction, so an
+// error leaves the machines handled so far removed; they are counted in the result.
+func (m *Manager) ApplyRetention(ctx context.Context, p RetentionPolicy) (RetentionResult, error) {
+	var result RetentionResult
+	batch := p.BatchSize
+	if batch <= 0 {
+		batch = 100
+	}
+
+	if p.CompletedFor > 0 {
+		cutoff := time.Now().Add(-p.CompletedFor)
+		m.mu.RLock()
+		defs := make([]*Definition, 0, len(m.definitions))
+		for _, def := range m.definitions {
+			defs = append(defs, def)
+		}
+		m.mu.RUnlock()
+
+		for _, def := range defs {
+			if err := m.removeCompleted(ctx, def, cutoff, batch, p.Delete, &result); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if p.PurgeArchivedAfter > 0 {
+		cutoff := time.Now().Add(-p.PurgeArchivedAfter)
+		for {
+			ids, err := m.client.ArchivedMachine.Query().
+				Where(archivedmachine.ArchivedAtLT(cutoff)).
+				Limit(batch).
+				IDs(ctx)
+			if err != nil {
+				return result, fmt.Errorf("failed to query archived machines: %w", err)
+			}
+			if len(ids) == 0 {
+				break
+			}
+			_, err = m.client.ArchivedTransition.Delete().
+				Where(archivedtransition.HasMachineWith(archivedmachine.IDIn(ids...))).
+				Exec(ctx)
+			if err != nil {
+				return result, fmt.Errorf("failed to purge archived history: %w", err)
+			}
+			n, err := m.client.ArchivedMachine.Delete().Where(archivedmachine.IDIn(ids...)).Exec(ctx)
+			if err != nil {
+				return result, fmt.Errorf("failed to purge archived machines: %w", err)
+			}
+			result.Purged += n
+		}
+	}
+	return result, nil
+}
+
+// removeCompleted archives or deletes the machines of def in a final state that have not changed
+// since cutoff.
+func (m *Manager) removeCompleted(ctx context.Context, def *Definition, cutoff time.Time, batch int, del bool, result *RetentionResult) error {
+	if len(def.Final) == 0 {
+		return nil
+	}
+	final := make([]string, len(def.Final))
+	for i, state := range def.Final {
+		final[i] = string(state)
+	}
+
+	for {
+		machines, err := m.client.StateMachine.Query().
+			Where(
+				statemachine.DefinitionName(def.Name),
+				statemachine.CurrentStateIn(final...),
+				statemachine.UpdatedAtLT(cutoff),
+			).
+			Order(ent.Asc(statemachine.FieldID)).
+			Limit(batch).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query completed machines of %s: %w", def.Name, err)
+		}
+		if len(machines) == 0 {
+			return nil
+		}
+
+		for _, sm := range machines {
+			remove, counter := archive, &result.Archived
+			if del {
+				remove, counter = deleteMachine, &result.Deleted
+			}
+			if err := m.removeMachine(ctx, sm, remove); err != nil {
+				return err
+			}
+			*counter++
+		}
+	}
+}
+
+// removeMachine runs remove on a machine in a transaction of its own.
+func (m *Manager) removeMachine(ctx context.Context, sm *ent.StateMachine, remove func(context.Context, *ent.Tx, *ent.StateMachine) error) error {
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if err := remove(ctx, tx, sm); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RunRetention applies p every interval until ctx is done or retention fails.
+func (m *Manager) RunRetention(ctx context.Context, p RetentionPolicy, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.ApplyRetention(ctx, p); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
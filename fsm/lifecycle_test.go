@@ -0,0 +1,228 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/archivedmachine"
+	"github.com/shinhauhuang/go-fsm/ent/archivedtransition"
+	"github.com/shinhauhuang/go-fsm/ent/processedevent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	m := NewManager(client)
+	def := &Definition{Name: "lifecycle", Initial: StateIdle, Final: []State{StateStopped}, Transitions: defineTestTransitions()}
+	if err := m.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	// newMachine creates a machine of the definition and fires events into it
+	newMachine := func(t *testing.T, id string, events ...Event) *FSM {
+		t.Helper()
+		f, err := m.NewFSM(ctx, "lifecycle", id)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		for _, event := range events {
+			if err := f.Transition(WithIdempotencyKey(ctx, id+string(event)), event); err != nil {
+				t.Fatalf("Transition failed: %v", err)
+			}
+		}
+		return f
+	}
+	exists := func(t *testing.T, id string) bool {
+		t.Helper()
+		ok, err := client.StateMachine.Query().Where(statemachine.MachineID(id)).Exist(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query machine: %v", err)
+		}
+		return ok
+	}
+	historyOf := func(t *testing.T, id string) int {
+		t.Helper()
+		n, err := client.StateTransition.Query().Where(statetransition.HasMachineWith(statemachine.MachineID(id))).Count(ctx)
+		if err != nil {
+			t.Fatalf("Failed to count history: %v", err)
+		}
+		return n
+	}
+	age := func(t *testing.T, id string, d time.Duration) {
+		t.Helper()
+		err := client.StateMachine.Update().Where(statemachine.MachineID(id)).SetUpdatedAt(time.Now().Add(-d)).Exec(ctx)
+		if err != nil {
+			t.Fatalf("Failed to age machine: %v", err)
+		}
+	}
+
+	t.Run("Reset", func(t *testing.T) {
+		f := newMachine(t, "lifecycle_reset", EventStart, EventPause)
+		client.StateMachine.Update().Where(statemachine.MachineID("lifecycle_reset")).SetData([]byte("{}")).ExecX(ctx)
+
+		if err := f.Reset(ctx, "", "alice"); err == nil {
+			t.Errorf("Expected error for reset without reason, got nil")
+		}
+		if err := f.Reset(ctx, "start over", "alice"); err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		sm := client.StateMachine.Query().Where(statemachine.MachineID("lifecycle_reset")).OnlyX(ctx)
+		if f.CurrentState() != StateIdle || State(sm.CurrentState) != StateIdle || sm.Data != nil {
+			t.Errorf("Expected machine to be idle without data, got %s, %q", sm.CurrentState, sm.Data)
+		}
+		last := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID("lifecycle_reset"))).
+			Order(ent.Desc(statetransition.FieldID)).
+			FirstX(ctx)
+		if last.Kind != statetransition.KindReset || last.FromState != string(StatePaused) || last.Actor != "alice" {
+			t.Errorf("Unexpected reset record %+v", last)
+		}
+		if n := historyOf(t, "lifecycle_reset"); n != 3 {
+			t.Errorf("Expected history to be kept, got %d records", n)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		newMachine(t, "lifecycle_delete", EventStart, EventStop)
+		if err := DeleteMachine(ctx, client, "lifecycle_delete"); err != nil {
+			t.Fatalf("DeleteMachine failed: %v", err)
+		}
+		if exists(t, "lifecycle_delete") {
+			t.Errorf("Expected machine to be deleted")
+		}
+		orphans, err := client.StateTransition.Query().Where(statetransition.Not(statetransition.HasMachine())).Count(ctx)
+		if err != nil || orphans != 0 {
+			t.Errorf("Expected no orphaned history, got %d, %v", orphans, err)
+		}
+		keys, err := client.ProcessedEvent.Query().Where(processedevent.KeyHasPrefix("lifecycle_delete")).Count(ctx)
+		if err != nil || keys != 0 {
+			t.Errorf("Expected idempotency keys to be deleted, got %d, %v", keys, err)
+		}
+		if err := DeleteMachine(ctx, client, "lifecycle_delete"); !ent.IsNotFound(err) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("Cascade", func(t *testing.T) {
+		newMachine(t, "lifecycle_cascade", EventStart)
+		if _, err := client.StateMachine.Delete().Where(statemachine.MachineID("lifecycle_cascade")).Exec(ctx); err != nil {
+			t.Fatalf("Failed to delete machine: %v", err)
+		}
+		orphans, err := client.StateTransition.Query().Where(statetransition.Not(statetransition.HasMachine())).Count(ctx)
+		if err != nil || orphans != 0 {
+			t.Errorf("Expected history to be deleted with its machine, got %d orphans, %v", orphans, err)
+		}
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		newMachine(t, "lifecycle_running", EventStart)
+		if err := m.Archive(ctx, "lifecycle_running"); !errors.Is(err, ErrNotCompleted) {
+			t.Errorf("Expected ErrNotCompleted, got %v", err)
+		}
+
+		newMachine(t, "lifecycle_archive", EventStart, EventStop)
+		if err := m.Archive(ctx, "lifecycle_archive"); err != nil {
+			t.Fatalf("Archive failed: %v", err)
+		}
+		if exists(t, "lifecycle_archive") {
+			t.Errorf("Expected machine to be moved out of state_machines")
+		}
+		archived, err := client.ArchivedMachine.Query().
+			Where(archivedmachine.MachineID("lifecycle_archive")).
+			WithHistory(func(q *ent.ArchivedTransitionQuery) { q.Order(ent.Asc(archivedtransition.FieldID)) }).
+			Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query archived machine: %v", err)
+		}
+		if State(archived.CurrentState) != StateStopped || archived.DefinitionName != "lifecycle" {
+			t.Errorf("Unexpected archived machine %+v", archived)
+		}
+		if h := archived.Edges.History; len(h) != 2 || h[0].Event != string(EventStart) || h[1].Kind != "transition" {
+			t.Errorf("Unexpected archived history %+v", h)
+		}
+	})
+
+	t.Run("Archive a reused machine ID", func(t *testing.T) {
+		newMachine(t, "lifecycle_reused", EventStart, EventStop)
+		if err := m.Archive(ctx, "lifecycle_reused"); err != nil {
+			t.Fatalf("Archive failed: %v", err)
+		}
+		f := newMachine(t, "lifecycle_reused", EventStart, EventStop)
+		if err := f.Suspend(ctx, "audit", "alice"); err != nil {
+			t.Fatalf("Suspend failed: %v", err)
+		}
+		if err := m.Archive(ctx, "lifecycle_reused"); err != nil {
+			t.Fatalf("Archive of the reused ID failed: %v", err)
+		}
+
+		archived, err := client.ArchivedMachine.Query().
+			Where(archivedmachine.MachineID("lifecycle_reused")).
+			Order(ent.Asc(archivedmachine.FieldID)).
+			All(ctx)
+		if err != nil || len(archived) != 2 {
+			t.Fatalf("Expected 2 archived machines, got %d, %v", len(archived), err)
+		}
+		last := archived[1]
+		if !last.Suspended || last.EnteredAt == nil || !last.EnteredAt.Equal(f.EnteredAt()) {
+			t.Errorf("Expected suspension and state entry time to be archived, got %+v", last)
+		}
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		newMachine(t, "lifecycle_old", EventStart, EventStop)
+		newMachine(t, "lifecycle_recent", EventStart, EventStop)
+		newMachine(t, "lifecycle_old_running", EventStart)
+		age(t, "lifecycle_old", 48*time.Hour)
+		age(t, "lifecycle_old_running", 48*time.Hour)
+
+		result, err := m.ApplyRetention(ctx, RetentionPolicy{CompletedFor: 24 * time.Hour, BatchSize: 1})
+		if err != nil {
+			t.Fatalf("ApplyRetention failed: %v", err)
+		}
+		if result != (RetentionResult{Archived: 1}) {
+			t.Errorf("Unexpected result %+v", result)
+		}
+		if exists(t, "lifecycle_old") || !exists(t, "lifecycle_recent") || !exists(t, "lifecycle_old_running") {
+			t.Errorf("Expected only the old completed machine to be archived")
+		}
+
+		age(t, "lifecycle_recent", 48*time.Hour)
+		result, err = m.ApplyRetention(ctx, RetentionPolicy{CompletedFor: 24 * time.Hour, Delete: true})
+		if err != nil {
+			t.Fatalf("ApplyRetention failed: %v", err)
+		}
+		if result != (RetentionResult{Deleted: 1}) || exists(t, "lifecycle_recent") {
+			t.Errorf("Expected the completed machine to be deleted, got %+v", result)
+		}
+		if ok, _ := client.ArchivedMachine.Query().Where(archivedmachine.MachineID("lifecycle_recent")).Exist(ctx); ok {
+			t.Errorf("Expected deleted machine not to be archived")
+		}
+
+		client.ArchivedMachine.Update().
+			Where(archivedmachine.MachineID("lifecycle_old")).
+			SetArchivedAt(time.Now().Add(-100 * 24 * time.Hour)).
+			ExecX(ctx)
+		result, err = m.ApplyRetention(ctx, RetentionPolicy{PurgeArchivedAfter: 90 * 24 * time.Hour})
+		if err != nil {
+			t.Fatalf("ApplyRetention failed: %v", err)
+		}
+		if result != (RetentionResult{Purged: 1}) {
+			t.Errorf("Unexpected result %+v", result)
+		}
+		remaining, _ := client.ArchivedMachine.Query().Where(archivedmachine.MachineID("lifecycle_archive")).Exist(ctx)
+		if !remaining {
+			t.Errorf("Expected recent archives to be kept")
+		}
+		orphans, _ := client.ArchivedTransition.Query().Where(archivedtransition.Not(archivedtransition.HasMachine())).Count(ctx)
+		if orphans != 0 {
+			t.Errorf("Expected purged history to be deleted, got %d orphans", orphans)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package fsm
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Phase identifies a step of the transition lifecycle.
+type Phase string
+
+const (
+	// PhaseBeforeGuard is reported once the transition has been found, before guards are evaluated.
+	PhaseBeforeGuard Phase = "before_guard"
+	// PhaseGuardRejected is reported when a guard denies the transition.
+	PhaseGuardRejected Phase = "guard_rejected"
+	// PhaseExit is reported after the exit actions of the source state have run.
+	PhaseExit Phase = "exit"
+	// PhaseTransition is reported after the transition callbacks have run and the state has changed.
+	PhaseTransition Phase = "transition"
+	// PhaseEntry is reported after the entry actions of the target state have run.
+	PhaseEntry Phase = "entry"
+	// PhasePersisted is reported after the new state and its history record have been committed.
+	// It is not reported when the transition joined a transaction of the caller, which commits it.
+	PhasePersisted Phase = "persisted"
+	// PhaseFailed is reported when Transition returns an error.
+	PhaseFailed Phase = "failed"
+)
+
+// Notification describes one lifecycle step of a Transition call.
+type Notification struct {
+	MachineID string
+	Phase     Phase
+	Event     Event
+	From      State
+	To        State // Empty when the event has no transition from From
+	Args      []interface{}
+	Time      time.Time     // When the phase completed
+	Duration  time.Duration // Time spent in the phase; for PhaseFailed, the time since the call started
+	Err       error         // Cause of the failure for PhaseFailed and PhaseGuardRejected
+}
+
+// Listener receives lifecycle notifications. Listeners run synchronously while the machine is
+// locked, so they must not call methods of the machine that sent the notification.
+type Listener func(ctx context.Context, n Notification)
+
+// Handle is returned by registration methods and undoes the registration when removed.
+type Handle struct {
+	once   sync.Once
+	remove func()
+}
+
+// Remove undoes the registration. It is safe to call more than once.
+func (h *Handle) Remove() {
+	h.once.Do(h.remove)
+}
+
+// listenerSet is an ordered, concurrency-safe collection of listeners.
+type listenerSet struct {
+	mu        sync.RWMutex
+	nextID    uint64
+	listeners []registeredListener
+}
+
+// registeredListener pairs a listener with the ID used to remove it.
+type registeredListener struct {
+	id       uint64
+	listener Listener
+}
+
+// add registers a listener and returns the handle that removes it.
+func (s *listenerSet) add(l Listener) *Handle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := s.nextID
+	s.listeners = append(s.listeners, registeredListener{id: id, listener: l})
+	return &Handle{remove: func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, r := range s.listeners {
+			if r.id == id {
+				s.listeners = append(s.listeners[:i:i], s.listeners[i+1:]...)
+				return
+			}
+		}
+	}}
+}
+
+// notify calls every listener in registration order.
+func (s *listenerSet) notify(ctx context.Context, n Notification) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, r := range listeners {
+		r.listener(ctx, n)
+	}
+}
+
+// globalListeners receive notifications from every FSM.
+var globalListeners listenerSet
+
+// AddGlobalListener registers a listener that is notified by every FSM in the process.
+func AddGlobalListener(l Listener) *Handle {
+	return globalListeners.add(l)
+}
+
+// AddListener registers a listener that is notified by this FSM only.
+func (f *FSM) AddListener(l Listener) *Handle {
+	return f.listeners.add(l)
+}
+
+// notify sends a notification to the global listeners, then to the FSM's own listeners.
+func (f *FSM) notify(ctx context.Context, n Notification) {
+	n.MachineID = f.machineID
+	n.Time = time.Now()
+	globalListeners.notify(ctx, n)
+	f.listeners.notify(ctx, n)
+}
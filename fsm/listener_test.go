@@ -0,0 +1,163 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+)
+
+// recordPhases returns a listener appending the phases it receives to phases.
+func recordPhases(phases *[]Phase) Listener {
+	return func(ctx context.Context, n Notification) {
+		*phases = append(*phases, n.Phase)
+	}
+}
+
+func TestListeners(t *testing.T) {
+	ctx := context.Background()
+	transitions := defineTestTransitions()
+
+	t.Run("Successful transition with persistence", func(t *testing.T) {
+		client := setupTestClient(t)
+		defer client.Close()
+
+		f, err := NewFSM(ctx, client, "listener_machine_1", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var phases []Phase
+		var last Notification
+		f.AddListener(recordPhases(&phases))
+		f.AddListener(func(ctx context.Context, n Notification) { last = n })
+
+		if err := f.Transition(ctx, EventStart, "arg"); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+
+		expected := []Phase{PhaseBeforeGuard, PhaseExit, PhaseTransition, PhaseEntry, PhasePersisted}
+		if !reflect.DeepEqual(phases, expected) {
+			t.Errorf("Expected phases %v, got %v", expected, phases)
+		}
+		if last.MachineID != "listener_machine_1" || last.From != StateIdle || last.To != StateRunning ||
+			last.Event != EventStart || !reflect.DeepEqual(last.Args, []interface{}{"arg"}) || last.Time.IsZero() {
+			t.Errorf("Unexpected notification: %+v", last)
+		}
+	})
+
+	t.Run("Transition joining a caller transaction", func(t *testing.T) {
+		client := setupTestClient(t)
+		defer client.Close()
+
+		f, err := NewFSM(ctx, client, "listener_machine_2", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		var phases []Phase
+		f.AddListener(recordPhases(&phases))
+
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to start transaction: %v", err)
+		}
+		defer tx.Rollback()
+		if err := f.Transition(ent.NewTxContext(ctx, tx), EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+
+		expected := []Phase{PhaseBeforeGuard, PhaseExit, PhaseTransition, PhaseEntry}
+		if !reflect.DeepEqual(phases, expected) {
+			t.Errorf("Expected phases %v, got %v", expected, phases)
+		}
+	})
+
+	t.Run("Guard rejection", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.AddGuard(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) bool { return false })
+
+		var phases []Phase
+		var failure Notification
+		f.AddListener(recordPhases(&phases))
+		f.AddListener(func(ctx context.Context, n Notification) {
+			if n.Phase == PhaseFailed {
+				failure = n
+			}
+		})
+
+		f.Transition(ctx, EventStart)
+		expected := []Phase{PhaseBeforeGuard, PhaseGuardRejected, PhaseFailed}
+		if !reflect.DeepEqual(phases, expected) {
+			t.Errorf("Expected phases %v, got %v", expected, phases)
+		}
+		if !errors.Is(failure.Err, ErrTransitionDenied) {
+			t.Errorf("Expected ErrTransitionDenied in failure notification, got %v", failure.Err)
+		}
+	})
+
+	t.Run("Failing entry action", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			return errors.New("entry action error")
+		})
+
+		var phases []Phase
+		f.AddListener(recordPhases(&phases))
+
+		f.Transition(ctx, EventStart)
+		expected := []Phase{PhaseBeforeGuard, PhaseExit, PhaseTransition, PhaseFailed}
+		if !reflect.DeepEqual(phases, expected) {
+			t.Errorf("Expected phases %v, got %v", expected, phases)
+		}
+	})
+
+	t.Run("Invalid event", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var phases []Phase
+		f.AddListener(recordPhases(&phases))
+
+		f.Transition(ctx, EventPause)
+		if !reflect.DeepEqual(phases, []Phase{PhaseFailed}) {
+			t.Errorf("Expected only %s, got %v", PhaseFailed, phases)
+		}
+	})
+
+	t.Run("Global listeners and unsubscribing", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var global, local []Phase
+		globalHandle := AddGlobalListener(recordPhases(&global))
+		defer globalHandle.Remove()
+		localHandle := f.AddListener(recordPhases(&local))
+
+		f.Transition(ctx, EventStart)
+		if len(global) == 0 || !reflect.DeepEqual(global, local) {
+			t.Errorf("Expected global and instance listeners to see the same phases, got %v and %v", global, local)
+		}
+
+		globalHandle.Remove()
+		localHandle.Remove()
+		localHandle.Remove() // Removing twice is harmless
+		global, local = nil, nil
+
+		f.Transition(ctx, EventPause)
+		if len(global) != 0 || len(local) != 0 {
+			t.Errorf("Expected no notifications after removal, got %v and %v", global, local)
+		}
+	})
+}
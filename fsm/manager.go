@@ -0,0 +1,115 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+// Manager creates and loads persisted machines that share a database client, a registry of
+// definitions and a middleware chain. It also runs the async actions enqueued by its machines.
+type Manager struct {
+	client       *ent.Client
+	mu           sync.RWMutex
+	definitions  map[string]*Definition
+	middlewares  []Middleware
+	asyncActions map[string]*asyncAction
+}
+
+// NewManager creates a Manager backed by the given Ent client.
+func NewManager(client *ent.Client) *Manager {
+	return &Manager{
+		client:       client,
+		definitions:  make(map[string]*Definition),
+		asyncActions: make(map[string]*asyncAction),
+	}
+}
+
+// Client returns the Ent client of the manager.
+func (m *Manager) Client() *ent.Client {
+	return m.client
+}
+
+// Use appends middlewares that wrap every machine created or loaded by the manager.
+// They run outside the middlewares of the definition and of the machine itself.
+func (m *Manager) Use(middlewares ...Middleware) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.middlewares = append(m.middlewares, middlewares...)
+}
+
+// Register adds a named definition to the registry, replacing any previous definition with the same name.
+func (m *Manager) Register(def *Definition) error {
+	if def.Name == "" {
+		return errors.New("only named definitions can be registered")
+	}
+	if err := def.Validate(); err != nil {
+		return fmt.Errorf("invalid definition %s: %w", def.Name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.definitions[def.Name] = def
+	return nil
+}
+
+// Definition returns the registered definition with the given name.
+func (m *Manager) Definition(name string) (*Definition, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	def, ok := m.definitions[name]
+	return def, ok
+}
+
+// NewFSM creates a machine from the registered definition with the given name, loading it if it already exists.
+func (m *Manager) NewFSM(ctx context.Context, definitionName, machineID string) (*FSM, error) {
+	def, ok := m.Definition(definitionName)
+	if !ok {
+		return nil, fmt.Errorf("definition %s is not registered", definitionName)
+	}
+
+	f, err := NewFSMFromDefinition(ctx, m.client, machineID, def)
+	if err != nil {
+		return nil, err
+	}
+	m.attach(f)
+	return f, nil
+}
+
+// LoadFSM loads an existing machine using the registered definition recorded on its row.
+func (m *Manager) LoadFSM(ctx context.Context, machineID string) (*FSM, error) {
+	sm, err := m.client.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query state machine with ID %s: %w", machineID, err)
+	}
+
+	def, ok := m.Definition(sm.DefinitionName)
+	if !ok {
+		return nil, fmt.Errorf("%w: machine %s uses definition %q, which is not registered",
+			ErrIncompatibleDefinition, machineID, sm.DefinitionName)
+	}
+
+	f, err := LoadFSMFromDefinition(ctx, m.client, machineID, def)
+	if err != nil {
+		return nil, err
+	}
+	m.attach(f)
+	return f, nil
+}
+
+// attach installs the manager's middlewares on a machine ahead of any others.
+func (m *Manager) attach(f *FSM) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.middlewares) == 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.outerMiddlewares = append([]Middleware(nil), m.middlewares...)
+}
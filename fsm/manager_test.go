@@ -0,0 +1,89 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	var calls []string
+	entered := 0
+	def := &Definition{
+		Name:        "managed",
+		Version:     1,
+		Initial:     StateIdle,
+		Transitions: defineTestTransitions(),
+		Middlewares: []Middleware{recordMiddleware("definition", &calls)},
+		Setup: func(f *FSM) error {
+			f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+				entered++
+				return nil
+			})
+			return nil
+		},
+	}
+
+	m := NewManager(client)
+	m.Use(recordMiddleware("manager", &calls))
+	if err := m.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	t.Run("Register requires a name", func(t *testing.T) {
+		if err := m.Register(&Definition{Transitions: defineTestTransitions()}); err == nil {
+			t.Errorf("Expected error for unnamed definition, got nil")
+		}
+	})
+
+	t.Run("New machine", func(t *testing.T) {
+		f, err := m.NewFSM(ctx, "managed", "managed_machine_1")
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Use(recordMiddleware("instance", &calls))
+
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		expected := []string{"manager", "definition", "instance"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected middleware order %v, got %v", expected, calls)
+		}
+		if entered != 1 {
+			t.Errorf("Expected entry action registered by Setup to run once, ran %d times", entered)
+		}
+	})
+
+	t.Run("Load machine by recorded definition", func(t *testing.T) {
+		f, err := m.LoadFSM(ctx, "managed_machine_1")
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		if f.CurrentState() != StateRunning {
+			t.Errorf("Expected state %s, got %s", StateRunning, f.CurrentState())
+		}
+		if f.Definition() != def {
+			t.Errorf("Expected registered definition to be used")
+		}
+	})
+
+	t.Run("Unknown definitions", func(t *testing.T) {
+		if _, err := m.NewFSM(ctx, "unknown", "managed_machine_2"); err == nil {
+			t.Errorf("Expected error for unregistered definition, got nil")
+		}
+
+		if _, err := NewFSM(ctx, client, "unmanaged_machine", StateIdle, defineTestTransitions()); err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		_, err := m.LoadFSM(ctx, "unmanaged_machine")
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPanic is returned by the Recover middleware when dispatching an event panicked.
+	ErrPanic = errors.New("panic during transition")
+	// ErrUnauthorized is returned by the Authorize middleware when a request is rejected.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited is returned by the RateLimit middleware when a machine receives events too fast.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// Request describes an event dispatched to a machine through the middleware chain.
+type Request struct {
+	Machine *FSM
+	Event   Event
+	Args    []interface{}
+}
+
+// Handler dispatches a request. The innermost handler performs the transition.
+type Handler func(ctx context.Context, req Request) error
+
+// Middleware wraps a Handler with cross-cutting behaviour. A middleware may short-circuit by
+// returning an error without calling next, or call next with an enriched context or request.
+type Middleware func(next Handler) Handler
+
+// Chain composes middlewares into one; the first middleware is the outermost.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// Use appends middlewares to the FSM's chain. They run inside the middlewares of the definition.
+func (f *FSM) Use(middlewares ...Middleware) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.middlewares = append(f.middlewares, middlewares...)
+}
+
+// dispatchHandler is the innermost handler, performing the transition itself.
+func dispatchHandler(ctx context.Context, req Request) error {
+	return req.Machine.dispatch(ctx, req.Event, req.Args...)
+}
+
+// Recover converts a panic raised while dispatching an event into an error wrapping ErrPanic.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w: event %s: %v", ErrPanic, req.Event, r)
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
+
+// Timeout bounds the context passed down the chain to d.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, req)
+		}
+	}
+}
+
+// Authorize rejects requests for which check returns an error. The error is wrapped with ErrUnauthorized.
+func Authorize(check func(ctx context.Context, req Request) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) error {
+			if err := check(ctx, req); err != nil {
+				return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// Trace calls start before each dispatch and the returned function with the result afterwards.
+// It is meant for tracing integrations: start may return a context carrying a span.
+func Trace(start func(ctx context.Context, req Request) (context.Context, func(err error))) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) error {
+			ctx, end := start(ctx, req)
+			err := next(ctx, req)
+			end(err)
+			return err
+		}
+	}
+}
+
+// RateLimit allows each machine at most burst events at once, refilled at perSecond events per second.
+// Requests over the limit fail with ErrRateLimited. Machines are told apart by machine ID; the bucket
+// of a machine is dropped once it has refilled, so idle machines do not use memory.
+func RateLimit(perSecond float64, burst int) Middleware {
+	l := &rateLimiter{rate: perSecond, burst: float64(burst), buckets: make(map[string]*tokenBucket)}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) error {
+			if !l.allow(req.Machine.machineID, time.Now()) {
+				return fmt.Errorf("%w: machine %s", ErrRateLimited, req.Machine.machineID)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// rateLimiter holds the token buckets of the machines seen by a RateLimit middleware.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// allow consumes a token from the bucket of the machine, if available.
+func (l *rateLimiter) allow(machineID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	b, ok := l.buckets[machineID]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[machineID] = b
+	}
+	return b.take(l.rate, l.burst, now)
+}
+
+// sweep drops the buckets that have been idle long enough to refill, as a new bucket would be
+// identical. It scans the buckets at most once per refill period.
+func (l *rateLimiter) sweep(now time.Time) {
+	if l.rate <= 0 {
+		return // Buckets never refill
+	}
+	idle := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.lastSweep) < idle {
+		return
+	}
+	l.lastSweep = now
+	for id, b := range l.buckets {
+		if now.Sub(b.last) >= idle {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// tokenBucket is the state of a single machine's rate limit.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket up to now and consumes one token if available.
+func (b *tokenBucket) take(rate, burst float64, now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
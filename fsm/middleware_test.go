@@ -0,0 +1,182 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type contextKey string
+
+// recordMiddleware returns a middleware appending its name to calls on the way in.
+func recordMiddleware(name string, calls *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) error {
+			*calls = append(*calls, name)
+			return next(ctx, req)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Definition middlewares run before instance middlewares", func(t *testing.T) {
+		var calls []string
+		def := &Definition{
+			Initial:     StateIdle,
+			Transitions: defineTestTransitions(),
+			Middlewares: []Middleware{recordMiddleware("definition", &calls)},
+		}
+		f, err := NewFSMFromDefinition(ctx, nil, "", def)
+		if err != nil {
+			t.Fatalf("NewFSMFromDefinition failed: %v", err)
+		}
+		f.Use(recordMiddleware("first", &calls), recordMiddleware("second", &calls))
+
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		expected := []string{"definition", "first", "second"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected middleware order %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("Short-circuit", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Use(Authorize(func(ctx context.Context, req Request) error {
+			return errors.New("not allowed")
+		}))
+
+		err = f.Transition(ctx, EventStart)
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("Expected ErrUnauthorized, got %v", err)
+		}
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state %s, got %s", StateIdle, f.CurrentState())
+		}
+	})
+
+	t.Run("Enrich context and arguments", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Use(func(next Handler) Handler {
+			return func(ctx context.Context, req Request) error {
+				req.Args = append(req.Args, "added")
+				return next(context.WithValue(ctx, contextKey("user"), "alice"), req)
+			}
+		})
+
+		var gotUser interface{}
+		var gotArgs []interface{}
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			gotUser = ctx.Value(contextKey("user"))
+			gotArgs = args
+			return nil
+		})
+
+		if err := f.Transition(ctx, EventStart, "original"); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if gotUser != "alice" || !reflect.DeepEqual(gotArgs, []interface{}{"original", "added"}) {
+			t.Errorf("Expected enriched context and args, got %v and %v", gotUser, gotArgs)
+		}
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Use(Recover())
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			panic("boom")
+		})
+
+		err = f.Transition(ctx, EventStart)
+		if !errors.Is(err, ErrPanic) {
+			t.Errorf("Expected ErrPanic, got %v", err)
+		}
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state to be reverted to %s, got %s", StateIdle, f.CurrentState())
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Use(Timeout(time.Minute))
+
+		var hasDeadline bool
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			_, hasDeadline = ctx.Deadline()
+			return nil
+		})
+		f.Transition(ctx, EventStart)
+		if !hasDeadline {
+			t.Errorf("Expected context with deadline")
+		}
+	})
+
+	t.Run("Trace", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+
+		var started Event
+		var ended error = errors.New("not called")
+		f.Use(Trace(func(ctx context.Context, req Request) (context.Context, func(error)) {
+			started = req.Event
+			return ctx, func(err error) { ended = err }
+		}))
+
+		f.Transition(ctx, EventStart)
+		if started != EventStart || ended != nil {
+			t.Errorf("Expected trace of %s ending without error, got %s and %v", EventStart, started, ended)
+		}
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		f, err := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.Use(RateLimit(0.001, 2))
+
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("First transition failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventPause); err != nil {
+			t.Fatalf("Second transition failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventResume); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("Expected ErrRateLimited, got %v", err)
+		}
+	})
+
+	t.Run("RateLimit drops refilled buckets", func(t *testing.T) {
+		l := &rateLimiter{rate: 1, burst: 2, buckets: make(map[string]*tokenBucket)}
+		now := time.Now()
+		l.allow("a", now)
+		l.allow("b", now.Add(time.Second))
+
+		l.allow("b", now.Add(2*time.Second))
+		if _, ok := l.buckets["a"]; ok {
+			t.Errorf("Expected the idle bucket to be dropped")
+		}
+		if _, ok := l.buckets["b"]; !ok {
+			t.Errorf("Expected the active bucket to be kept")
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// checkCompatible verifies that a persisted machine can be driven by the given definition.
+// Machines recorded under another definition name or version, recorded under a name but loaded
+// without one, or sitting in a state the definition does not know, are rejected with
+// ErrIncompatibleDefinition.
+func checkCompatible(sm *ent.StateMachine, def *Definition) error {
+	if sm.DefinitionName != "" {
+		if def.Name == "" {
+			return fmt.Errorf("%w: machine %s was created with definition %s and must be loaded with it",
+				ErrIncompatibleDefinition, sm.MachineID, sm.DefinitionName)
+		}
+		if sm.DefinitionName != def.Name {
+			return fmt.Errorf("%w: machine %s was created with definition %s, not %s",
+				ErrIncompatibleDefinition, sm.MachineID, sm.DefinitionName, def.Name)
+		}
+		if sm.DefinitionVersion != def.Version {
+			return fmt.Errorf("%w: machine %s is at version %d of definition %s, not version %d",
+				ErrIncompatibleDefinition, sm.MachineID, sm.DefinitionVersion, def.Name, def.Version)
+		}
+	}
+	if len(def.Transitions) > 0 && !def.HasState(State(sm.CurrentState)) {
+		return fmt.Errorf("%w: machine %s is in state %s which does not exist in the definition",
+			ErrIncompatibleDefinition, sm.MachineID, sm.CurrentState)
+	}
+	return nil
+}
+
+// adopt records a named definition on a machine created without one, the first time the machine
+// is loaded with it, so that version checks and Migrate apply to the machine from then on.
+func adopt(ctx context.Context, client *ent.Client, sm *ent.StateMachine, def *Definition) error {
+	if sm.DefinitionName != "" || def.Name == "" {
+		return nil
+	}
+	err := client.StateMachine.UpdateOne(sm).
+		SetDefinitionName(def.Name).
+		SetDefinitionVersion(def.Version).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record definition %s on machine %s: %w", def.Name, sm.MachineID, err)
+	}
+	return nil
+}
+
+// Migration describes how persisted machines move from one version of a definition to the next.
+type Migration struct {
+	From     *Definition     // Definition the machines are currently recorded under
+	To       *Definition     // Definition the machines are moved to
+	StateMap map[State]State // Target state for states that are renamed or removed in To
+	// Unnamed also migrates the machines recorded without a definition name, such as machines
+	// created before definitions were named and never loaded with one since.
+	Unnamed bool
+}
+
+// target returns the state a machine in the given state is moved to.
+func (m *Migration) target(state State) (State, error) {
+	if to, ok := m.StateMap[state]; ok {
+		return to, nil
+	}
+	if m.To.HasState(state) {
+		return state, nil
+	}
+	return "", fmt.Errorf("%w: no mapping for state %s, which does not exist in version %d",
+		ErrIncompatibleDefinition, state, m.To.Version)
+}
+
+// validate checks the migration before any machine is touched.
+func (m *Migration) validate() error {
+	if m.From == nil || m.To == nil {
+		return errors.New("migration requires both a source and a target definition")
+	}
+	if m.From.Name == "" || m.From.Name != m.To.Name {
+		return fmt.Errorf("migration must be between versions of the same named definition, got %q and %q", m.From.Name, m.To.Name)
+	}
+	if m.To.Version <= m.From.Version {
+		return fmt.Errorf("migration target version %d must be greater than source version %d", m.To.Version, m.From.Version)
+	}
+	if err := m.To.Validate(); err != nil {
+		return fmt.Errorf("invalid target definition: %w", err)
+	}
+	for from, to := range m.StateMap {
+		if !m.To.HasState(to) {
+			return fmt.Errorf("state map sends %s to %s, which does not exist in version %d", from, to, m.To.Version)
+		}
+	}
+	return nil
+}
+
+// Migrate moves every machine recorded under m.From to m.To in a single transaction.
+// Each machine's state is mapped through m.StateMap (or kept when it still exists) and a
+// migration history entry is written for it. It returns the number of machines migrated.
+func Migrate(ctx context.Context, client *ent.Client, m Migration) (n int, err error) {
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		} else if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	selected := statemachine.And(
+		statemachine.DefinitionName(m.From.Name),
+		statemachine.DefinitionVersion(m.From.Version),
+	)
+	if m.Unnamed {
+		selected = statemachine.Or(selected, statemachine.DefinitionName(""))
+	}
+	machines, err := tx.StateMachine.Query().Where(selected).All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query machines to migrate: %w", err)
+	}
+
+	reason := fmt.Sprintf("migrated %s from version %d to %d", m.From.Name, m.From.Version, m.To.Version)
+	for _, sm := range machines {
+		from := State(sm.CurrentState)
+		to, err := m.target(from)
+		if err != nil {
+			return 0, fmt.Errorf("machine %s: %w", sm.MachineID, err)
+		}
+
+		_, err = tx.StateTransition.Create().
+			SetFromState(string(from)).
+			SetToState(string(to)).
+			SetEvent("").
+			SetKind(statetransition.KindMigration).
+			SetReason(reason).
+			SetMachine(sm).
+			Save(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record migration of machine %s: %w", sm.MachineID, err)
+		}
+
+		_, err = sm.Update().
+			SetCurrentState(string(to)).
+			SetDefinitionName(m.To.Name).
+			SetDefinitionVersion(m.To.Version).
+			Save(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to migrate machine %s: %w", sm.MachineID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return len(machines), nil
+}
@@ -0,0 +1,211 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// defineVersionedDefinitions returns two versions of a workflow where v2 removes StatePaused.
+func defineVersionedDefinitions() (*Definition, *Definition) {
+	v1 := &Definition{
+		Name:        "worker",
+		Version:     1,
+		Initial:     StateIdle,
+		Transitions: defineTestTransitions(),
+	}
+	v2 := &Definition{
+		Name:    "worker",
+		Version: 2,
+		Initial: StateIdle,
+		Transitions: []Transition{
+			{From: StateIdle, Event: EventStart, To: StateRunning},
+			{From: StateRunning, Event: EventStop, To: StateStopped},
+		},
+	}
+	return v1, v2
+}
+
+func TestDefinitionCompatibility(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+	v1, v2 := defineVersionedDefinitions()
+
+	t.Run("New machine records definition", func(t *testing.T) {
+		machineID := "versioned_machine_1"
+		if _, err := NewFSMFromDefinition(ctx, client, machineID, v1); err != nil {
+			t.Fatalf("NewFSMFromDefinition failed: %v", err)
+		}
+
+		sm, err := client.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query state machine from DB: %v", err)
+		}
+		if sm.DefinitionName != "worker" || sm.DefinitionVersion != 1 {
+			t.Errorf("Expected definition worker v1, got %s v%d", sm.DefinitionName, sm.DefinitionVersion)
+		}
+	})
+
+	t.Run("Load with a different version is refused", func(t *testing.T) {
+		_, err := LoadFSMFromDefinition(ctx, client, "versioned_machine_1", v2)
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition, got %v", err)
+		}
+		_, err = NewFSMFromDefinition(ctx, client, "versioned_machine_1", v2)
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition from NewFSMFromDefinition, got %v", err)
+		}
+	})
+
+	t.Run("Load with a different name is refused", func(t *testing.T) {
+		other := &Definition{Name: "other", Version: 1, Initial: StateIdle, Transitions: defineTestTransitions()}
+		_, err := LoadFSMFromDefinition(ctx, client, "versioned_machine_1", other)
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition, got %v", err)
+		}
+	})
+
+	t.Run("Load without a definition name is refused", func(t *testing.T) {
+		_, err := LoadFSM(ctx, client, "versioned_machine_1", defineTestTransitions())
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition, got %v", err)
+		}
+	})
+
+	t.Run("Unnamed machine adopts the definition it is loaded with", func(t *testing.T) {
+		machineID := "versioned_machine_unnamed"
+		if _, err := NewFSM(ctx, client, machineID, StateIdle, defineTestTransitions()); err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if _, err := LoadFSMFromDefinition(ctx, client, machineID, v1); err != nil {
+			t.Fatalf("LoadFSMFromDefinition failed: %v", err)
+		}
+		sm := client.StateMachine.Query().Where(statemachine.MachineID(machineID)).OnlyX(ctx)
+		if sm.DefinitionName != "worker" || sm.DefinitionVersion != 1 {
+			t.Errorf("Expected definition worker v1 to be recorded, got %q v%d", sm.DefinitionName, sm.DefinitionVersion)
+		}
+		if _, err := LoadFSMFromDefinition(ctx, client, machineID, v2); !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition once adopted, got %v", err)
+		}
+	})
+
+	t.Run("Load machine in unknown state is refused", func(t *testing.T) {
+		machineID := "versioned_machine_2"
+		_, err := client.StateMachine.Create().
+			SetMachineID(machineID).
+			SetCurrentState("removed").
+			Save(ctx)
+		if err != nil {
+			t.Fatalf("Failed to pre-create state machine: %v", err)
+		}
+
+		_, err = LoadFSM(ctx, client, machineID, defineTestTransitions())
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Errorf("Expected ErrIncompatibleDefinition, got %v", err)
+		}
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+	v1, v2 := defineVersionedDefinitions()
+
+	paused, err := NewFSMFromDefinition(ctx, client, "migrate_machine_paused", v1)
+	if err != nil {
+		t.Fatalf("NewFSMFromDefinition failed: %v", err)
+	}
+	if err := paused.Transition(ctx, EventStart); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if err := paused.Transition(ctx, EventPause); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if _, err := NewFSMFromDefinition(ctx, client, "migrate_machine_idle", v1); err != nil {
+		t.Fatalf("NewFSMFromDefinition failed: %v", err)
+	}
+
+	t.Run("Missing mapping for removed state", func(t *testing.T) {
+		_, err := Migrate(ctx, client, Migration{From: v1, To: v2})
+		if !errors.Is(err, ErrIncompatibleDefinition) {
+			t.Fatalf("Expected ErrIncompatibleDefinition, got %v", err)
+		}
+		// Nothing must have been migrated
+		count, err := client.StateMachine.Query().Where(statemachine.DefinitionVersion(2)).Count(ctx)
+		if err != nil {
+			t.Fatalf("Failed to count machines: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("Expected no migrated machines after failure, got %d", count)
+		}
+	})
+
+	t.Run("Invalid migrations", func(t *testing.T) {
+		if _, err := Migrate(ctx, client, Migration{From: v2, To: v1}); err == nil {
+			t.Errorf("Expected error for downgrade, got nil")
+		}
+		_, err := Migrate(ctx, client, Migration{From: v1, To: v2, StateMap: map[State]State{StatePaused: "unknown"}})
+		if err == nil {
+			t.Errorf("Expected error for mapping to unknown state, got nil")
+		}
+	})
+
+	t.Run("Successful migration", func(t *testing.T) {
+		n, err := Migrate(ctx, client, Migration{From: v1, To: v2, StateMap: map[State]State{StatePaused: StateRunning}})
+		if err != nil {
+			t.Fatalf("Migrate failed: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("Expected 2 migrated machines, got %d", n)
+		}
+
+		f, err := LoadFSMFromDefinition(ctx, client, "migrate_machine_paused", v2)
+		if err != nil {
+			t.Fatalf("LoadFSMFromDefinition failed after migration: %v", err)
+		}
+		if f.CurrentState() != StateRunning {
+			t.Errorf("Expected migrated state %s, got %s", StateRunning, f.CurrentState())
+		}
+
+		record, err := client.StateTransition.Query().
+			Where(
+				statetransition.HasMachineWith(statemachine.MachineID("migrate_machine_paused")),
+				statetransition.KindEQ(statetransition.KindMigration),
+			).
+			Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query migration history: %v", err)
+		}
+		if record.FromState != string(StatePaused) || record.ToState != string(StateRunning) || record.Reason == "" {
+			t.Errorf("Unexpected migration history record: %+v", record)
+		}
+	})
+
+	t.Run("Unnamed machines", func(t *testing.T) {
+		err := client.StateMachine.Create().
+			SetMachineID("migrate_machine_unnamed").
+			SetCurrentState(string(StatePaused)).
+			Exec(ctx)
+		if err != nil {
+			t.Fatalf("Failed to create unnamed machine: %v", err)
+		}
+		migration := Migration{From: v1, To: v2, StateMap: map[State]State{StatePaused: StateRunning}}
+		if n, err := Migrate(ctx, client, migration); err != nil || n != 0 {
+			t.Fatalf("Expected unnamed machines to be left alone, got %d, %v", n, err)
+		}
+
+		migration.Unnamed = true
+		if n, err := Migrate(ctx, client, migration); err != nil || n != 1 {
+			t.Fatalf("Expected 1 migrated machine, got %d, %v", n, err)
+		}
+		sm := client.StateMachine.Query().Where(statemachine.MachineID("migrate_machine_unnamed")).OnlyX(ctx)
+		if sm.DefinitionName != "worker" || sm.DefinitionVersion != 2 || State(sm.CurrentState) != StateRunning {
+			t.Errorf("Unexpected migrated machine %+v", sm)
+		}
+	})
+}
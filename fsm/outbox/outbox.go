@@ -0,0 +1,63 @@
+// Package outbox delivers messages written in the same transaction as a state change.
+//
+// Actions call Enqueue with the context they receive from Transition, so the message is committed
+// or rolled back together with the new state. A Relay then reads pending messages and hands them
+// to a Publisher, retrying failed deliveries with a backoff. Delivery is at least once: a message
+// may be published again if the relay stops between publishing it and recording the delivery, so
+// consumers should deduplicate by message ID.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+)
+
+// ErrNoTransaction is returned by Enqueue when the context carries no transaction.
+var ErrNoTransaction = errors.New("no transaction in context")
+
+// Message is a message to deliver.
+type Message struct {
+	ID        int // Assigned when the message is enqueued
+	Topic     string
+	Key       string // Messages with the same non-empty key are delivered in order
+	Payload   []byte
+	Attempts  int // Number of failed deliveries
+	CreatedAt time.Time
+}
+
+// Enqueue writes m to the outbox in the transaction carried by ctx, as set up by fsm.Transition
+// for the actions of persistent machines.
+func Enqueue(ctx context.Context, m Message) error {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return ErrNoTransaction
+	}
+	if m.Topic == "" {
+		return errors.New("outbox message requires a topic")
+	}
+	err := tx.OutboxMessage.Create().
+		SetTopic(m.Topic).
+		SetKey(m.Key).
+		SetPayload(m.Payload).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+	return nil
+}
+
+// fromEntity converts a stored message.
+func fromEntity(m *ent.OutboxMessage) *Message {
+	return &Message{
+		ID:        m.ID,
+		Topic:     m.Topic,
+		Key:       m.Key,
+		Payload:   m.Payload,
+		Attempts:  m.Attempts,
+		CreatedAt: m.CreatedAt,
+	}
+}
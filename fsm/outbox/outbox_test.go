@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/enttest"
+	"github.com/shinhauhuang/go-fsm/ent/outboxmessage"
+	"github.com/shinhauhuang/go-fsm/fsm"
+
+	_ "github.com/mattn/go-sqlite3" // Driver for SQLite
+)
+
+func setupTestClient(t *testing.T) *ent.Client {
+	return enttest.Open(t, "sqlite3", "file:outbox?mode=memory&cache=shared&_fk=1")
+}
+
+var testTransitions = []fsm.Transition{
+	{From: "pending", Event: "pay", To: "paid"},
+	{From: "paid", Event: "ship", To: "shipped"},
+}
+
+func TestEnqueue(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	count := func(t *testing.T, topic string) int {
+		t.Helper()
+		n, err := client.OutboxMessage.Query().Where(outboxmessage.Topic(topic)).Count(ctx)
+		if err != nil {
+			t.Fatalf("Failed to count messages: %v", err)
+		}
+		return n
+	}
+
+	f, err := fsm.NewFSM(ctx, client, "outbox_order_1", "pending", testTransitions)
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	f.OnEntry("paid", func(ctx context.Context, args ...interface{}) error {
+		return Enqueue(ctx, Message{Topic: "order.paid", Key: "outbox_order_1", Payload: []byte(`{"order":1}`)})
+	})
+	f.OnEntry("shipped", func(ctx context.Context, args ...interface{}) error {
+		if err := Enqueue(ctx, Message{Topic: "order.shipped"}); err != nil {
+			return err
+		}
+		return errors.New("carrier unavailable")
+	})
+
+	if err := f.Transition(ctx, "pay"); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if n := count(t, "order.paid"); n != 1 {
+		t.Errorf("Expected 1 message committed with the transition, got %d", n)
+	}
+
+	if err := f.Transition(ctx, "ship"); err == nil {
+		t.Fatalf("Expected error from entry action, got nil")
+	}
+	if n := count(t, "order.shipped"); n != 0 {
+		t.Errorf("Expected message to be rolled back with the transition, got %d", n)
+	}
+
+	if err := Enqueue(ctx, Message{Topic: "order.paid"}); !errors.Is(err, ErrNoTransaction) {
+		t.Errorf("Expected ErrNoTransaction, got %v", err)
+	}
+}
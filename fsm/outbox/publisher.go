@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Publisher delivers messages. Publish returns an error when the message must be retried.
+type Publisher interface {
+	Publish(ctx context.Context, m *Message) error
+}
+
+// PublisherFunc adapts a function to a Publisher.
+type PublisherFunc func(ctx context.Context, m *Message) error
+
+// Publish calls fn.
+func (fn PublisherFunc) Publish(ctx context.Context, m *Message) error {
+	return fn(ctx, m)
+}
+
+// ChannelPublisher delivers messages to an in-process channel.
+type ChannelPublisher struct {
+	C chan *Message
+}
+
+// NewChannelPublisher creates a ChannelPublisher whose channel has the given buffer size.
+func NewChannelPublisher(size int) *ChannelPublisher {
+	return &ChannelPublisher{C: make(chan *Message, size)}
+}
+
+// Publish sends m on the channel, failing if ctx is done before it is received.
+func (p *ChannelPublisher) Publish(ctx context.Context, m *Message) error {
+	select {
+	case p.C <- m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WebhookPublisher posts messages to an HTTP endpoint. The payload is the request body and the
+// message ID, topic and key are sent in the X-Outbox-ID, X-Outbox-Topic and X-Outbox-Key headers.
+// Any response other than 2xx is a failed delivery.
+type WebhookPublisher struct {
+	URL         string
+	ContentType string       // Defaults to application/json
+	Header      http.Header  // Extra headers, such as authorization
+	Client      *http.Client // Defaults to http.DefaultClient
+}
+
+// Publish posts m to the endpoint.
+func (p *WebhookPublisher) Publish(ctx context.Context, m *Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(m.Payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for name, values := range p.Header {
+		req.Header[name] = values
+	}
+	contentType := p.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Outbox-ID", strconv.Itoa(m.ID))
+	req.Header.Set("X-Outbox-Topic", m.Topic)
+	req.Header.Set("X-Outbox-Key", m.Key)
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
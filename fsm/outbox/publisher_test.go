@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	var received *http.Request
+	var body string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received, body = r, string(data)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	p := &WebhookPublisher{URL: server.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	m := &Message{ID: 7, Topic: "order.paid", Key: "order-1", Payload: []byte(`{"order":1}`)}
+
+	if err := p.Publish(ctx, m); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if received.Method != http.MethodPost || body != `{"order":1}` {
+		t.Errorf("Unexpected request %s with body %s", received.Method, body)
+	}
+	headers := map[string]string{
+		"Content-Type":   "application/json",
+		"Authorization":  "Bearer token",
+		"X-Outbox-Id":    "7",
+		"X-Outbox-Topic": "order.paid",
+		"X-Outbox-Key":   "order-1",
+	}
+	for name, value := range headers {
+		if got := received.Header.Get(name); got != value {
+			t.Errorf("Expected header %s %q, got %q", name, value, got)
+		}
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := p.Publish(ctx, m); err == nil {
+		t.Errorf("Expected error for unsuccessful response, got nil")
+	}
+}
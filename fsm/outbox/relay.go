@@ -0,0 +1,164 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/outboxmessage"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+)
+
+// Relay moves pending messages from the outbox to a publisher.
+type Relay struct {
+	client      *ent.Client
+	publisher   Publisher
+	batchSize   int
+	interval    time.Duration
+	maxAttempts int
+	backoff     func(attempts int) time.Duration
+	onError     func(err error)
+}
+
+// RelayOption configures a Relay.
+type RelayOption func(r *Relay)
+
+// WithBatchSize sets the maximum number of messages read per pass. The default is 100.
+func WithBatchSize(n int) RelayOption {
+	return func(r *Relay) {
+		r.batchSize = n
+	}
+}
+
+// WithInterval sets the time Run waits between passes. The default is one second.
+func WithInterval(d time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.interval = d
+	}
+}
+
+// WithMaxAttempts sets the number of failed deliveries after which a message is given up.
+// Given up messages stay in the outbox with their last error. The default, 0, retries forever.
+func WithMaxAttempts(n int) RelayOption {
+	return func(r *Relay) {
+		r.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the delay before retrying a message that failed the given number of times.
+// The default doubles from one second up to five minutes.
+func WithBackoff(backoff func(attempts int) time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.backoff = backoff
+	}
+}
+
+// WithErrorHandler sets a function receiving the errors Run encounters. By default they are ignored
+// and the next pass is attempted.
+func WithErrorHandler(onError func(err error)) RelayOption {
+	return func(r *Relay) {
+		r.onError = onError
+	}
+}
+
+// defaultBackoff doubles the delay from one second up to five minutes.
+func defaultBackoff(attempts int) time.Duration {
+	d := time.Second
+	for i := 1; i < attempts && d < 5*time.Minute; i++ {
+		d *= 2
+	}
+	return min(d, 5*time.Minute)
+}
+
+// NewRelay creates a relay delivering the messages of client's outbox to publisher.
+func NewRelay(client *ent.Client, publisher Publisher, opts ...RelayOption) *Relay {
+	r := &Relay{
+		client:    client,
+		publisher: publisher,
+		batchSize: 100,
+		interval:  time.Second,
+		backoff:   defaultBackoff,
+		onError:   func(error) {},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run delivers messages until ctx is done, then returns ctx.Err().
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.RelayOnce(ctx); err != nil && ctx.Err() == nil {
+			r.onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce makes one pass over the pending messages that are due, in ID order, and returns the
+// number published. A message that fails is retried after the backoff; later messages with the
+// same key wait for it, while messages with other keys are delivered meanwhile.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	pending := []predicate.OutboxMessage{outboxmessage.PublishedAtIsNil()}
+	if r.maxAttempts > 0 {
+		pending = append(pending, outboxmessage.AttemptsLT(r.maxAttempts))
+	}
+
+	// Keys of messages waiting for a retry hold back the later messages with the same key
+	waiting, err := r.client.OutboxMessage.Query().
+		Where(pending...).
+		Where(outboxmessage.NextAttemptAtGT(now), outboxmessage.KeyNEQ("")).
+		Unique(true).
+		Select(outboxmessage.FieldKey).
+		Strings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	due, err := r.client.OutboxMessage.Query().
+		Where(pending...).
+		Where(outboxmessage.NextAttemptAtLTE(now), outboxmessage.KeyNotIn(waiting...)).
+		Order(ent.Asc(outboxmessage.FieldID)).
+		Limit(r.batchSize).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outbox: %w", err)
+	}
+
+	blocked := make(map[string]bool)
+	published := 0
+	for _, m := range due {
+		if blocked[m.Key] {
+			continue
+		}
+
+		if perr := r.publisher.Publish(ctx, fromEntity(m)); perr != nil {
+			if m.Key != "" {
+				blocked[m.Key] = true
+			}
+			err = m.Update().
+				AddAttempts(1).
+				SetLastError(perr.Error()).
+				SetNextAttemptAt(time.Now().Add(r.backoff(m.Attempts + 1))).
+				Exec(ctx)
+			if err != nil {
+				return published, fmt.Errorf("failed to record delivery failure of message %d: %w", m.ID, err)
+			}
+			continue
+		}
+
+		if err := m.Update().SetPublishedAt(time.Now()).Exec(ctx); err != nil {
+			return published, fmt.Errorf("failed to record delivery of message %d: %w", m.ID, err)
+		}
+		published++
+	}
+	return published, nil
+}
@@ -0,0 +1,175 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/outboxmessage"
+)
+
+// enqueue commits messages with the given topic and key in one transaction.
+func enqueue(t *testing.T, client *ent.Client, key string, topics ...string) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatalf("Failed to start transaction: %v", err)
+	}
+	for _, topic := range topics {
+		if err := Enqueue(ent.NewTxContext(ctx, tx), Message{Topic: topic, Key: key}); err != nil {
+			tx.Rollback()
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+}
+
+func TestRelay(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	t.Run("Delivers pending messages once", func(t *testing.T) {
+		enqueue(t, client, "relay_1", "relay.a", "relay.b")
+		p := NewChannelPublisher(10)
+		r := NewRelay(client, p)
+
+		n, err := r.RelayOnce(ctx)
+		if err != nil || n != 2 {
+			t.Fatalf("Expected 2 messages published, got %d, %v", n, err)
+		}
+		if m := <-p.C; m.Topic != "relay.a" || m.Key != "relay_1" || m.ID == 0 {
+			t.Errorf("Unexpected first message: %+v", m)
+		}
+		if m := <-p.C; m.Topic != "relay.b" {
+			t.Errorf("Unexpected second message: %+v", m)
+		}
+
+		if n, err := r.RelayOnce(ctx); err != nil || n != 0 {
+			t.Errorf("Expected no messages left, got %d, %v", n, err)
+		}
+	})
+
+	t.Run("Retries failed deliveries in key order", func(t *testing.T) {
+		enqueue(t, client, "relay_2", "retry.a", "retry.b")
+		enqueue(t, client, "relay_3", "retry.c")
+
+		var published []string
+		failures := 1
+		p := PublisherFunc(func(ctx context.Context, m *Message) error {
+			if m.Topic == "retry.a" && failures > 0 {
+				failures--
+				return errors.New("broker unavailable")
+			}
+			published = append(published, m.Topic)
+			return nil
+		})
+		r := NewRelay(client, p, WithBackoff(func(int) time.Duration { return 0 }))
+
+		if n, err := r.RelayOnce(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected 1 message published, got %d, %v", n, err)
+		}
+		if !reflect.DeepEqual(published, []string{"retry.c"}) {
+			t.Errorf("Expected messages with the failed key to wait, got %v", published)
+		}
+		failed, err := client.OutboxMessage.Query().Where(outboxmessage.Topic("retry.a")).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query message: %v", err)
+		}
+		if failed.Attempts != 1 || failed.LastError != "broker unavailable" || failed.PublishedAt != nil {
+			t.Errorf("Unexpected retry bookkeeping: %+v", failed)
+		}
+
+		if n, err := r.RelayOnce(ctx); err != nil || n != 2 {
+			t.Fatalf("Expected 2 messages published, got %d, %v", n, err)
+		}
+		if !reflect.DeepEqual(published, []string{"retry.c", "retry.a", "retry.b"}) {
+			t.Errorf("Unexpected delivery order %v", published)
+		}
+	})
+
+	t.Run("Messages waiting for a retry do not hold back others", func(t *testing.T) {
+		enqueue(t, client, "relay_4", "wait.a", "wait.b")
+		enqueue(t, client, "", "wait.c")
+
+		var published []string
+		p := PublisherFunc(func(ctx context.Context, m *Message) error {
+			if m.Topic == "wait.a" {
+				return errors.New("broker unavailable")
+			}
+			published = append(published, m.Topic)
+			return nil
+		})
+		r := NewRelay(client, p, WithBatchSize(1), WithBackoff(func(int) time.Duration { return time.Hour }))
+
+		for i := 0; i < 3; i++ {
+			if _, err := r.RelayOnce(ctx); err != nil {
+				t.Fatalf("RelayOnce failed: %v", err)
+			}
+		}
+		if !reflect.DeepEqual(published, []string{"wait.c"}) {
+			t.Errorf("Expected only the message with another key to be delivered, got %v", published)
+		}
+	})
+
+	t.Run("Gives up after the maximum attempts", func(t *testing.T) {
+		enqueue(t, client, "", "giveup.a")
+
+		attempts := 0
+		p := PublisherFunc(func(ctx context.Context, m *Message) error {
+			if m.Topic == "giveup.a" {
+				attempts++
+			}
+			return errors.New("rejected")
+		})
+		r := NewRelay(client, p, WithMaxAttempts(2), WithBackoff(func(int) time.Duration { return 0 }))
+		for i := 0; i < 4; i++ {
+			if _, err := r.RelayOnce(ctx); err != nil {
+				t.Fatalf("RelayOnce failed: %v", err)
+			}
+		}
+		if attempts != 2 {
+			t.Errorf("Expected 2 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("Run stops with the context", func(t *testing.T) {
+		enqueue(t, client, "", "run.a")
+		p := NewChannelPublisher(10)
+		r := NewRelay(client, p, WithInterval(10*time.Millisecond))
+
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() { done <- r.Run(runCtx) }()
+
+		// Messages given up by other relays are delivered too
+		timeout := time.After(time.Second)
+		for received := false; !received; {
+			select {
+			case m := <-p.C:
+				received = m.Topic == "run.a"
+			case <-timeout:
+				t.Fatalf("Timed out waiting for message")
+			}
+		}
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestDefaultBackoff(t *testing.T) {
+	expected := map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 20: 5 * time.Minute}
+	for attempts, d := range expected {
+		if got := defaultBackoff(attempts); got != d {
+			t.Errorf("defaultBackoff(%d) = %v, expected %v", attempts, got, d)
+		}
+	}
+}
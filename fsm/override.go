@@ -0,0 +1,149 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// Override is an administrative change of state applied by ForceState.
+type Override struct {
+	To     State
+	Reason string // Required explanation, recorded in the history
+	Actor  string // Required identity of the requester, recorded in the history
+
+	// RunEntryActions runs the entry actions of To with Args. Exit actions, transition
+	// callbacks and guards never run for an override.
+	RunEntryActions bool
+	Args            []interface{}
+}
+
+// ForceState sets the state of the machine to o.To without an event, bypassing guards and
+// middlewares, and records an override entry with the reason and actor in its history. It is
+// meant for repairing stuck machines; To must be a state of the definition.
+//
+// Entry actions and the state update share one database transaction, joining the one carried
+// by ctx if any, as for Transition.
+func (f *FSM) ForceState(ctx context.Context, o Override) error {
+	if o.Reason == "" || o.Actor == "" {
+		return errors.New("state override requires a reason and an actor")
+	}
+	return f.force(ctx, o, statetransition.KindOverride)
+}
+
+// force applies an administrative change of state recorded in history with the given kind.
+func (f *FSM) force(ctx context.Context, o Override, kind statetransition.Kind) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.definition.HasState(o.To) {
+		return fmt.Errorf("cannot force machine %s into state %s: state is not defined", f.machineID, o.To)
+	}
+
+	var tx *ent.Tx
+	owned := false
+	baseCtx := ctx
+	if f.client != nil && f.machineID != "" {
+		if tx = ent.TxFromContext(ctx); tx == nil {
+			if tx, err = f.client.Tx(ctx); err != nil {
+				return fmt.Errorf("failed to start transaction: %w", err)
+			}
+			owned = true
+			ctx = ent.NewTxContext(ctx, tx)
+		}
+	}
+
+	previous := f.currentState
+	enteredAt := f.enteredAt
+	if o.To != previous {
+		enteredAt = time.Now()
+	}
+	var done []*hook
+	defer func() {
+		// Never leave the machine in a half-applied state if an entry action panics
+		if r := recover(); r != nil {
+			f.currentState = previous
+			if owned {
+				tx.Rollback()
+			}
+			panic(r)
+		}
+		if err == nil {
+			f.enteredAt = enteredAt
+			return
+		}
+		f.currentState = previous // Revert state
+		if owned {
+			tx.Rollback()
+		}
+		if _, cerr := compensate(baseCtx, done, o.Args); cerr != nil {
+			err = errors.Join(err, fmt.Errorf("compensation failed: %w", cerr))
+		}
+	}()
+
+	f.currentState = o.To
+	if o.RunEntryActions {
+		if err := runHooks(ctx, orderedHooks(f.entryActions[o.To], f.anyEntryActions), o.Args, &done); err != nil {
+			return fmt.Errorf("entry action failed while forcing state %s: %w", o.To, err)
+		}
+	}
+
+	if tx == nil {
+		return nil
+	}
+	if err := f.persistOverride(ctx, tx, previous, o, kind, enteredAt); err != nil {
+		return err
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+	return nil
+}
+
+// persistOverride writes the forced state, entered at enteredAt, and its history entry within tx.
+// A reset also clears the data of the machine.
+func (f *FSM) persistOverride(ctx context.Context, tx *ent.Tx, previousState State, o Override, kind statetransition.Kind, enteredAt time.Time) error {
+	sm, err := tx.StateMachine.Query().Where(statemachine.MachineID(f.machineID)).Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query state machine for update: %w", err)
+	}
+
+	err = tx.StateTransition.Create().
+		SetFromState(string(previousState)).
+		SetToState(string(o.To)).
+		SetEvent("").
+		SetKind(kind).
+		SetReason(o.Reason).
+		SetActor(o.Actor).
+		SetMachine(sm).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record %s of machine %s: %w", kind, f.machineID, err)
+	}
+
+	update := sm.Update().SetCurrentState(string(o.To)).SetEnteredAt(enteredAt)
+	if kind == statetransition.KindReset {
+		update.ClearData()
+	}
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to persist state: %w", err)
+	}
+	return nil
+}
+
+// ForceState loads the machine with the given ID through the manager and applies o to it.
+// See FSM.ForceState.
+func (m *Manager) ForceState(ctx context.Context, machineID string, o Override) error {
+	f, err := m.LoadFSM(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	return f.ForceState(ctx, o)
+}
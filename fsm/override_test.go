@@ -0,0 +1,156 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+func TestForceState(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	newMachine := func(t *testing.T, id string) *FSM {
+		t.Helper()
+		f, err := NewFSM(ctx, client, id, StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		return f
+	}
+	persisted := func(t *testing.T, id string) State {
+		t.Helper()
+		sm, err := client.StateMachine.Query().Where(statemachine.MachineID(id)).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query machine: %v", err)
+		}
+		return State(sm.CurrentState)
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		f := newMachine(t, "override_validation")
+		if err := f.ForceState(ctx, Override{To: StateStopped, Actor: "alice"}); err == nil {
+			t.Errorf("Expected error for override without reason, got nil")
+		}
+		if err := f.ForceState(ctx, Override{To: StateStopped, Reason: "stuck"}); err == nil {
+			t.Errorf("Expected error for override without actor, got nil")
+		}
+		if err := f.ForceState(ctx, Override{To: "unknown", Reason: "stuck", Actor: "alice"}); err == nil {
+			t.Errorf("Expected error for undefined state, got nil")
+		}
+	})
+
+	t.Run("Bypasses guards and records the override", func(t *testing.T) {
+		f := newMachine(t, "override_audit")
+		var calls []string
+		f.AddGuard(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) bool { return false })
+		f.OnExit(StateIdle, func(ctx context.Context, args ...interface{}) error {
+			calls = append(calls, "exit idle")
+			return nil
+		})
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			calls = append(calls, "enter running")
+			return nil
+		})
+
+		if err := f.ForceState(ctx, Override{To: StateRunning, Reason: "ticket 42", Actor: "alice"}); err != nil {
+			t.Fatalf("ForceState failed: %v", err)
+		}
+		if f.CurrentState() != StateRunning || persisted(t, "override_audit") != StateRunning {
+			t.Errorf("Expected state %s", StateRunning)
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected no actions to run, got %v", calls)
+		}
+
+		record, err := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID("override_audit"))).
+			Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query history: %v", err)
+		}
+		if record.Kind != statetransition.KindOverride || record.FromState != string(StateIdle) ||
+			record.ToState != string(StateRunning) || record.Reason != "ticket 42" || record.Actor != "alice" {
+			t.Errorf("Unexpected history record %+v", record)
+		}
+
+		if err := f.ForceState(ctx, Override{To: StatePaused, Reason: "retry", Actor: "bob", RunEntryActions: true, Args: []interface{}{"x"}}); err != nil {
+			t.Fatalf("ForceState failed: %v", err)
+		}
+		f.OnEntry(StateStopped, func(ctx context.Context, args ...interface{}) error {
+			calls = append(calls, "enter stopped "+args[0].(string))
+			return nil
+		})
+		if err := f.ForceState(ctx, Override{To: StateStopped, Reason: "retry", Actor: "bob", RunEntryActions: true, Args: []interface{}{"x"}}); err != nil {
+			t.Fatalf("ForceState failed: %v", err)
+		}
+		if !reflect.DeepEqual(calls, []string{"enter stopped x"}) {
+			t.Errorf("Expected only entry actions of the target to run, got %v", calls)
+		}
+	})
+
+	t.Run("Failing entry action leaves the machine unchanged", func(t *testing.T) {
+		f := newMachine(t, "override_failure")
+		errBroken := errors.New("broken")
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error { return errBroken })
+
+		err := f.ForceState(ctx, Override{To: StateRunning, Reason: "stuck", Actor: "alice", RunEntryActions: true})
+		if !errors.Is(err, errBroken) {
+			t.Fatalf("Expected entry action error, got %v", err)
+		}
+		if f.CurrentState() != StateIdle || persisted(t, "override_failure") != StateIdle {
+			t.Errorf("Expected state to stay %s", StateIdle)
+		}
+		n, _ := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID("override_failure"))).
+			Count(ctx)
+		if n != 0 {
+			t.Errorf("Expected no history records, got %d", n)
+		}
+	})
+
+	t.Run("Panicking entry action leaves the machine unchanged", func(t *testing.T) {
+		f := newMachine(t, "override_panic")
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error { panic("boom") })
+
+		func() {
+			defer func() {
+				if r := recover(); r != "boom" {
+					t.Errorf("Expected the panic to propagate, got %v", r)
+				}
+			}()
+			f.ForceState(ctx, Override{To: StateRunning, Reason: "stuck", Actor: "alice", RunEntryActions: true})
+		}()
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state to stay %s, got %s", StateIdle, f.CurrentState())
+		}
+		// The transaction was rolled back, so the machine can still be written
+		if err := f.ForceState(ctx, Override{To: StateStopped, Reason: "stuck", Actor: "alice"}); err != nil {
+			t.Fatalf("ForceState after panic failed: %v", err)
+		}
+		if persisted(t, "override_panic") != StateStopped {
+			t.Errorf("Expected state %s to be persisted", StateStopped)
+		}
+	})
+
+	t.Run("Manager", func(t *testing.T) {
+		m := NewManager(client)
+		if err := m.Register(&Definition{Name: "override", Initial: StateIdle, Transitions: defineTestTransitions()}); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		if _, err := m.NewFSM(ctx, "override", "override_manager"); err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := m.ForceState(ctx, "override_manager", Override{To: StateStopped, Reason: "stuck", Actor: "alice"}); err != nil {
+			t.Fatalf("ForceState failed: %v", err)
+		}
+		if state := persisted(t, "override_manager"); state != StateStopped {
+			t.Errorf("Expected state %s, got %s", StateStopped, state)
+		}
+	})
+}
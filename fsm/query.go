@@ -0,0 +1,78 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// target returns the state event leads to from the current state. f.mu must be held.
+func (f *FSM) target(event Event) (State, error) {
+	nextStates, ok := f.transitions[f.currentState]
+	if !ok {
+		return "", fmt.Errorf("%w: no transitions defined from state %s", ErrInvalidTransition, f.currentState)
+	}
+
+	nextState, ok := nextStates[event]
+	if !ok {
+		return "", fmt.Errorf("%w: no transition defined for event %s from state %s", ErrInvalidEvent, event, f.currentState)
+	}
+	return nextState, nil
+}
+
+// Events returns the events defined from the current state, sorted by name.
+func (f *FSM) Events() []Event {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.events()
+}
+
+// events returns the events defined from the current state, sorted by name. f.mu must be held.
+func (f *FSM) events() []Event {
+	events := make([]Event, 0, len(f.transitions[f.currentState]))
+	for event := range f.transitions[f.currentState] {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+	return events
+}
+
+// AvailableEvents returns the events defined from the current state whose guards pass for args,
+// sorted by name. No events are available while the machine is suspended.
+func (f *FSM) AvailableEvents(ctx context.Context, args ...interface{}) []Event {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.checkSuspended(ctx) != nil {
+		return nil
+	}
+
+	var available []Event
+	for _, event := range f.events() {
+		next := f.transitions[f.currentState][event]
+		if f.checkGuards(ctx, f.currentState, event, next, args) == nil {
+			available = append(available, event)
+		}
+	}
+	return available
+}
+
+// DryRun evaluates the guards of the transition triggered by event and returns the target state,
+// without running actions, notifying listeners or persisting anything.
+// It returns the same errors Transition would return before running actions.
+func (f *FSM) DryRun(ctx context.Context, event Event, args ...interface{}) (State, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if err := f.checkSuspended(ctx); err != nil {
+		return "", f.transitionError(PhaseValidate, event, "", err)
+	}
+	next, err := f.target(event)
+	if err != nil {
+		return "", f.transitionError(PhaseValidate, event, "", err)
+	}
+	if err := f.checkGuards(ctx, f.currentState, event, next, args); err != nil {
+		return next, f.transitionError(PhaseGuard, event, next, err)
+	}
+	return next, nil
+}
@@ -0,0 +1,77 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	f, err := NewFSM(ctx, client, "query_machine", StateRunning, defineTestTransitions())
+	if err != nil {
+		t.Fatalf("NewFSM failed: %v", err)
+	}
+	f.AddGuard(StateRunning, EventStop, func(ctx context.Context, args ...interface{}) bool {
+		return len(args) > 0 && args[0] == "confirm"
+	})
+	entered := false
+	f.OnEntry(StatePaused, func(ctx context.Context, args ...interface{}) error {
+		entered = true
+		return nil
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		expected := []Event{EventPause, EventStop}
+		if events := f.Events(); !reflect.DeepEqual(events, expected) {
+			t.Errorf("Expected events %v, got %v", expected, events)
+		}
+	})
+
+	t.Run("AvailableEvents", func(t *testing.T) {
+		if events := f.AvailableEvents(ctx); !reflect.DeepEqual(events, []Event{EventPause}) {
+			t.Errorf("Expected events %v, got %v", []Event{EventPause}, events)
+		}
+		expected := []Event{EventPause, EventStop}
+		if events := f.AvailableEvents(ctx, "confirm"); !reflect.DeepEqual(events, expected) {
+			t.Errorf("Expected events %v, got %v", expected, events)
+		}
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		next, err := f.DryRun(ctx, EventPause)
+		if err != nil {
+			t.Fatalf("DryRun failed: %v", err)
+		}
+		if next != StatePaused {
+			t.Errorf("Expected target %s, got %s", StatePaused, next)
+		}
+		if entered {
+			t.Errorf("Expected DryRun not to run entry actions")
+		}
+		if f.CurrentState() != StateRunning {
+			t.Errorf("Expected state %s, got %s", StateRunning, f.CurrentState())
+		}
+		sm, err := client.StateMachine.Query().Where(statemachine.MachineID("query_machine")).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query state machine: %v", err)
+		}
+		if State(sm.CurrentState) != StateRunning {
+			t.Errorf("Expected persisted state %s, got %s", StateRunning, sm.CurrentState)
+		}
+
+		next, err = f.DryRun(ctx, EventStop)
+		if !errors.Is(err, ErrTransitionDenied) || next != StateStopped {
+			t.Errorf("Expected denied transition to %s, got %s, %v", StateStopped, next, err)
+		}
+		if _, err := f.DryRun(ctx, EventStart); !errors.Is(err, ErrInvalidEvent) {
+			t.Errorf("Expected ErrInvalidEvent, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,324 @@
+// Package saga orchestrates multi-step operations on top of persistent fsm machines.
+//
+// A saga is a sequence of steps, each with a forward action and an optional compensating action.
+// Every execution of a saga is an fsm machine whose state records the last completed step, so
+// progress is kept in the StateMachine and StateTransition tables. When a step fails, the steps
+// that completed are compensated in reverse order. After a crash, Recover resumes executions that
+// were moving forward and finishes the compensation of those that were being aborted.
+//
+// An execution resumed after a crash repeats the step that was in flight, so forward and
+// compensating actions must be idempotent.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+const (
+	// StateStarted is the state of an execution before its first step completes.
+	StateStarted fsm.State = "started"
+	// StateCompleted is the final state of an execution whose steps all completed.
+	StateCompleted fsm.State = "completed"
+	// StateAborted is the final state of an execution whose completed steps were compensated.
+	StateAborted fsm.State = "aborted"
+
+	// compensatingPrefix prefixes the name of the next step to compensate.
+	compensatingPrefix = "compensating:"
+	// compensatePrefix prefixes the event compensating a step.
+	compensatePrefix = "compensate:"
+
+	eventComplete fsm.Event = "complete"
+	eventFail     fsm.Event = "fail"
+)
+
+var (
+	// ErrAborted is returned when a step failed and the execution was compensated.
+	ErrAborted = errors.New("saga aborted")
+	// ErrCompensationFailed is returned when a compensating action fails. The execution stays in
+	// its compensating state and the compensation is retried by Resume or Recover.
+	ErrCompensationFailed = errors.New("saga compensation failed")
+	// ErrAlreadyStarted is returned by Start for an execution ID that is already in use.
+	ErrAlreadyStarted = errors.New("saga already started")
+)
+
+// Execution is one run of a saga, passed to the actions of its steps.
+type Execution struct {
+	ID string
+	// Data is persisted with the execution after every step, so values recorded by a forward
+	// action, such as a payment reference, are available to later steps and compensations.
+	Data map[string]string
+}
+
+// StepFunc is the forward or compensating action of a step.
+type StepFunc func(ctx context.Context, e *Execution) error
+
+// Step is one step of a saga.
+type Step struct {
+	Name       string
+	Action     StepFunc
+	Compensate StepFunc // Optional
+}
+
+// Saga runs executions of a sequence of steps.
+type Saga struct {
+	client     *ent.Client
+	steps      []Step
+	definition *fsm.Definition
+}
+
+// New creates a saga. Executions are recorded under the definition name "saga:" + name.
+func New(client *ent.Client, name string, steps ...Step) (*Saga, error) {
+	if client == nil || name == "" {
+		return nil, errors.New("client and name are required to create a saga")
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("saga %s has no steps", name)
+	}
+	seen := make(map[string]bool)
+	for _, step := range steps {
+		switch {
+		case step.Name == "" || step.Action == nil:
+			return nil, fmt.Errorf("saga %s: steps require a name and an action", name)
+		case seen[step.Name]:
+			return nil, fmt.Errorf("saga %s: duplicate step %s", name, step.Name)
+		case isReserved(step.Name):
+			return nil, fmt.Errorf("saga %s: step name %s is reserved", name, step.Name)
+		}
+		seen[step.Name] = true
+	}
+
+	s := &Saga{client: client, steps: steps}
+	s.definition = s.define(name)
+	if err := s.definition.Validate(); err != nil {
+		return nil, fmt.Errorf("saga %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// isReserved reports whether name clashes with the states and events of the saga itself.
+func isReserved(name string) bool {
+	switch fsm.State(name) {
+	case StateStarted, StateCompleted, StateAborted:
+		return true
+	}
+	switch fsm.Event(name) {
+	case eventComplete, eventFail:
+		return true
+	}
+	return strings.HasPrefix(name, compensatingPrefix) || strings.HasPrefix(name, compensatePrefix)
+}
+
+// define builds the definition of the machines of the saga.
+func (s *Saga) define(name string) *fsm.Definition {
+	def := &fsm.Definition{
+		Name:    "saga:" + name,
+		Version: 1,
+		Initial: StateStarted,
+		Final:   []fsm.State{StateCompleted, StateAborted},
+		Setup:   s.setup,
+	}
+
+	prev := StateStarted
+	def.Transitions = append(def.Transitions, fsm.Transition{From: StateStarted, Event: eventFail, To: StateAborted})
+	for i, step := range s.steps {
+		done := fsm.State(step.Name)
+		def.Transitions = append(def.Transitions,
+			fsm.Transition{From: prev, Event: fsm.Event(step.Name), To: done},
+			fsm.Transition{From: done, Event: eventFail, To: compensating(step.Name)},
+		)
+
+		// Compensating a step moves on to the step before it
+		next := StateAborted
+		if i > 0 {
+			next = compensating(s.steps[i-1].Name)
+		}
+		def.Transitions = append(def.Transitions,
+			fsm.Transition{From: compensating(step.Name), Event: fsm.Event(compensatePrefix + step.Name), To: next})
+		prev = done
+	}
+	def.Transitions = append(def.Transitions, fsm.Transition{From: prev, Event: eventComplete, To: StateCompleted})
+	return def
+}
+
+// compensating returns the state in which step is the next step to compensate.
+func compensating(step string) fsm.State {
+	return fsm.State(compensatingPrefix + step)
+}
+
+// setup registers the actions of the steps on a machine of the saga.
+func (s *Saga) setup(f *fsm.FSM) error {
+	prev := StateStarted
+	for _, step := range s.steps {
+		var opts []fsm.HookOption
+		if step.Compensate != nil {
+			// Undo a step whose state change could not be persisted
+			opts = append(opts, fsm.WithCompensation(stepAction(step.Compensate)))
+			if _, err := f.OnTransition(compensating(step.Name), fsm.Event(compensatePrefix+step.Name), stepAction(step.Compensate)); err != nil {
+				return err
+			}
+		}
+		if _, err := f.OnTransition(prev, fsm.Event(step.Name), stepAction(step.Action), opts...); err != nil {
+			return err
+		}
+		prev = fsm.State(step.Name)
+	}
+	f.OnAnyEntry(saveData)
+	return nil
+}
+
+// stepAction adapts a StepFunc to an action receiving the execution as its argument.
+func stepAction(fn StepFunc) fsm.Action {
+	return func(ctx context.Context, args ...interface{}) error {
+		return fn(ctx, args[0].(*Execution))
+	}
+}
+
+// saveData persists the data of the execution in the transaction of the transition.
+func saveData(ctx context.Context, args ...interface{}) error {
+	e := args[0].(*Execution)
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode saga data: %w", err)
+	}
+	return ent.TxFromContext(ctx).StateMachine.Update().
+		Where(statemachine.MachineID(e.ID)).
+		SetData(data).
+		Exec(ctx)
+}
+
+// Definition returns the definition of the machines of the saga.
+func (s *Saga) Definition() *fsm.Definition {
+	return s.definition
+}
+
+// Start creates an execution with the given ID and data and runs it until it completes or aborts.
+// It returns an error wrapping ErrAborted and the cause when a step fails.
+func (s *Saga) Start(ctx context.Context, id string, data map[string]string) error {
+	exists, err := s.client.StateMachine.Query().Where(statemachine.MachineID(id)).Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query saga %s: %w", id, err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrAlreadyStarted, id)
+	}
+
+	if data == nil {
+		data = make(map[string]string)
+	}
+	e := &Execution{ID: id, Data: data}
+	encoded, err := json.Marshal(e.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode saga data: %w", err)
+	}
+
+	f, err := fsm.NewFSMFromDefinition(ctx, s.client, id, s.definition)
+	if err != nil {
+		return err
+	}
+	if err := s.client.StateMachine.Update().Where(statemachine.MachineID(id)).SetData(encoded).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to persist saga data: %w", err)
+	}
+	return s.run(ctx, f, e)
+}
+
+// Resume continues the execution with the given ID from its persisted state.
+func (s *Saga) Resume(ctx context.Context, id string) error {
+	sm, err := s.client.StateMachine.Query().Where(statemachine.MachineID(id)).Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query saga %s: %w", id, err)
+	}
+	e := &Execution{ID: id, Data: make(map[string]string)}
+	if len(sm.Data) > 0 {
+		if err := json.Unmarshal(sm.Data, &e.Data); err != nil {
+			return fmt.Errorf("failed to decode data of saga %s: %w", id, err)
+		}
+	}
+
+	f, err := fsm.LoadFSMFromDefinition(ctx, s.client, id, s.definition)
+	if err != nil {
+		return err
+	}
+	return s.run(ctx, f, e)
+}
+
+// Recover resumes every execution of the saga that is neither completed nor aborted, such as
+// executions interrupted by a crash. It returns the number of executions resumed. Executions that
+// abort are not reported as errors. Recover must not run while the same executions are in progress
+// elsewhere, for example by calling it on startup before new executions are started.
+func (s *Saga) Recover(ctx context.Context) (int, error) {
+	ids, err := s.client.StateMachine.Query().
+		Where(
+			statemachine.DefinitionName(s.definition.Name),
+			statemachine.CurrentStateNotIn(string(StateCompleted), string(StateAborted)),
+		).
+		Select(statemachine.FieldMachineID).
+		Strings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query in-flight sagas: %w", err)
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if err := s.Resume(ctx, id); err != nil && !errors.Is(err, ErrAborted) {
+			errs = append(errs, fmt.Errorf("saga %s: %w", id, err))
+		}
+	}
+	return len(ids), errors.Join(errs...)
+}
+
+// run fires the events of the execution until it reaches a final state or an error prevents
+// further progress.
+func (s *Saga) run(ctx context.Context, f *fsm.FSM, e *Execution) error {
+	var cause error
+	for {
+		state := f.CurrentState()
+		switch {
+		case state == StateCompleted:
+			return nil
+		case state == StateAborted:
+			if cause != nil {
+				return fmt.Errorf("%w: %w", ErrAborted, cause)
+			}
+			return ErrAborted
+		case strings.HasPrefix(string(state), compensatingPrefix):
+			step := strings.TrimPrefix(string(state), compensatingPrefix)
+			if err := f.Transition(ctx, fsm.Event(compensatePrefix+step), e); err != nil {
+				return fmt.Errorf("%w: step %s: %w", ErrCompensationFailed, step, err)
+			}
+		default:
+			event := s.next(state)
+			err := f.Transition(ctx, event, e)
+			if err == nil {
+				continue
+			}
+			if event == eventComplete {
+				return err
+			}
+			cause = err
+			if err := f.Transition(ctx, eventFail, e); err != nil {
+				return errors.Join(cause, fmt.Errorf("failed to start compensation: %w", err))
+			}
+		}
+	}
+}
+
+// next returns the event moving an execution forward from state.
+func (s *Saga) next(state fsm.State) fsm.Event {
+	if state == StateStarted {
+		return fsm.Event(s.steps[0].Name)
+	}
+	for i, step := range s.steps {
+		if fsm.State(step.Name) == state && i+1 < len(s.steps) {
+			return fsm.Event(s.steps[i+1].Name)
+		}
+	}
+	return eventComplete
+}
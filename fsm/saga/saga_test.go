@@ -0,0 +1,188 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/enttest"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/fsm"
+
+	_ "github.com/mattn/go-sqlite3" // Driver for SQLite
+)
+
+func setupTestClient(t *testing.T) *ent.Client {
+	return enttest.Open(t, "sqlite3", "file:saga?mode=memory&cache=shared&_fk=1")
+}
+
+// orderSteps returns reserve, charge and ship steps recording their calls. Steps listed in fail
+// return errors.
+func orderSteps(calls *[]string, fail map[string]error) []Step {
+	step := func(name string) Step {
+		return Step{
+			Name: name,
+			Action: func(ctx context.Context, e *Execution) error {
+				*calls = append(*calls, name)
+				if err := fail[name]; err != nil {
+					return err
+				}
+				e.Data[name] = name + "_" + e.ID
+				return nil
+			},
+			Compensate: func(ctx context.Context, e *Execution) error {
+				*calls = append(*calls, "undo "+name)
+				return fail["undo "+name]
+			},
+		}
+	}
+	return []Step{step("reserve"), step("charge"), step("ship")}
+}
+
+func TestSaga(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	machine := func(t *testing.T, id string) *ent.StateMachine {
+		t.Helper()
+		sm, err := client.StateMachine.Query().Where(statemachine.MachineID(id)).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query saga: %v", err)
+		}
+		return sm
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		action := func(ctx context.Context, e *Execution) error { return nil }
+		if _, err := New(client, "empty"); err == nil {
+			t.Errorf("Expected error for saga without steps, got nil")
+		}
+		if _, err := New(client, "duplicate", Step{Name: "a", Action: action}, Step{Name: "a", Action: action}); err == nil {
+			t.Errorf("Expected error for duplicate steps, got nil")
+		}
+		if _, err := New(client, "reserved", Step{Name: "completed", Action: action}); err == nil {
+			t.Errorf("Expected error for reserved step name, got nil")
+		}
+	})
+
+	t.Run("Completes every step", func(t *testing.T) {
+		var calls []string
+		s, err := New(client, "order", orderSteps(&calls, nil)...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+
+		if err := s.Start(ctx, "saga_1", map[string]string{"order": "42"}); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		if !reflect.DeepEqual(calls, []string{"reserve", "charge", "ship"}) {
+			t.Errorf("Unexpected calls %v", calls)
+		}
+
+		sm := machine(t, "saga_1")
+		if fsm.State(sm.CurrentState) != StateCompleted {
+			t.Errorf("Expected state %s, got %s", StateCompleted, sm.CurrentState)
+		}
+		var data map[string]string
+		if err := json.Unmarshal(sm.Data, &data); err != nil {
+			t.Fatalf("Failed to decode data: %v", err)
+		}
+		expected := map[string]string{"order": "42", "reserve": "reserve_saga_1", "charge": "charge_saga_1", "ship": "ship_saga_1"}
+		if !reflect.DeepEqual(data, expected) {
+			t.Errorf("Expected data %v, got %v", expected, data)
+		}
+
+		if err := s.Start(ctx, "saga_1", nil); !errors.Is(err, ErrAlreadyStarted) {
+			t.Errorf("Expected ErrAlreadyStarted, got %v", err)
+		}
+	})
+
+	t.Run("Failing step compensates completed steps", func(t *testing.T) {
+		errDeclined := errors.New("card declined")
+		var calls []string
+		s, err := New(client, "order", orderSteps(&calls, map[string]error{"ship": errDeclined})...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+
+		err = s.Start(ctx, "saga_2", nil)
+		if !errors.Is(err, ErrAborted) || !errors.Is(err, errDeclined) {
+			t.Fatalf("Expected ErrAborted wrapping the step error, got %v", err)
+		}
+		expected := []string{"reserve", "charge", "ship", "undo charge", "undo reserve"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("Expected calls %v, got %v", expected, calls)
+		}
+		if state := machine(t, "saga_2").CurrentState; fsm.State(state) != StateAborted {
+			t.Errorf("Expected state %s, got %s", StateAborted, state)
+		}
+	})
+
+	t.Run("Recover retries failed compensations", func(t *testing.T) {
+		fail := map[string]error{"charge": errors.New("card declined"), "undo reserve": errors.New("inventory unavailable")}
+		var calls []string
+		s, err := New(client, "refund", orderSteps(&calls, fail)...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+
+		if err := s.Start(ctx, "saga_3", nil); !errors.Is(err, ErrCompensationFailed) {
+			t.Fatalf("Expected ErrCompensationFailed, got %v", err)
+		}
+		if state := machine(t, "saga_3").CurrentState; state != "compensating:reserve" {
+			t.Errorf("Expected state compensating:reserve, got %s", state)
+		}
+
+		delete(fail, "undo reserve")
+		calls = nil
+		n, err := s.Recover(ctx)
+		if err != nil {
+			t.Fatalf("Recover failed: %v", err)
+		}
+		if n != 1 {
+			t.Errorf("Expected 1 saga to be recovered, got %d", n)
+		}
+		if !reflect.DeepEqual(calls, []string{"undo reserve"}) {
+			t.Errorf("Expected only the pending compensation to run, got %v", calls)
+		}
+		if state := machine(t, "saga_3").CurrentState; fsm.State(state) != StateAborted {
+			t.Errorf("Expected state %s, got %s", StateAborted, state)
+		}
+	})
+
+	t.Run("Recover resumes interrupted sagas", func(t *testing.T) {
+		var calls []string
+		s, err := New(client, "shipping", orderSteps(&calls, nil)...)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+
+		// An execution that crashed after reserving stock
+		def := s.Definition()
+		err = client.StateMachine.Create().
+			SetMachineID("saga_4").
+			SetCurrentState("reserve").
+			SetDefinitionName(def.Name).
+			SetDefinitionVersion(def.Version).
+			SetData([]byte(`{"reserve":"reserve_saga_4"}`)).
+			Exec(ctx)
+		if err != nil {
+			t.Fatalf("Failed to create interrupted saga: %v", err)
+		}
+
+		n, err := s.Recover(ctx)
+		if err != nil || n != 1 {
+			t.Fatalf("Expected 1 saga to be recovered, got %d, %v", n, err)
+		}
+		if !reflect.DeepEqual(calls, []string{"charge", "ship"}) {
+			t.Errorf("Expected remaining steps to run, got %v", calls)
+		}
+		if state := machine(t, "saga_4").CurrentState; fsm.State(state) != StateCompleted {
+			t.Errorf("Expected state %s, got %s", StateCompleted, state)
+		}
+	})
+}
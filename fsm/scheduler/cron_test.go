@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * * funday"}
+	for _, expr := range invalid {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("Expected error for %q, got nil", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2024, time.January, 31, 10, 30, 15, 0, time.UTC) // A Wednesday
+
+	tests := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"* * * * *", base, time.Date(2024, 1, 31, 10, 31, 0, 0, time.UTC)},
+		{"0 2 * * *", base, time.Date(2024, 2, 1, 2, 0, 0, 0, time.UTC)},
+		{"@daily", base, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", base, time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", base, time.Date(2024, 1, 31, 10, 45, 0, 0, time.UTC)},
+		{"0-10/5 11 * * *", base, time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", base, time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)},
+		{"0 0 29 feb *", base, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2024, 2, 2, 12, 0, 0, 0, time.UTC), time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", base, time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted
+		{"0 0 15 * fri", base, time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", base, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", base, time.Time{}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if got := c.Next(tt.from); !got.Equal(tt.expected) {
+			t.Errorf("Next(%q) from %v = %v, expected %v", tt.expr, tt.from, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/lease"
+)
+
+// acquire takes or renews the lease of the scheduler and reports whether it holds it.
+func (s *Scheduler) acquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+	n, err := s.client.Lease.Update().
+		Where(lease.Name(s.leaseName), lease.Or(lease.Holder(s.holder), lease.ExpiresAtLT(now))).
+		SetHolder(s.holder).
+		SetExpiresAt(now.Add(s.leaseTTL)).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to renew lease %s: %w", s.leaseName, err)
+	}
+	if n == 1 {
+		return true, nil
+	}
+
+	err = s.client.Lease.Create().
+		SetName(s.leaseName).
+		SetHolder(s.holder).
+		SetExpiresAt(now.Add(s.leaseTTL)).
+		Exec(ctx)
+	if ent.IsConstraintError(err) {
+		return false, nil // Held by another scheduler
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", s.leaseName, err)
+	}
+	return true, nil
+}
+
+// release gives up the lease if the scheduler holds it, so another scheduler can take over at once.
+func (s *Scheduler) release(ctx context.Context) error {
+	_, err := s.client.Lease.Delete().
+		Where(lease.Name(s.leaseName), lease.Holder(s.holder)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", s.leaseName, err)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+// Package scheduler sends events to persistent machines at fixed times or on cron expressions.
+//
+// Schedules are stored in the database, so they survive restarts. Any number of processes may run
+// a Scheduler on the same database: a lease elects the one that fires events, and the others take
+// over when it stops renewing the lease. Events are sent with an idempotency key derived from the
+// schedule and its run time, so a run interrupted by a crash can be repeated without applying an
+// event twice to the same machine.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/schedule"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+// Target selects the machines a schedule sends its event to: the machine with MachineID, or else
+// every machine with the given definition name and current state. Empty selector fields match
+// every machine.
+type Target struct {
+	MachineID  string
+	Definition string
+	State      fsm.State
+}
+
+// Schedule describes when and where an event is sent.
+type Schedule struct {
+	Name   string
+	Cron   string    // Cron expression of a recurring schedule, see ParseCron
+	At     time.Time // Time of a one-off schedule, used when Cron is empty
+	Event  fsm.Event
+	Args   []interface{} // Stored as JSON; events receive them as decoded JSON values
+	Target Target
+}
+
+// Scheduler fires the schedules stored in the database of a manager.
+type Scheduler struct {
+	manager   *fsm.Manager
+	client    *ent.Client
+	holder    string
+	leaseName string
+	leaseTTL  time.Duration
+	interval  time.Duration
+	location  *time.Location
+}
+
+// Option configures a Scheduler.
+type Option func(s *Scheduler)
+
+// WithHolder sets the identity of the scheduler in the lease. It defaults to the host name and
+// process ID, and must be unique among the schedulers sharing a database.
+func WithHolder(holder string) Option {
+	return func(s *Scheduler) {
+		s.holder = holder
+	}
+}
+
+// WithLeaseTTL sets how long the lease is held without being renewed. The default is 30 seconds;
+// it must exceed the time taken to fire the schedules that are due at once.
+func WithLeaseTTL(ttl time.Duration) Option {
+	return func(s *Scheduler) {
+		s.leaseTTL = ttl
+	}
+}
+
+// WithInterval sets the time Run waits between passes. The default is 10 seconds.
+func WithInterval(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.interval = d
+	}
+}
+
+// WithLocation sets the time zone of cron expressions. The default is UTC.
+func WithLocation(loc *time.Location) Option {
+	return func(s *Scheduler) {
+		s.location = loc
+	}
+}
+
+// New creates a scheduler loading machines through manager.
+func New(manager *fsm.Manager, opts ...Option) *Scheduler {
+	host, _ := os.Hostname()
+	s := &Scheduler{
+		manager:   manager,
+		client:    manager.Client(),
+		holder:    host + ":" + strconv.Itoa(os.Getpid()),
+		leaseName: "scheduler",
+		leaseTTL:  30 * time.Second,
+		interval:  10 * time.Second,
+		location:  time.UTC,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Add stores a schedule, replacing any schedule with the same name.
+func (s *Scheduler) Add(ctx context.Context, sched Schedule) error {
+	if sched.Name == "" || sched.Event == "" {
+		return errors.New("schedules require a name and an event")
+	}
+	if sched.Target == (Target{}) {
+		return fmt.Errorf("schedule %s has no target", sched.Name)
+	}
+
+	next := sched.At
+	if sched.Cron != "" {
+		c, err := ParseCron(sched.Cron)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", sched.Name, err)
+		}
+		if next = c.Next(time.Now().In(s.location)); next.IsZero() {
+			return fmt.Errorf("schedule %s: cron expression %q never matches", sched.Name, sched.Cron)
+		}
+	} else if next.IsZero() {
+		return fmt.Errorf("schedule %s requires a cron expression or a time", sched.Name)
+	}
+
+	args, err := json.Marshal(sched.Args)
+	if err != nil {
+		return fmt.Errorf("failed to encode arguments of schedule %s: %w", sched.Name, err)
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if _, err := tx.Schedule.Delete().Where(schedule.Name(sched.Name)).Exec(ctx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to replace schedule %s: %w", sched.Name, err)
+	}
+	err = tx.Schedule.Create().
+		SetName(sched.Name).
+		SetCron(sched.Cron).
+		SetEvent(string(sched.Event)).
+		SetArgs(args).
+		SetMachineID(sched.Target.MachineID).
+		SetDefinitionName(sched.Target.Definition).
+		SetState(string(sched.Target.State)).
+		SetNextRunAt(next).
+		Exec(ctx)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to store schedule %s: %w", sched.Name, err)
+	}
+	return tx.Commit()
+}
+
+// Remove deletes the schedule with the given name.
+func (s *Scheduler) Remove(ctx context.Context, name string) error {
+	if _, err := s.client.Schedule.Delete().Where(schedule.Name(name)).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove schedule %s: %w", name, err)
+	}
+	return nil
+}
+
+// Run fires due schedules every interval until ctx is done or a pass fails, then releases the lease.
+func (s *Scheduler) Run(ctx context.Context) error {
+	defer s.release(context.WithoutCancel(ctx))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce fires the schedules that are due if the scheduler holds the lease, acquiring or renewing
+// it first, and returns the number fired. Errors returned by transitions are recorded as the last
+// error of their schedule rather than returned. A recurring schedule that missed several runs,
+// for example while no scheduler was running, fires once.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	held, err := s.acquire(ctx)
+	if err != nil || !held {
+		return 0, err
+	}
+
+	now := time.Now().In(s.location)
+	due, err := s.client.Schedule.Query().
+		Where(schedule.Enabled(true), schedule.NextRunAtLTE(now)).
+		Order(ent.Asc(schedule.FieldNextRunAt)).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query schedules: %w", err)
+	}
+
+	for i, sched := range due {
+		ferr := s.fire(ctx, sched)
+
+		lastError := ""
+		if ferr != nil {
+			lastError = ferr.Error()
+		}
+		update := sched.Update().SetLastRunAt(sched.NextRunAt).SetLastError(lastError)
+		if sched.Cron == "" {
+			update.SetEnabled(false)
+		} else if c, err := ParseCron(sched.Cron); err != nil {
+			update.SetEnabled(false).SetLastError(err.Error())
+		} else if next := c.Next(now); next.IsZero() {
+			update.SetEnabled(false)
+		} else {
+			update.SetNextRunAt(next)
+		}
+		if err := update.Exec(ctx); err != nil {
+			return i, fmt.Errorf("failed to update schedule %s: %w", sched.Name, err)
+		}
+	}
+	return len(due), nil
+}
+
+// fire sends the event of a schedule to its target machines.
+func (s *Scheduler) fire(ctx context.Context, sched *ent.Schedule) error {
+	var args []interface{}
+	if len(sched.Args) > 0 {
+		if err := json.Unmarshal(sched.Args, &args); err != nil {
+			return fmt.Errorf("failed to decode arguments: %w", err)
+		}
+	}
+
+	machineIDs := []string{sched.MachineID}
+	if sched.MachineID == "" {
+		query := s.client.StateMachine.Query()
+		if sched.DefinitionName != "" {
+			query = query.Where(statemachine.DefinitionName(sched.DefinitionName))
+		}
+		if sched.State != "" {
+			query = query.Where(statemachine.CurrentState(sched.State))
+		}
+		var err error
+		if machineIDs, err = query.Select(statemachine.FieldMachineID).Strings(ctx); err != nil {
+			return fmt.Errorf("failed to select machines: %w", err)
+		}
+	}
+
+	key := fmt.Sprintf("schedule:%s:%d", sched.Name, sched.NextRunAt.Unix())
+	var errs []error
+	for _, id := range machineIDs {
+		f, err := s.manager.LoadFSM(ctx, id)
+		if err == nil {
+			err = f.Transition(fsm.WithIdempotencyKey(ctx, key), fsm.Event(sched.Event), args...)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("machine %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,207 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/enttest"
+	"github.com/shinhauhuang/go-fsm/ent/schedule"
+	"github.com/shinhauhuang/go-fsm/fsm"
+
+	_ "github.com/mattn/go-sqlite3" // Driver for SQLite
+)
+
+func setupTestClient(t *testing.T) *ent.Client {
+	return enttest.Open(t, "sqlite3", "file:scheduler?mode=memory&cache=shared&_fk=1")
+}
+
+const (
+	statePending fsm.State = "pending"
+	stateDone    fsm.State = "done"
+)
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	reconciled := make(map[string]int)
+	def := &fsm.Definition{
+		Name:    "reconciled",
+		Initial: statePending,
+		Transitions: []fsm.Transition{
+			{From: statePending, Event: "reconcile", To: statePending},
+			{From: statePending, Event: "finish", To: stateDone},
+		},
+		Setup: func(f *fsm.FSM) error {
+			_, err := f.OnTransition(statePending, "reconcile", func(ctx context.Context, args ...interface{}) error {
+				reconciled[args[0].(string)]++
+				return nil
+			})
+			return err
+		},
+	}
+	m := fsm.NewManager(client)
+	if err := m.Register(def); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	for _, id := range []string{"scheduled_1", "scheduled_2", "scheduled_3"} {
+		if _, err := m.NewFSM(ctx, "reconciled", id); err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+	}
+	done, _ := m.LoadFSM(ctx, "scheduled_3")
+	if err := done.Transition(ctx, "finish"); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+
+	// makeDue moves the next run of a schedule to the given time.
+	makeDue := func(t *testing.T, name string, at time.Time) {
+		t.Helper()
+		if err := client.Schedule.Update().Where(schedule.Name(name)).SetNextRunAt(at).Exec(ctx); err != nil {
+			t.Fatalf("Failed to update schedule: %v", err)
+		}
+	}
+	s := New(m, WithHolder("scheduler-a"))
+
+	t.Run("Validation", func(t *testing.T) {
+		invalid := []Schedule{
+			{Name: "no target", Cron: "@daily", Event: "reconcile"},
+			{Name: "no time", Event: "reconcile", Target: Target{MachineID: "scheduled_1"}},
+			{Name: "bad cron", Cron: "* * *", Event: "reconcile", Target: Target{MachineID: "scheduled_1"}},
+		}
+		for _, sched := range invalid {
+			if err := s.Add(ctx, sched); err == nil {
+				t.Errorf("Expected error for schedule %q, got nil", sched.Name)
+			}
+		}
+	})
+
+	t.Run("Cron schedule with a state selector", func(t *testing.T) {
+		err := s.Add(ctx, Schedule{
+			Name:   "nightly",
+			Cron:   "0 2 * * *",
+			Event:  "reconcile",
+			Args:   []interface{}{"nightly"},
+			Target: Target{Definition: "reconciled", State: statePending},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if n, err := s.RunOnce(ctx); err != nil || n != 0 {
+			t.Fatalf("Expected nothing to be due, got %d, %v", n, err)
+		}
+
+		due := time.Now().Add(-time.Minute).Truncate(time.Second)
+		makeDue(t, "nightly", due)
+		if n, err := s.RunOnce(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected 1 schedule fired, got %d, %v", n, err)
+		}
+		if reconciled["nightly"] != 2 {
+			t.Errorf("Expected the 2 pending machines to be reconciled, got %d", reconciled["nightly"])
+		}
+
+		sched, err := client.Schedule.Query().Where(schedule.Name("nightly")).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query schedule: %v", err)
+		}
+		next := sched.NextRunAt.UTC()
+		if !sched.Enabled || !next.After(time.Now()) || next.Hour() != 2 || next.Minute() != 0 {
+			t.Errorf("Expected next run at 02:00, got %v", next)
+		}
+
+		// Repeating the same run, as after a crash, does not apply the event again
+		makeDue(t, "nightly", due)
+		if _, err := s.RunOnce(ctx); err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+		if reconciled["nightly"] != 2 {
+			t.Errorf("Expected repeated run to be skipped, got %d reconciliations", reconciled["nightly"])
+		}
+	})
+
+	t.Run("One-off schedule", func(t *testing.T) {
+		err := s.Add(ctx, Schedule{
+			Name:   "once",
+			At:     time.Now().Add(-time.Second),
+			Event:  "reconcile",
+			Args:   []interface{}{"once"},
+			Target: Target{MachineID: "scheduled_1"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if n, err := s.RunOnce(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected 1 schedule fired, got %d, %v", n, err)
+		}
+		if n, err := s.RunOnce(ctx); err != nil || n != 0 {
+			t.Fatalf("Expected one-off schedule to fire once, got %d, %v", n, err)
+		}
+		if reconciled["once"] != 1 {
+			t.Errorf("Expected 1 reconciliation, got %d", reconciled["once"])
+		}
+	})
+
+	t.Run("Errors are recorded on the schedule", func(t *testing.T) {
+		err := s.Add(ctx, Schedule{
+			Name:   "invalid",
+			At:     time.Now().Add(-time.Second),
+			Event:  "reconcile",
+			Args:   []interface{}{"invalid"},
+			Target: Target{MachineID: "scheduled_3"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if _, err := s.RunOnce(ctx); err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+		sched, _ := client.Schedule.Query().Where(schedule.Name("invalid")).Only(ctx)
+		if sched.LastError == "" || sched.Enabled {
+			t.Errorf("Expected disabled schedule with an error, got %+v", sched)
+		}
+		if err := s.Remove(ctx, "invalid"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+	})
+
+	t.Run("Single runner", func(t *testing.T) {
+		other := New(m, WithHolder("scheduler-b"), WithLeaseTTL(time.Minute))
+		err := s.Add(ctx, Schedule{
+			Name:   "leased",
+			At:     time.Now().Add(-time.Second),
+			Event:  "reconcile",
+			Args:   []interface{}{"leased"},
+			Target: Target{MachineID: "scheduled_2"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if n, err := other.RunOnce(ctx); err != nil || n != 0 {
+			t.Fatalf("Expected scheduler without the lease to do nothing, got %d, %v", n, err)
+		}
+
+		if err := s.release(ctx); err != nil {
+			t.Fatalf("release failed: %v", err)
+		}
+		if n, err := other.RunOnce(ctx); err != nil || n != 1 {
+			t.Fatalf("Expected scheduler to take over the lease, got %d, %v", n, err)
+		}
+		if held, err := s.acquire(ctx); err != nil || held {
+			t.Errorf("Expected lease to be held by the other scheduler, got %v, %v", held, err)
+		}
+	})
+
+	t.Run("Expired leases are taken over", func(t *testing.T) {
+		short := New(m, WithHolder("scheduler-c"), WithLeaseTTL(-time.Second))
+		client.Lease.Delete().ExecX(ctx)
+		if held, err := short.acquire(ctx); err != nil || !held {
+			t.Fatalf("Expected lease to be acquired, got %v, %v", held, err)
+		}
+		if held, err := s.acquire(ctx); err != nil || !held {
+			t.Errorf("Expected expired lease to be taken over, got %v, %v", held, err)
+		}
+	})
+}
@@ -0,0 +1,388 @@
+// Package scxml converts between W3C SCXML documents and fsm definitions.
+//
+// Only the structural subset of SCXML that maps onto a flat fsm.Definition is supported:
+// <scxml>, <state>, <final>, <initial> and <transition> elements with event and target attributes.
+// Compound (nested) states are flattened on import: transitions declared on a parent apply to each
+// of its leaf descendants unless the descendant handles the same event itself, and a transition
+// targeting a compound state enters its initial leaf. Event names are matched exactly, not by prefix.
+// Executable content, data models, conditions, parallel and history states are rejected with an
+// error wrapping ErrUnsupported.
+package scxml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+const (
+	// Namespace is the SCXML namespace URI.
+	Namespace = "http://www.w3.org/2005/07/scxml"
+	// ExtensionNamespace qualifies go-fsm specific attributes such as fsm:version.
+	ExtensionNamespace = "https://github.com/shinhauhuang/go-fsm"
+)
+
+var (
+	// ErrUnsupported is returned for SCXML constructs that have no fsm equivalent.
+	ErrUnsupported = errors.New("unsupported SCXML construct")
+	// ErrInvalidDocument is returned for documents that are not well-formed SCXML.
+	ErrInvalidDocument = errors.New("invalid SCXML document")
+)
+
+// element is a generic XML element used to walk the document tree.
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []element  `xml:",any"`
+}
+
+// attr returns the value of the un-namespaced attribute with the given name.
+func (e *element) attr(name string) (string, bool) {
+	for _, a := range e.Attrs {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// stateNode is a <state> or <final> element of the document.
+type stateNode struct {
+	id          string
+	parent      *stateNode
+	children    []*stateNode
+	initial     string
+	final       bool
+	transitions []transitionNode
+}
+
+// transitionNode is a <transition> element of the document.
+type transitionNode struct {
+	events []string
+	target string
+}
+
+// parser holds the state collected while walking a document.
+type parser struct {
+	nodes  map[string]*stateNode
+	leaves []*stateNode
+}
+
+// Decode reads an SCXML document from r and converts it into a definition.
+func Decode(r io.Reader) (*fsm.Definition, error) {
+	var root element
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	if root.XMLName.Local != "scxml" || (root.XMLName.Space != "" && root.XMLName.Space != Namespace) {
+		return nil, fmt.Errorf("%w: root element must be <scxml>, got <%s>", ErrInvalidDocument, root.XMLName.Local)
+	}
+	if dm, ok := root.attr("datamodel"); ok && dm != "null" {
+		return nil, fmt.Errorf("%w: datamodel %q", ErrUnsupported, dm)
+	}
+
+	p := &parser{nodes: make(map[string]*stateNode)}
+	top := &stateNode{}
+	top.initial, _ = root.attr("initial")
+	for i := range root.Children {
+		if err := p.visit(&root.Children[i], top); err != nil {
+			return nil, err
+		}
+	}
+	if len(top.children) == 0 {
+		return nil, fmt.Errorf("%w: document declares no states", ErrInvalidDocument)
+	}
+
+	def := &fsm.Definition{}
+	def.Name, _ = root.attr("name")
+	for _, a := range root.Attrs {
+		if a.Name.Space == ExtensionNamespace && a.Name.Local == "version" {
+			v, err := strconv.Atoi(a.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: fsm:version %q is not an integer", ErrInvalidDocument, a.Value)
+			}
+			def.Version = v
+		}
+	}
+
+	initial, err := p.resolve(top)
+	if err != nil {
+		return nil, err
+	}
+	def.Initial = fsm.State(initial.id)
+
+	for _, leaf := range p.leaves {
+		def.States = append(def.States, fsm.State(leaf.id))
+		if leaf.final {
+			def.Final = append(def.Final, fsm.State(leaf.id))
+		}
+
+		// Innermost transitions take priority, mirroring SCXML's document-order selection.
+		handled := make(map[string]bool)
+		for n := leaf; n != top; n = n.parent {
+			for _, t := range n.transitions {
+				target, ok := p.nodes[t.target]
+				if !ok {
+					return nil, fmt.Errorf("%w: transition in state %s targets unknown state %s", ErrInvalidDocument, n.id, t.target)
+				}
+				to, err := p.resolve(target)
+				if err != nil {
+					return nil, err
+				}
+				for _, ev := range t.events {
+					if handled[ev] {
+						continue
+					}
+					handled[ev] = true
+					def.Transitions = append(def.Transitions, fsm.Transition{
+						From:  fsm.State(leaf.id),
+						Event: fsm.Event(ev),
+						To:    fsm.State(to.id),
+					})
+				}
+			}
+		}
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	return def, nil
+}
+
+// visit records a child element of the <scxml> root or of a compound state.
+func (p *parser) visit(el *element, parent *stateNode) error {
+	if el.XMLName.Space != "" && el.XMLName.Space != Namespace {
+		return fmt.Errorf("%w: foreign element <%s>", ErrUnsupported, el.XMLName.Local)
+	}
+
+	switch el.XMLName.Local {
+	case "state", "final":
+		id, _ := el.attr("id")
+		if id == "" {
+			return fmt.Errorf("%w: <%s> without id", ErrUnsupported, el.XMLName.Local)
+		}
+		if _, dup := p.nodes[id]; dup {
+			return fmt.Errorf("%w: duplicate state id %s", ErrInvalidDocument, id)
+		}
+		node := &stateNode{id: id, parent: parent, final: el.XMLName.Local == "final"}
+		if node.final && parent.parent != nil {
+			return fmt.Errorf("%w: <final> %s inside compound state %s", ErrUnsupported, id, parent.id)
+		}
+		node.initial, _ = el.attr("initial")
+		p.nodes[id] = node
+		parent.children = append(parent.children, node)
+
+		for i := range el.Children {
+			child := &el.Children[i]
+			if node.final {
+				return fmt.Errorf("%w: <%s> inside <final> %s", ErrUnsupported, child.XMLName.Local, id)
+			}
+			if err := p.visit(child, node); err != nil {
+				return err
+			}
+		}
+		if len(node.children) == 0 {
+			p.leaves = append(p.leaves, node)
+		}
+		return nil
+
+	case "transition":
+		if parent.parent == nil && parent.id == "" {
+			return fmt.Errorf("%w: <transition> outside of a state", ErrInvalidDocument)
+		}
+		t, err := parseTransition(el)
+		if err != nil {
+			return fmt.Errorf("%w in state %s", err, parent.id)
+		}
+		parent.transitions = append(parent.transitions, t)
+		return nil
+
+	case "initial":
+		if parent.id == "" {
+			return fmt.Errorf("%w: <initial> element at document level", ErrUnsupported)
+		}
+		if len(el.Children) != 1 || el.Children[0].XMLName.Local != "transition" {
+			return fmt.Errorf("%w: <initial> in state %s must contain exactly one <transition>", ErrInvalidDocument, parent.id)
+		}
+		target, _ := el.Children[0].attr("target")
+		if target == "" || len(el.Children[0].Children) > 0 {
+			return fmt.Errorf("%w: <initial> transition in state %s", ErrUnsupported, parent.id)
+		}
+		parent.initial = target
+		return nil
+
+	default:
+		return fmt.Errorf("%w: <%s> element", ErrUnsupported, el.XMLName.Local)
+	}
+}
+
+// parseTransition converts a <transition> element, rejecting anything beyond event and target.
+func parseTransition(el *element) (transitionNode, error) {
+	if len(el.Children) > 0 {
+		return transitionNode{}, fmt.Errorf("%w: executable content in <transition>", ErrUnsupported)
+	}
+	if _, ok := el.attr("cond"); ok {
+		return transitionNode{}, fmt.Errorf("%w: conditional <transition>", ErrUnsupported)
+	}
+
+	event, _ := el.attr("event")
+	target, _ := el.attr("target")
+	events := strings.Fields(event)
+	targets := strings.Fields(target)
+	switch {
+	case len(events) == 0:
+		return transitionNode{}, fmt.Errorf("%w: eventless <transition>", ErrUnsupported)
+	case len(targets) == 0:
+		return transitionNode{}, fmt.Errorf("%w: targetless <transition>", ErrUnsupported)
+	case len(targets) > 1:
+		return transitionNode{}, fmt.Errorf("%w: <transition> with multiple targets", ErrUnsupported)
+	}
+	for _, ev := range events {
+		if ev == "*" || strings.HasSuffix(ev, ".*") {
+			return transitionNode{}, fmt.Errorf("%w: wildcard event %q", ErrUnsupported, ev)
+		}
+	}
+	return transitionNode{events: events, target: targets[0]}, nil
+}
+
+// resolve returns the leaf state entered when entering n.
+func (p *parser) resolve(n *stateNode) (*stateNode, error) {
+	for len(n.children) > 0 {
+		if n.initial == "" {
+			n = n.children[0]
+			continue
+		}
+		next, ok := p.nodes[n.initial]
+		if !ok || !isDescendant(next, n) {
+			return nil, fmt.Errorf("%w: initial state %s is not a descendant of %q", ErrInvalidDocument, n.initial, n.id)
+		}
+		n = next
+	}
+	return n, nil
+}
+
+// isDescendant reports whether n is nested (at any depth) inside ancestor.
+func isDescendant(n, ancestor *stateNode) bool {
+	for p := n.parent; p != nil; p = p.parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Unmarshal parses an SCXML document into a definition.
+func Unmarshal(data []byte) (*fsm.Definition, error) {
+	return Decode(bytes.NewReader(data))
+}
+
+// ReadFile parses the SCXML document stored at path.
+func ReadFile(path string) (*fsm.Definition, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	def, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return def, nil
+}
+
+// xmlDocument is the serialised form of a definition.
+type xmlDocument struct {
+	XMLName     xml.Name      `xml:"http://www.w3.org/2005/07/scxml scxml"`
+	ExtensionNS string        `xml:"xmlns:fsm,attr,omitempty"`
+	Version     string        `xml:"version,attr"`
+	FSMVersion  string        `xml:"fsm:version,attr,omitempty"`
+	Name        string        `xml:"name,attr,omitempty"`
+	Initial     string        `xml:"initial,attr,omitempty"`
+	States      []interface{} `xml:""`
+}
+
+// xmlState is the serialised form of a non-final state.
+type xmlState struct {
+	XMLName     xml.Name        `xml:"state"`
+	ID          string          `xml:"id,attr"`
+	Transitions []xmlTransition `xml:"transition"`
+}
+
+// xmlFinal is the serialised form of a final state.
+type xmlFinal struct {
+	XMLName xml.Name `xml:"final"`
+	ID      string   `xml:"id,attr"`
+}
+
+// xmlTransition is the serialised form of a transition.
+type xmlTransition struct {
+	Event  string `xml:"event,attr"`
+	Target string `xml:"target,attr"`
+}
+
+// Encode writes def to w as an indented SCXML document.
+func Encode(w io.Writer, def *fsm.Definition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	doc := xmlDocument{Version: "1.0", Name: def.Name, Initial: string(def.Initial)}
+	if def.Version != 0 {
+		doc.ExtensionNS = ExtensionNamespace
+		doc.FSMVersion = strconv.Itoa(def.Version)
+	}
+	for _, s := range def.AllStates() {
+		// State IDs are also used in target, which holds a space-separated list
+		if strings.ContainsAny(string(s), " \t\r\n") || s == "" {
+			return fmt.Errorf("%w: state ID %q cannot be expressed in SCXML", ErrUnsupported, s)
+		}
+		var transitions []xmlTransition
+		for _, t := range def.Transitions {
+			if t.From != s {
+				continue
+			}
+			if strings.ContainsAny(string(t.Event), " \t\r\n") || t.Event == "" {
+				return fmt.Errorf("%w: event name %q cannot be expressed in SCXML", ErrUnsupported, t.Event)
+			}
+			transitions = append(transitions, xmlTransition{Event: string(t.Event), Target: string(t.To)})
+		}
+
+		if def.IsFinal(s) {
+			if len(transitions) > 0 {
+				return fmt.Errorf("%w: final state %s has outgoing transitions", ErrUnsupported, s)
+			}
+			doc.States = append(doc.States, xmlFinal{ID: string(s)})
+			continue
+		}
+		doc.States = append(doc.States, xmlState{ID: string(s), Transitions: transitions})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Marshal serialises def as an SCXML document.
+func Marshal(def *fsm.Definition) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, def); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
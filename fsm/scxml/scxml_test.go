@@ -0,0 +1,253 @@
+package scxml
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/fsm"
+)
+
+const turnstileSCXML = `<?xml version="1.0" encoding="UTF-8"?>
+<scxml xmlns="http://www.w3.org/2005/07/scxml" xmlns:fsm="https://github.com/shinhauhuang/go-fsm"
+       version="1.0" fsm:version="3" name="turnstile" initial="LOCKED">
+  <state id="LOCKED">
+    <transition event="COIN" target="UNLOCKED"/>
+  </state>
+  <state id="UNLOCKED">
+    <transition event="PUSH" target="LOCKED"/>
+    <transition event="BREAK" target="BROKEN"/>
+  </state>
+  <final id="BROKEN"/>
+</scxml>`
+
+const nestedSCXML = `<scxml xmlns="http://www.w3.org/2005/07/scxml" version="1.0">
+  <state id="active">
+    <initial><transition target="running"/></initial>
+    <transition event="stop" target="stopped"/>
+    <state id="idle">
+      <transition event="start" target="running"/>
+    </state>
+    <state id="running">
+      <transition event="pause" target="paused"/>
+      <transition event="stop" target="idle"/>
+    </state>
+    <state id="paused">
+      <transition event="resume reset" target="active"/>
+    </state>
+  </state>
+  <final id="stopped"/>
+</scxml>`
+
+func TestDecode(t *testing.T) {
+	t.Run("Flat document", func(t *testing.T) {
+		def, err := Unmarshal([]byte(turnstileSCXML))
+		if err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		expected := &fsm.Definition{
+			Name:    "turnstile",
+			Version: 3,
+			Initial: "LOCKED",
+			States:  []fsm.State{"LOCKED", "UNLOCKED", "BROKEN"},
+			Final:   []fsm.State{"BROKEN"},
+			Transitions: []fsm.Transition{
+				{From: "LOCKED", Event: "COIN", To: "UNLOCKED"},
+				{From: "UNLOCKED", Event: "PUSH", To: "LOCKED"},
+				{From: "UNLOCKED", Event: "BREAK", To: "BROKEN"},
+			},
+		}
+		if !reflect.DeepEqual(def, expected) {
+			t.Errorf("Expected definition %+v, got %+v", expected, def)
+		}
+	})
+
+	t.Run("Nested states are flattened", func(t *testing.T) {
+		def, err := Unmarshal([]byte(nestedSCXML))
+		if err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if def.Initial != "running" {
+			t.Errorf("Expected initial state running, got %s", def.Initial)
+		}
+		expectedStates := []fsm.State{"idle", "running", "paused", "stopped"}
+		if !reflect.DeepEqual(def.States, expectedStates) {
+			t.Errorf("Expected states %v, got %v", expectedStates, def.States)
+		}
+		expectedTransitions := []fsm.Transition{
+			{From: "idle", Event: "start", To: "running"},
+			{From: "idle", Event: "stop", To: "stopped"},
+			{From: "running", Event: "pause", To: "paused"},
+			{From: "running", Event: "stop", To: "idle"}, // Child transition overrides the parent's
+			{From: "paused", Event: "resume", To: "running"},
+			{From: "paused", Event: "reset", To: "running"},
+			{From: "paused", Event: "stop", To: "stopped"},
+		}
+		if !reflect.DeepEqual(def.Transitions, expectedTransitions) {
+			t.Errorf("Expected transitions %v, got %v", expectedTransitions, def.Transitions)
+		}
+	})
+
+	t.Run("Unsupported constructs", func(t *testing.T) {
+		tests := map[string]string{
+			"parallel":          `<parallel id="p"/>`,
+			"history":           `<state id="a"><history id="h"/></state>`,
+			"datamodel":         `<datamodel/>`,
+			"onentry":           `<state id="a"><onentry/></state>`,
+			"conditional":       `<state id="a"><transition event="e" cond="x" target="a"/></state>`,
+			"eventless":         `<state id="a"><transition target="a"/></state>`,
+			"targetless":        `<state id="a"><transition event="e"/></state>`,
+			"multiple targets":  `<state id="a"><transition event="e" target="a b"/></state><state id="b"/>`,
+			"wildcard event":    `<state id="a"><transition event="*" target="a"/></state>`,
+			"executable":        `<state id="a"><transition event="e" target="a"><log expr="1"/></transition></state>`,
+			"nested final":      `<state id="a"><final id="b"/></state>`,
+			"missing state id":  `<state/>`,
+			"foreign namespace": `<x:foo xmlns:x="urn:example"/>`,
+		}
+		for name, body := range tests {
+			t.Run(name, func(t *testing.T) {
+				doc := `<scxml xmlns="http://www.w3.org/2005/07/scxml" version="1.0">` + body + `</scxml>`
+				_, err := Unmarshal([]byte(doc))
+				if !errors.Is(err, ErrUnsupported) {
+					t.Errorf("Expected ErrUnsupported, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Invalid documents", func(t *testing.T) {
+		tests := map[string]string{
+			"not xml":        `<scxml`,
+			"wrong root":     `<statechart/>`,
+			"no states":      `<scxml/>`,
+			"unknown target": `<scxml><state id="a"><transition event="e" target="b"/></state></scxml>`,
+			"duplicate id":   `<scxml><state id="a"/><state id="a"/></scxml>`,
+			"bad version":    `<scxml xmlns:fsm="https://github.com/shinhauhuang/go-fsm" fsm:version="x"><state id="a"/></scxml>`,
+		}
+		for name, doc := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := Unmarshal([]byte(doc))
+				if !errors.Is(err, ErrInvalidDocument) {
+					t.Errorf("Expected ErrInvalidDocument, got %v", err)
+				}
+			})
+		}
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		def, err := Unmarshal([]byte(turnstileSCXML))
+		if err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		data, err := Marshal(def)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !strings.Contains(string(data), `fsm:version="3"`) {
+			t.Errorf("Expected fsm:version attribute in output, got:\n%s", data)
+		}
+		if !strings.Contains(string(data), `<final id="BROKEN"></final>`) {
+			t.Errorf("Expected final state in output, got:\n%s", data)
+		}
+
+		decoded, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal of encoded document failed: %v\n%s", err, data)
+		}
+		if !reflect.DeepEqual(decoded, def) {
+			t.Errorf("Round trip mismatch: expected %+v, got %+v", def, decoded)
+		}
+	})
+
+	t.Run("Round trip of flattened nested document", func(t *testing.T) {
+		def, err := Unmarshal([]byte(nestedSCXML))
+		if err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		data, err := Marshal(def)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		decoded, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal of encoded document failed: %v", err)
+		}
+		if !reflect.DeepEqual(decoded, def) {
+			t.Errorf("Round trip mismatch: expected %+v, got %+v", def, decoded)
+		}
+	})
+
+	t.Run("Definition built in code", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial: "idle",
+			Transitions: []fsm.Transition{
+				{From: "idle", Event: "start", To: "running"},
+				{From: "running", Event: "stop", To: "idle"},
+			},
+		}
+
+		data, err := Marshal(def)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		decoded, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !reflect.DeepEqual(decoded.Transitions, def.Transitions) || decoded.Initial != def.Initial {
+			t.Errorf("Round trip mismatch: expected %+v, got %+v", def, decoded)
+		}
+	})
+
+	t.Run("Final state with outgoing transitions", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial:     "a",
+			Final:       []fsm.State{"a"},
+			Transitions: []fsm.Transition{{From: "a", Event: "e", To: "b"}},
+		}
+		if _, err := Marshal(def); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported, got %v", err)
+		}
+	})
+
+	t.Run("Event name with whitespace", func(t *testing.T) {
+		def := &fsm.Definition{
+			Initial:     "a",
+			Transitions: []fsm.Transition{{From: "a", Event: "two words", To: "a"}},
+		}
+		if _, err := Marshal(def); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported, got %v", err)
+		}
+	})
+
+	t.Run("State ID with whitespace", func(t *testing.T) {
+		defs := map[string]*fsm.Definition{
+			"id and target": {
+				Initial:     "a",
+				Transitions: []fsm.Transition{{From: "a", Event: "e", To: "in progress"}},
+			},
+			"initial": {
+				Initial:     "not started",
+				Transitions: []fsm.Transition{{From: "not started", Event: "e", To: "b"}},
+			},
+		}
+		for name, def := range defs {
+			t.Run(name, func(t *testing.T) {
+				data, err := Marshal(def)
+				if !errors.Is(err, ErrUnsupported) {
+					t.Fatalf("Expected ErrUnsupported, got %v", err)
+				}
+				if data != nil {
+					t.Errorf("Expected no document for a definition that cannot round trip")
+				}
+			})
+		}
+	})
+}
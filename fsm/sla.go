@@ -0,0 +1,268 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/predicate"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// SLA limits how long machines of a definition may stay in a state.
+type SLA struct {
+	State  State
+	Within time.Duration
+	// Event, if set, is fired by ProcessSLABreaches into machines breaching the SLA, with the
+	// SLABreach as its argument. It must be defined from State.
+	Event Event
+}
+
+// EnteredAt returns when the machine entered its current state. A self-transition does not
+// change it.
+func (f *FSM) EnteredAt() time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.enteredAt
+}
+
+// TimeInState returns how long the machine has been in its current state.
+func (f *FSM) TimeInState() time.Duration {
+	return time.Since(f.EnteredAt())
+}
+
+// StateVisit is a stay of a machine in a state, derived from its history.
+type StateVisit struct {
+	State     State
+	EnteredAt time.Time
+	ExitedAt  time.Time     // Zero for the current state
+	Duration  time.Duration // Up to now for the current state
+}
+
+// Visits returns the states a machine went through, in order, with the time spent in each.
+// The first visit starts when the machine was created. Failed attempts, suspensions and
+// self-transitions do not start a new visit, and a migration renames the current one.
+func Visits(ctx context.Context, client *ent.Client, machineID string) ([]StateVisit, error) {
+	sm, err := client.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query state machine with ID %s: %w", machineID, err)
+	}
+	return machineVisits(ctx, client, sm)
+}
+
+// machineVisits derives the visits of sm from its history. See Visits.
+func machineVisits(ctx context.Context, client *ent.Client, sm *ent.StateMachine) ([]StateVisit, error) {
+	var visits []StateVisit
+	start := sm.CreatedAt
+	for entry, err := range HistoryEntries(ctx, client, HistoryFilter{MachineID: sm.MachineID}) {
+		if err != nil {
+			return nil, err
+		}
+		if len(visits) == 0 && entry.Timestamp.Before(start) {
+			// Machines created before creation times were recorded have older history
+			start = entry.Timestamp
+		}
+		switch entry.Kind {
+		case statetransition.KindFailed, statetransition.KindSuspend, statetransition.KindResume:
+			continue
+		}
+		if len(visits) == 0 {
+			visits = append(visits, StateVisit{State: State(entry.FromState), EnteredAt: start})
+		}
+		current := &visits[len(visits)-1]
+		switch {
+		case entry.Kind == statetransition.KindMigration:
+			current.State = State(entry.ToState)
+		case State(entry.ToState) != current.State:
+			current.ExitedAt = entry.Timestamp
+			visits = append(visits, StateVisit{State: State(entry.ToState), EnteredAt: entry.Timestamp})
+		}
+	}
+	if len(visits) == 0 {
+		visits = append(visits, StateVisit{State: State(sm.CurrentState), EnteredAt: start})
+	}
+
+	now := time.Now()
+	for i := range visits {
+		end := visits[i].ExitedAt
+		if end.IsZero() {
+			end = now
+		}
+		visits[i].Duration = end.Sub(visits[i].EnteredAt)
+	}
+	return visits, nil
+}
+
+// stateEnteredAt returns when sm entered its current state, derived from its history for machines
+// created before it was recorded.
+func stateEnteredAt(ctx context.Context, client *ent.Client, sm *ent.StateMachine) (time.Time, error) {
+	if sm.EnteredAt != nil {
+		return *sm.EnteredAt, nil
+	}
+	visits, err := machineVisits(ctx, client, sm)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return visits[len(visits)-1].EnteredAt, nil
+}
+
+// BackfillEnteredAt records when machines created before state entry times were recorded entered
+// their current state, derived from their history, and returns the number of machines updated.
+// Machines without history are taken to have entered their state when they were created.
+// ListSLABreaches backfills the machines it checks by itself.
+func BackfillEnteredAt(ctx context.Context, client *ent.Client) (int, error) {
+	return backfillEnteredAt(ctx, client)
+}
+
+// backfillEnteredAt backfills the entry time of the machines selected by ps.
+func backfillEnteredAt(ctx context.Context, client *ent.Client, ps ...predicate.StateMachine) (int, error) {
+	n := 0
+	for {
+		machines, err := client.StateMachine.Query().
+			Where(statemachine.EnteredAtIsNil()).
+			Where(ps...).
+			Order(ent.Asc(statemachine.FieldID)).
+			Limit(100).
+			All(ctx)
+		if err != nil {
+			return n, fmt.Errorf("failed to query machines without state entry time: %w", err)
+		}
+		if len(machines) == 0 {
+			return n, nil
+		}
+		for _, sm := range machines {
+			enteredAt, err := stateEnteredAt(ctx, client, sm)
+			if err != nil {
+				return n, err
+			}
+			// Keep the update time, which retention relies on, and leave machines that changed
+			// state meanwhile alone
+			updated, err := client.StateMachine.Update().
+				Where(statemachine.ID(sm.ID), statemachine.EnteredAtIsNil()).
+				SetEnteredAt(enteredAt).
+				SetUpdatedAt(sm.UpdatedAt).
+				Save(ctx)
+			if err != nil {
+				return n, fmt.Errorf("failed to backfill state entry time of machine %s: %w", sm.MachineID, err)
+			}
+			n += updated
+		}
+	}
+}
+
+// TimeInStates returns the total time a machine spent in each state it went through, including
+// the time spent so far in its current state. See Visits.
+func TimeInStates(ctx context.Context, client *ent.Client, machineID string) (map[State]time.Duration, error) {
+	visits, err := Visits(ctx, client, machineID)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[State]time.Duration)
+	for _, v := range visits {
+		durations[v.State] += v.Duration
+	}
+	return durations, nil
+}
+
+// SLABreach is a machine that stayed in a state longer than the SLA of its definition allows.
+type SLABreach struct {
+	MachineID  string
+	Definition string
+	State      State
+	EnteredAt  time.Time
+	Deadline   time.Time
+	Event      Event // Event of the SLA, if any
+	Err        error // Error of the event, set by ProcessSLABreaches
+}
+
+// ListSLABreaches returns the machines of the registered definitions that are in a state for
+// longer than its SLA allows, ordered by definition name and then by deadline.
+func (m *Manager) ListSLABreaches(ctx context.Context) ([]SLABreach, error) {
+	m.mu.RLock()
+	defs := make([]*Definition, 0, len(m.definitions))
+	for _, def := range m.definitions {
+		if len(def.SLAs) > 0 {
+			defs = append(defs, def)
+		}
+	}
+	m.mu.RUnlock()
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+
+	now := time.Now()
+	var breaches []SLABreach
+	for _, def := range defs {
+		if _, err := backfillEnteredAt(ctx, m.client, statemachine.DefinitionName(def.Name)); err != nil {
+			return nil, err
+		}
+		var found []SLABreach
+		for _, sla := range def.SLAs {
+			machines, err := m.client.StateMachine.Query().
+				Where(
+					statemachine.DefinitionName(def.Name),
+					statemachine.CurrentState(string(sla.State)),
+					statemachine.EnteredAtLT(now.Add(-sla.Within)),
+				).
+				All(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query SLA breaches of %s in state %s: %w", def.Name, sla.State, err)
+			}
+			for _, sm := range machines {
+				found = append(found, SLABreach{
+					MachineID:  sm.MachineID,
+					Definition: def.Name,
+					State:      sla.State,
+					EnteredAt:  *sm.EnteredAt,
+					Deadline:   sm.EnteredAt.Add(sla.Within),
+					Event:      sla.Event,
+				})
+			}
+		}
+		sort.SliceStable(found, func(i, j int) bool { return found[i].Deadline.Before(found[j].Deadline) })
+		breaches = append(breaches, found...)
+	}
+	return breaches, nil
+}
+
+// ProcessSLABreaches lists the SLA breaches and fires the event of the SLA, if any, into each
+// breaching machine. Events are sent with an idempotency key per stay in the state, so a breach
+// is acted on once even when checks overlap. The error of each event is reported in its breach;
+// ProcessSLABreaches returns an error only when the breaches cannot be listed.
+func (m *Manager) ProcessSLABreaches(ctx context.Context) ([]SLABreach, error) {
+	breaches, err := m.ListSLABreaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range breaches {
+		b := &breaches[i]
+		if b.Event == "" {
+			continue
+		}
+		f, err := m.LoadFSM(ctx, b.MachineID)
+		if err != nil {
+			b.Err = err
+			continue
+		}
+		key := fmt.Sprintf("sla:%s:%d", b.State, b.EnteredAt.UnixNano())
+		b.Err = f.Transition(WithIdempotencyKey(ctx, key), b.Event, *b)
+	}
+	return breaches, nil
+}
+
+// RunSLAChecks processes SLA breaches every interval until ctx is done or listing them fails.
+func (m *Manager) RunSLAChecks(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.ProcessSLABreaches(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+func TestSLADefinition(t *testing.T) {
+	base := func(slas ...SLA) *Definition {
+		return &Definition{Initial: StateIdle, Transitions: defineTestTransitions(), SLAs: slas}
+	}
+	tests := []struct {
+		name string
+		def  *Definition
+		ok   bool
+	}{
+		{"valid", base(SLA{State: StatePaused, Within: time.Hour, Event: EventStop}), true},
+		{"unknown state", base(SLA{State: "unknown", Within: time.Hour}), false},
+		{"duplicate", base(SLA{State: StatePaused, Within: time.Hour}, SLA{State: StatePaused, Within: time.Minute}), false},
+		{"no duration", base(SLA{State: StatePaused}), false},
+		{"undefined event", base(SLA{State: StateIdle, Within: time.Hour, Event: EventStop}), false},
+	}
+	for _, tt := range tests {
+		if err := tt.def.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: unexpected validation result %v", tt.name, err)
+		}
+	}
+}
+
+func TestTimeInState(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	transitions := append(defineTestTransitions(), Transition{From: StateRunning, Event: "tick", To: StateRunning})
+
+	t.Run("Entered at", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "sla_entered", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		entered := f.EnteredAt()
+		if err := f.Transition(ctx, "tick"); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if !f.EnteredAt().Equal(entered) {
+			t.Errorf("Expected a self-transition to keep the entry time")
+		}
+
+		loaded, err := LoadFSM(ctx, client, "sla_entered", transitions)
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		if !loaded.EnteredAt().Equal(entered) {
+			t.Errorf("Expected entry time %v to be persisted, got %v", entered, loaded.EnteredAt())
+		}
+		if d := loaded.TimeInState(); d < 0 || d > time.Minute {
+			t.Errorf("Unexpected time in state %v", d)
+		}
+
+		if err := f.ForceState(ctx, Override{To: StatePaused, Reason: "test", Actor: "alice"}); err != nil {
+			t.Fatalf("ForceState failed: %v", err)
+		}
+		if !f.EnteredAt().After(entered) {
+			t.Errorf("Expected an override to update the entry time")
+		}
+	})
+
+	t.Run("Visits", func(t *testing.T) {
+		t0 := time.Now().Add(-10 * time.Hour).Truncate(time.Second)
+		err := client.StateMachine.Create().
+			SetMachineID("sla_visits").
+			SetCurrentState(string(StateIdle)).
+			SetCreatedAt(t0).
+			SetEnteredAt(t0).
+			Exec(ctx)
+		if err != nil {
+			t.Fatalf("Failed to create machine: %v", err)
+		}
+		f, err := LoadFSM(ctx, client, "sla_visits", transitions)
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		for _, event := range []Event{EventStart, "tick", EventPause, EventResume} {
+			if err := f.Transition(ctx, event); err != nil {
+				t.Fatalf("Transition failed: %v", err)
+			}
+		}
+		if err := f.Suspend(ctx, "test", "alice"); err != nil {
+			t.Fatalf("Suspend failed: %v", err)
+		}
+
+		// Move the transitions to 1h, 2h, 3h and 6h after creation
+		entries := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID("sla_visits")), statetransition.KindEQ(statetransition.KindTransition)).
+			Order(statetransition.ByID()).
+			AllX(ctx)
+		for i, hours := range []int{1, 2, 3, 6} {
+			entries[i].Update().SetTimestamp(t0.Add(time.Duration(hours) * time.Hour)).ExecX(ctx)
+		}
+
+		visits, err := Visits(ctx, client, "sla_visits")
+		if err != nil {
+			t.Fatalf("Visits failed: %v", err)
+		}
+		expected := []struct {
+			state    State
+			duration time.Duration
+		}{
+			{StateIdle, time.Hour},
+			{StateRunning, 2 * time.Hour},
+			{StatePaused, 3 * time.Hour},
+			{StateRunning, 4 * time.Hour},
+		}
+		if len(visits) != len(expected) {
+			t.Fatalf("Expected %d visits, got %+v", len(expected), visits)
+		}
+		for i, e := range expected {
+			v := visits[i]
+			if v.State != e.state || v.Duration < e.duration || v.Duration > e.duration+time.Minute {
+				t.Errorf("Visit %d: expected %s for %v, got %s for %v", i, e.state, e.duration, v.State, v.Duration)
+			}
+		}
+		if !visits[0].EnteredAt.Equal(t0) || !visits[3].ExitedAt.IsZero() {
+			t.Errorf("Unexpected bounds of visits %+v", visits)
+		}
+
+		durations, err := TimeInStates(ctx, client, "sla_visits")
+		if err != nil {
+			t.Fatalf("TimeInStates failed: %v", err)
+		}
+		if d := durations[StateRunning]; d < 6*time.Hour || d > 6*time.Hour+time.Minute {
+			t.Errorf("Expected about 6h in %s, got %v", StateRunning, d)
+		}
+
+		if _, err := Visits(ctx, client, "sla_missing"); err == nil {
+			t.Errorf("Expected error for missing machine, got nil")
+		}
+	})
+
+	t.Run("Machines without a recorded entry time", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "sla_legacy", StateIdle, transitions)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		for _, event := range []Event{EventStart, EventPause} {
+			if err := f.Transition(ctx, event); err != nil {
+				t.Fatalf("Transition failed: %v", err)
+			}
+		}
+
+		// Make it look like a machine created before entry times were recorded, whose creation
+		// time was set when the column was added
+		t0 := time.Now().Add(-10 * time.Hour).Truncate(time.Second)
+		entries := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID("sla_legacy"))).
+			Order(statetransition.ByID()).
+			AllX(ctx)
+		for i, hours := range []int{1, 2} {
+			entries[i].Update().SetTimestamp(t0.Add(time.Duration(hours) * time.Hour)).ExecX(ctx)
+		}
+		updatedAt := t0.Add(2 * time.Hour)
+		client.StateMachine.Update().
+			Where(statemachine.MachineID("sla_legacy")).
+			ClearEnteredAt().
+			SetUpdatedAt(updatedAt).
+			ExecX(ctx)
+
+		loaded, err := LoadFSM(ctx, client, "sla_legacy", transitions)
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		if !loaded.EnteredAt().Equal(t0.Add(2 * time.Hour)) {
+			t.Errorf("Expected entry time to be derived from history, got %v", loaded.EnteredAt())
+		}
+		visits, err := Visits(ctx, client, "sla_legacy")
+		if err != nil {
+			t.Fatalf("Visits failed: %v", err)
+		}
+		if len(visits) != 3 || !visits[0].EnteredAt.Equal(t0.Add(time.Hour)) || visits[0].Duration < 0 {
+			t.Errorf("Expected the first visit to start with the history, got %+v", visits)
+		}
+
+		n, err := BackfillEnteredAt(ctx, client)
+		if err != nil || n != 1 {
+			t.Fatalf("Expected 1 machine backfilled, got %d, %v", n, err)
+		}
+		sm := client.StateMachine.Query().Where(statemachine.MachineID("sla_legacy")).OnlyX(ctx)
+		if sm.EnteredAt == nil || !sm.EnteredAt.Equal(t0.Add(2*time.Hour)) || !sm.UpdatedAt.Equal(updatedAt) {
+			t.Errorf("Unexpected backfilled machine %+v", sm)
+		}
+	})
+}
+
+func TestSLABreaches(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	m := NewManager(client)
+	err := m.Register(&Definition{
+		Name:        "sla",
+		Initial:     StateIdle,
+		Transitions: defineTestTransitions(),
+		SLAs: []SLA{
+			{State: StatePaused, Within: time.Hour, Event: EventStop},
+			{State: StateRunning, Within: 24 * time.Hour},
+		},
+		Setup: func(f *FSM) error {
+			_, err := f.OnTransition(StatePaused, EventStop, func(ctx context.Context, args ...interface{}) error {
+				if b, ok := args[0].(SLABreach); !ok || b.State != StatePaused {
+					return errors.New("expected the breach as argument")
+				}
+				return nil
+			})
+			return err
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	machine := func(t *testing.T, id string, since time.Duration, events ...Event) {
+		t.Helper()
+		f, err := m.NewFSM(ctx, "sla", id)
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		for _, event := range events {
+			if err := f.Transition(ctx, event); err != nil {
+				t.Fatalf("Transition failed: %v", err)
+			}
+		}
+		client.StateMachine.Update().
+			Where(statemachine.MachineID(id)).
+			SetEnteredAt(time.Now().Add(-since)).
+			ExecX(ctx)
+	}
+	machine(t, "sla_paused_long", 3*time.Hour, EventStart, EventPause)
+	machine(t, "sla_paused_longer", 5*time.Hour, EventStart, EventPause)
+	machine(t, "sla_paused_recent", time.Minute, EventStart, EventPause)
+	machine(t, "sla_running", 2*time.Hour, EventStart)
+	machine(t, "sla_running_long", 48*time.Hour, EventStart)
+	// A machine created before entry times were recorded, paused for 4h according to its history
+	machine(t, "sla_paused_legacy", 0, EventStart, EventPause)
+	client.StateTransition.Update().
+		Where(statetransition.HasMachineWith(statemachine.MachineID("sla_paused_legacy"))).
+		SetTimestamp(time.Now().Add(-4 * time.Hour)).
+		ExecX(ctx)
+	client.StateMachine.Update().Where(statemachine.MachineID("sla_paused_legacy")).ClearEnteredAt().ExecX(ctx)
+
+	breaches, err := m.ListSLABreaches(ctx)
+	if err != nil {
+		t.Fatalf("ListSLABreaches failed: %v", err)
+	}
+	var ids []string
+	for _, b := range breaches {
+		ids = append(ids, b.MachineID)
+	}
+	expected := []string{"sla_running_long", "sla_paused_longer", "sla_paused_legacy", "sla_paused_long"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("Expected breaches ordered by deadline, got %v", ids)
+	}
+	if b := breaches[1]; b.State != StatePaused || b.Event != EventStop || !b.Deadline.Equal(b.EnteredAt.Add(time.Hour)) {
+		t.Errorf("Unexpected breach %+v", b)
+	}
+
+	breaches, err = m.ProcessSLABreaches(ctx)
+	if err != nil {
+		t.Fatalf("ProcessSLABreaches failed: %v", err)
+	}
+	for _, b := range breaches {
+		if b.Err != nil {
+			t.Errorf("Unexpected error for %s: %v", b.MachineID, b.Err)
+		}
+	}
+	for _, id := range []string{"sla_paused_long", "sla_paused_longer", "sla_paused_legacy"} {
+		sm := client.StateMachine.Query().Where(statemachine.MachineID(id)).OnlyX(ctx)
+		if State(sm.CurrentState) != StateStopped {
+			t.Errorf("Expected %s to be stopped, got %s", id, sm.CurrentState)
+		}
+	}
+
+	breaches, err = m.ListSLABreaches(ctx)
+	if err != nil || len(breaches) != 1 || breaches[0].MachineID != "sla_running_long" {
+		t.Errorf("Expected only the breach without event to remain, got %+v, %v", breaches, err)
+	}
+}
@@ -0,0 +1,153 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+// ErrSuspended is returned by Transition for a machine that is suspended.
+var ErrSuspended = errors.New("machine suspended")
+
+// Suspend stops the machine from accepting events until Resume is called, for example while an
+// incident is investigated. Events sent to a suspended machine fail in PhaseValidate with an
+// error matching ErrSuspended, in every process sharing the database. Use the DeadLetters
+// middleware to keep them and replay them with RetryDeadLetters once the machine is resumed.
+//
+// A suspend entry with the reason and actor is recorded in the history. Suspending a suspended
+// machine does nothing. ForceState still applies to a suspended machine.
+func (f *FSM) Suspend(ctx context.Context, reason, actor string) error {
+	return f.setSuspended(ctx, true, reason, actor)
+}
+
+// Resume lets a suspended machine accept events again and records a resume entry with the reason
+// and actor in the history. Resuming a machine that is not suspended does nothing.
+func (f *FSM) Resume(ctx context.Context, reason, actor string) error {
+	return f.setSuspended(ctx, false, reason, actor)
+}
+
+// IsSuspended reports whether the machine was suspended when last loaded, suspended or resumed
+// by this instance, or sent an event.
+func (f *FSM) IsSuspended() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.suspended
+}
+
+// setSuspended persists the suspension of the machine, joining the transaction carried by ctx if any.
+func (f *FSM) setSuspended(ctx context.Context, suspended bool, reason, actor string) error {
+	if reason == "" || actor == "" {
+		return errors.New("suspending or resuming a machine requires a reason and an actor")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.client == nil || f.machineID == "" {
+		f.suspended = suspended
+		return nil
+	}
+
+	tx := ent.TxFromContext(ctx)
+	owned := tx == nil
+	if owned {
+		var err error
+		if tx, err = f.client.Tx(ctx); err != nil {
+			return fmt.Errorf("failed to start transaction: %w", err)
+		}
+	}
+	if err := f.persistSuspension(ctx, tx, suspended, reason, actor); err != nil {
+		if owned {
+			tx.Rollback()
+		}
+		return err
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+	f.suspended = suspended
+	return nil
+}
+
+// persistSuspension updates the suspended flag of the machine and records the change in its
+// history within tx. It does nothing if the flag already has the requested value.
+func (f *FSM) persistSuspension(ctx context.Context, tx *ent.Tx, suspended bool, reason, actor string) error {
+	sm, err := tx.StateMachine.Query().Where(statemachine.MachineID(f.machineID)).Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query state machine with ID %s: %w", f.machineID, err)
+	}
+	if sm.Suspended == suspended {
+		return nil
+	}
+
+	kind := statetransition.KindSuspend
+	if !suspended {
+		kind = statetransition.KindResume
+	}
+	err = tx.StateTransition.Create().
+		SetFromState(sm.CurrentState).
+		SetToState(sm.CurrentState).
+		SetEvent("").
+		SetKind(kind).
+		SetReason(reason).
+		SetActor(actor).
+		SetMachine(sm).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record %s of machine %s: %w", kind, f.machineID, err)
+	}
+	if err := sm.Update().SetSuspended(suspended).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to persist %s of machine %s: %w", kind, f.machineID, err)
+	}
+	return nil
+}
+
+// checkSuspended returns an error matching ErrSuspended if the machine is suspended. Persistent
+// machines are looked up in the database, within the transaction of ctx if any, as another
+// process may have suspended or resumed them. f.mu must be held.
+func (f *FSM) checkSuspended(ctx context.Context) error {
+	suspended := f.suspended
+	if f.client != nil && f.machineID != "" {
+		client := f.client
+		if tx := ent.TxFromContext(ctx); tx != nil {
+			client = tx.Client()
+		}
+		var err error
+		suspended, err = client.StateMachine.Query().
+			Where(statemachine.MachineID(f.machineID), statemachine.Suspended(true)).
+			Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query suspension of machine %s: %w", f.machineID, err)
+		}
+	}
+	if suspended {
+		return fmt.Errorf("%w: machine %s", ErrSuspended, f.machineID)
+	}
+	return nil
+}
+
+// Suspend loads the machine with the given ID through the manager and suspends it.
+// See FSM.Suspend.
+func (m *Manager) Suspend(ctx context.Context, machineID, reason, actor string) error {
+	f, err := m.LoadFSM(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	return f.Suspend(ctx, reason, actor)
+}
+
+// Resume loads the machine with the given ID through the manager and resumes it.
+// See FSM.Resume.
+func (m *Manager) Resume(ctx context.Context, machineID, reason, actor string) error {
+	f, err := m.LoadFSM(ctx, machineID)
+	if err != nil {
+		return err
+	}
+	return f.Resume(ctx, reason, actor)
+}
@@ -0,0 +1,215 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+	"github.com/shinhauhuang/go-fsm/ent/statetransition"
+)
+
+func TestSuspend(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	history := func(t *testing.T, id string) []statetransition.Kind {
+		t.Helper()
+		records, err := client.StateTransition.Query().
+			Where(statetransition.HasMachineWith(statemachine.MachineID(id))).
+			Order(statetransition.ByID()).
+			All(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query history: %v", err)
+		}
+		kinds := make([]statetransition.Kind, len(records))
+		for i, r := range records {
+			kinds[i] = r.Kind
+		}
+		return kinds
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		f, _ := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err := f.Suspend(ctx, "", "alice"); err == nil {
+			t.Errorf("Expected error for suspension without reason, got nil")
+		}
+		if err := f.Resume(ctx, "incident closed", ""); err == nil {
+			t.Errorf("Expected error for resumption without actor, got nil")
+		}
+	})
+
+	t.Run("In memory", func(t *testing.T) {
+		f, _ := NewFSM(ctx, nil, "", StateIdle, defineTestTransitions())
+		if err := f.Suspend(ctx, "incident", "alice"); err != nil {
+			t.Fatalf("Suspend failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventStart); !errors.Is(err, ErrSuspended) {
+			t.Errorf("Expected ErrSuspended, got %v", err)
+		}
+		if events := f.AvailableEvents(ctx); len(events) != 0 {
+			t.Errorf("Expected no available events, got %v", events)
+		}
+		if err := f.Resume(ctx, "incident closed", "alice"); err != nil {
+			t.Fatalf("Resume failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Errorf("Transition failed after resume: %v", err)
+		}
+	})
+
+	t.Run("Persisted and recorded", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "suspend_machine", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := f.Suspend(ctx, "incident 7", "alice"); err != nil {
+			t.Fatalf("Suspend failed: %v", err)
+		}
+		if err := f.Suspend(ctx, "incident 7", "alice"); err != nil {
+			t.Fatalf("Suspending again failed: %v", err)
+		}
+
+		// Other instances of the machine see the suspension
+		other, err := LoadFSM(ctx, client, "suspend_machine", defineTestTransitions())
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		if !other.IsSuspended() {
+			t.Errorf("Expected loaded machine to be suspended")
+		}
+		err = other.Transition(ctx, EventStart)
+		var terr *TransitionError
+		if !errors.Is(err, ErrSuspended) || !errors.As(err, &terr) || terr.Phase != PhaseValidate {
+			t.Errorf("Expected ErrSuspended in phase validate, got %v", err)
+		}
+		if _, err := other.DryRun(ctx, EventStart); !errors.Is(err, ErrSuspended) {
+			t.Errorf("Expected ErrSuspended from DryRun, got %v", err)
+		}
+
+		// A resumption by another instance is picked up on the next event
+		if err := other.Resume(ctx, "incident 7 closed", "bob"); err != nil {
+			t.Fatalf("Resume failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed after resume: %v", err)
+		}
+		if f.IsSuspended() {
+			t.Errorf("Expected machine to no longer be suspended")
+		}
+
+		expected := []statetransition.Kind{statetransition.KindSuspend, statetransition.KindResume, statetransition.KindTransition}
+		kinds := history(t, "suspend_machine")
+		if len(kinds) != len(expected) {
+			t.Fatalf("Expected history %v, got %v", expected, kinds)
+		}
+		for i := range expected {
+			if kinds[i] != expected[i] {
+				t.Errorf("Expected history %v, got %v", expected, kinds)
+			}
+		}
+		record, _ := client.StateTransition.Query().
+			Where(statetransition.KindEQ(statetransition.KindSuspend), statetransition.HasMachineWith(statemachine.MachineID("suspend_machine"))).
+			Only(ctx)
+		if record.Reason != "incident 7" || record.Actor != "alice" || record.FromState != string(StateIdle) {
+			t.Errorf("Unexpected suspend record %+v", record)
+		}
+	})
+
+	t.Run("Suspended by another instance", func(t *testing.T) {
+		a, err := NewFSM(ctx, client, "suspend_other", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		// Loaded before the suspension
+		b, err := LoadFSM(ctx, client, "suspend_other", defineTestTransitions())
+		if err != nil {
+			t.Fatalf("LoadFSM failed: %v", err)
+		}
+		var ran []string
+		record := func(name string) Action {
+			return func(ctx context.Context, args ...interface{}) error {
+				ran = append(ran, name)
+				return nil
+			}
+		}
+		b.AddGuard(StateIdle, EventStart, func(ctx context.Context, args ...interface{}) bool {
+			ran = append(ran, "guard")
+			return true
+		})
+		b.OnExit(StateIdle, record("exit"))
+		b.OnTransition(StateIdle, EventStart, record("transition"))
+		b.OnEntry(StateRunning, record("entry"))
+
+		if err := a.Suspend(ctx, "incident 8", "alice"); err != nil {
+			t.Fatalf("Suspend failed: %v", err)
+		}
+		err = b.Transition(ctx, EventStart)
+		var terr *TransitionError
+		if !errors.Is(err, ErrSuspended) || !errors.As(err, &terr) || terr.Phase != PhaseValidate {
+			t.Errorf("Expected ErrSuspended in phase validate, got %v", err)
+		}
+		if len(ran) != 0 {
+			t.Errorf("Expected no guard or action to run, got %v", ran)
+		}
+		if b.CurrentState() != StateIdle || !b.IsSuspended() {
+			t.Errorf("Expected machine to stay idle and be suspended")
+		}
+	})
+
+	t.Run("Suspended while actions run", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "suspend_race", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			// Stands in for another process suspending the machine
+			return ent.TxFromContext(ctx).StateMachine.Update().
+				Where(statemachine.MachineID("suspend_race")).
+				SetSuspended(true).
+				Exec(ctx)
+		})
+		err = f.Transition(ctx, EventStart)
+		if !errors.Is(err, ErrSuspended) {
+			t.Fatalf("Expected ErrSuspended, got %v", err)
+		}
+		if f.CurrentState() != StateIdle || !f.IsSuspended() {
+			t.Errorf("Expected machine to stay idle and be suspended")
+		}
+	})
+
+	t.Run("Replay with dead letters", func(t *testing.T) {
+		m := NewManager(client)
+		m.Use(DeadLetters(client))
+		if err := m.Register(&Definition{Name: "suspend", Initial: StateIdle, Transitions: defineTestTransitions()}); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		f, err := m.NewFSM(ctx, "suspend", "suspend_replay")
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		if err := m.Suspend(ctx, "suspend_replay", "incident", "alice"); err != nil {
+			t.Fatalf("Suspend failed: %v", err)
+		}
+		if err := f.Transition(ctx, EventStart); !errors.Is(err, ErrSuspended) {
+			t.Fatalf("Expected ErrSuspended, got %v", err)
+		}
+
+		letters, err := m.ListDeadLetters(ctx, DeadLetterFilter{MachineID: "suspend_replay"})
+		if err != nil || len(letters) != 1 {
+			t.Fatalf("Expected 1 dead letter, got %v, %v", letters, err)
+		}
+		if err := m.Resume(ctx, "suspend_replay", "incident closed", "alice"); err != nil {
+			t.Fatalf("Resume failed: %v", err)
+		}
+		if n, err := m.RetryDeadLetters(ctx, letters[0].ID); err != nil || n != 1 {
+			t.Fatalf("Expected 1 dead letter to be replayed, got %d, %v", n, err)
+		}
+		sm, _ := client.StateMachine.Query().Where(statemachine.MachineID("suspend_replay")).Only(ctx)
+		if State(sm.CurrentState) != StateRunning {
+			t.Errorf("Expected state %s, got %s", StateRunning, sm.CurrentState)
+		}
+	})
+}
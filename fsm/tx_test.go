@@ -0,0 +1,135 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+	"github.com/shinhauhuang/go-fsm/ent/statemachine"
+)
+
+// createRecord returns an action creating a state machine row named id in the transaction of ctx,
+// standing in for a domain write.
+func createRecord(id string) Action {
+	return func(ctx context.Context, args ...interface{}) error {
+		tx := ent.TxFromContext(ctx)
+		if tx == nil {
+			return errors.New("no transaction in context")
+		}
+		return tx.StateMachine.Create().SetMachineID(id).SetCurrentState("domain").Exec(ctx)
+	}
+}
+
+func TestTransactionalActions(t *testing.T) {
+	ctx := context.Background()
+	client := setupTestClient(t)
+	defer client.Close()
+
+	recordExists := func(t *testing.T, id string) bool {
+		t.Helper()
+		exists, err := client.StateMachine.Query().Where(statemachine.MachineID(id)).Exist(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query record: %v", err)
+		}
+		return exists
+	}
+	persistedState := func(t *testing.T, machineID string) State {
+		t.Helper()
+		sm, err := client.StateMachine.Query().Where(statemachine.MachineID(machineID)).Only(ctx)
+		if err != nil {
+			t.Fatalf("Failed to query state machine: %v", err)
+		}
+		return State(sm.CurrentState)
+	}
+
+	t.Run("Domain writes commit with the state change", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "tx_machine_1", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, createRecord("tx_record_1"))
+
+		if err := f.Transition(ctx, EventStart); err != nil {
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if !recordExists(t, "tx_record_1") {
+			t.Errorf("Expected domain write to be committed")
+		}
+		if persistedState(t, "tx_machine_1") != StateRunning {
+			t.Errorf("Expected persisted state %s", StateRunning)
+		}
+	})
+
+	t.Run("Failing action rolls back domain writes", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "tx_machine_2", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnExit(StateIdle, createRecord("tx_record_2"))
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			return errors.New("entry action error")
+		})
+
+		if err := f.Transition(ctx, EventStart); err == nil {
+			t.Fatalf("Expected error from entry action, got nil")
+		}
+		if recordExists(t, "tx_record_2") {
+			t.Errorf("Expected domain write to be rolled back")
+		}
+	})
+
+	t.Run("Failing persistence rolls back domain writes", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "tx_machine_3", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, createRecord("tx_record_3"))
+		f.OnEntry(StateRunning, func(ctx context.Context, args ...interface{}) error {
+			// Removing the machine row makes the state update fail
+			_, err := ent.TxFromContext(ctx).StateMachine.Delete().Where(statemachine.MachineID("tx_machine_3")).Exec(ctx)
+			return err
+		})
+
+		err = f.Transition(ctx, EventStart)
+		var terr *TransitionError
+		if !errors.As(err, &terr) || terr.Phase != PhasePersist {
+			t.Fatalf("Expected persist error, got %v", err)
+		}
+		if recordExists(t, "tx_record_3") {
+			t.Errorf("Expected domain write to be rolled back")
+		}
+		if persistedState(t, "tx_machine_3") != StateIdle {
+			t.Errorf("Expected persisted state %s", StateIdle)
+		}
+		if f.CurrentState() != StateIdle {
+			t.Errorf("Expected state %s, got %s", StateIdle, f.CurrentState())
+		}
+	})
+
+	t.Run("Joining the caller's transaction", func(t *testing.T) {
+		f, err := NewFSM(ctx, client, "tx_machine_4", StateIdle, defineTestTransitions())
+		if err != nil {
+			t.Fatalf("NewFSM failed: %v", err)
+		}
+		f.OnEntry(StateRunning, createRecord("tx_record_4"))
+
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to start transaction: %v", err)
+		}
+		if err := f.Transition(ent.NewTxContext(ctx, tx), EventStart); err != nil {
+			tx.Rollback()
+			t.Fatalf("Transition failed: %v", err)
+		}
+		if err := tx.Rollback(); err != nil {
+			t.Fatalf("Rollback failed: %v", err)
+		}
+		if recordExists(t, "tx_record_4") {
+			t.Errorf("Expected domain write to be rolled back with the caller's transaction")
+		}
+		if persistedState(t, "tx_machine_4") != StateIdle {
+			t.Errorf("Expected persisted state %s", StateIdle)
+		}
+	})
+}
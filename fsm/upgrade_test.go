@@ -0,0 +1,93 @@
+package fsm
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/shinhauhuang/go-fsm/ent"
+)
+
+// baselineSchema is the SQLite schema of the tables holding machines before creation, update
+// and state entry times were recorded.
+var baselineSchema = []string{
+	"CREATE TABLE `state_machines` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `machine_id` text NOT NULL, `current_state` text NOT NULL, `definition_name` text NOT NULL DEFAULT (''), `definition_version` integer NOT NULL DEFAULT (0), `data` blob NULL)",
+	"CREATE UNIQUE INDEX `state_machines_machine_id_key` ON `state_machines` (`machine_id`)",
+	"CREATE TABLE `state_transitions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `from_state` text NOT NULL, `to_state` text NOT NULL, `event` text NOT NULL, `timestamp` datetime NOT NULL, `kind` text NOT NULL DEFAULT ('transition'), `reason` text NULL, `error` text NULL, `compensation` text NOT NULL DEFAULT ('none'), `state_machine_history` integer NULL, CONSTRAINT `state_transitions_state_machines_history` FOREIGN KEY (`state_machine_history`) REFERENCES `state_machines` (`id`) ON DELETE SET NULL)",
+	"CREATE TABLE `processed_events` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `key` text NOT NULL, `event` text NOT NULL, `to_state` text NOT NULL, `processed_at` datetime NOT NULL, `state_machine_processed_events` integer NOT NULL, CONSTRAINT `processed_events_state_machines_processed_events` FOREIGN KEY (`state_machine_processed_events`) REFERENCES `state_machines` (`id`) ON DELETE NO ACTION)",
+	"CREATE UNIQUE INDEX `processedevent_key_state_machine_processed_events` ON `processed_events` (`key`, `state_machine_processed_events`)",
+	"CREATE INDEX `processedevent_processed_at` ON `processed_events` (`processed_at`)",
+	"CREATE TABLE `jobs` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `state` text NOT NULL, `payload` blob NULL, `status` text NOT NULL DEFAULT ('pending'), `attempts` integer NOT NULL DEFAULT (0), `next_run_at` datetime NOT NULL, `last_error` text NULL, `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `state_machine_jobs` integer NOT NULL, CONSTRAINT `jobs_state_machines_jobs` FOREIGN KEY (`state_machine_jobs`) REFERENCES `state_machines` (`id`) ON DELETE NO ACTION)",
+	"CREATE INDEX `job_status_next_run_at` ON `jobs` (`status`, `next_run_at`)",
+}
+
+func TestSchemaUpgrade(t *testing.T) {
+	ctx := context.Background()
+	const dsn = "file:upgrade?mode=memory&cache=shared&_fk=1"
+
+	// Keep a connection open so the in-memory database lives for the whole test
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	for _, stmt := range baselineSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Failed to create baseline schema: %v", err)
+		}
+	}
+
+	// A paused machine that started 3h ago and was paused 1h ago
+	started := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	paused := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if _, err := db.Exec("INSERT INTO `state_machines` (`machine_id`, `current_state`) VALUES ('upgrade_machine', ?)", string(StatePaused)); err != nil {
+		t.Fatalf("Failed to insert machine: %v", err)
+	}
+	history := []struct {
+		from, to State
+		event    Event
+		at       time.Time
+	}{
+		{StateIdle, StateRunning, EventStart, started},
+		{StateRunning, StatePaused, EventPause, paused},
+	}
+	for _, h := range history {
+		_, err := db.Exec("INSERT INTO `state_transitions` (`from_state`, `to_state`, `event`, `timestamp`, `state_machine_history`) VALUES (?, ?, ?, ?, 1)",
+			string(h.from), string(h.to), string(h.event), h.at)
+		if err != nil {
+			t.Fatalf("Failed to insert history: %v", err)
+		}
+	}
+
+	client, err := ent.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open client: %v", err)
+	}
+	defer client.Close()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("Upgrading a populated database failed: %v", err)
+	}
+
+	f, err := LoadFSM(ctx, client, "upgrade_machine", defineTestTransitions())
+	if err != nil {
+		t.Fatalf("LoadFSM failed: %v", err)
+	}
+	if f.CurrentState() != StatePaused || !f.EnteredAt().Equal(paused) {
+		t.Errorf("Expected %s entered at %v, got %s entered at %v", StatePaused, paused, f.CurrentState(), f.EnteredAt())
+	}
+	visits, err := Visits(ctx, client, "upgrade_machine")
+	if err != nil {
+		t.Fatalf("Visits failed: %v", err)
+	}
+	if len(visits) != 3 || !visits[0].EnteredAt.Equal(started) || visits[1].Duration != 2*time.Hour {
+		t.Errorf("Unexpected visits %+v", visits)
+	}
+
+	if n, err := BackfillEnteredAt(ctx, client); err != nil || n != 1 {
+		t.Fatalf("Expected 1 machine backfilled, got %d, %v", n, err)
+	}
+	if err := f.Transition(ctx, EventResume); err != nil {
+		t.Fatalf("Transition after upgrade failed: %v", err)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 
 	"github.com/shinhauhuang/go-fsm/ent"
-	"github.com/shinhauhuang/go-fsm/ent/statemachine"
 	"github.com/shinhauhuang/go-fsm/fsm"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -115,15 +114,10 @@ func main() {
 
 	// --- Query and display transition history ---
 	fmt.Println("\n--- Querying Transition History ---")
-	machine, err := client.StateMachine.Query().
-		Where(statemachine.MachineIDEQ(machineID)).
-		WithHistory().
-		Only(ctx)
-	if err != nil {
-		log.Fatalf("failed to query machine history: %v", err)
-	}
-
-	for _, record := range machine.Edges.History {
+	for record, err := range turnstile.HistoryEntries(ctx, fsm.HistoryFilter{}) {
+		if err != nil {
+			log.Fatalf("failed to query machine history: %v", err)
+		}
 		fmt.Printf("  - From: %s, To: %s, Event: %s, Time: %s\n",
 			record.FromState, record.ToState, record.Event, record.Timestamp.Format("15:04:05"))
 	}